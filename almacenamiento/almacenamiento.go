@@ -0,0 +1,42 @@
+package almacenamiento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrNoEncontrado se devuelve al abrir o eliminar un archivo que no existe.
+var ErrNoEncontrado = errors.New("archivo no encontrado")
+
+// Almacenamiento guarda archivos identificados por una clave con segmentos separados por "/".
+type Almacenamiento interface {
+	// Guardar escribe el contenido bajo la clave, reemplazando el archivo anterior si existía, y
+	// devuelve la cantidad de bytes escritos.
+	Guardar(ctx context.Context, clave string, contenido io.Reader) (int64, error)
+	// Abrir devuelve el contenido guardado bajo la clave. Quien lo llama debe cerrarlo.
+	Abrir(ctx context.Context, clave string) (io.ReadCloser, error)
+	// Eliminar borra el archivo guardado bajo la clave.
+	Eliminar(ctx context.Context, clave string) error
+}
+
+// NewDesdeEntorno crea el almacenamiento configurado en las variables de entorno:
+//   - ALMACENAMIENTO_TIPO: "local" (por defecto)
+//   - ALMACENAMIENTO_DIRECTORIO: directorio del almacenamiento local (por defecto "archivos")
+func NewDesdeEntorno() (Almacenamiento, error) {
+	switch tipo := valorEntorno("ALMACENAMIENTO_TIPO", "local"); tipo {
+	case "local":
+		return NewLocal(valorEntorno("ALMACENAMIENTO_DIRECTORIO", "archivos")), nil
+	default:
+		return nil, fmt.Errorf("tipo de almacenamiento desconocido: %s", tipo)
+	}
+}
+
+func valorEntorno(clave, porDefecto string) string {
+	if valor := os.Getenv(clave); valor != "" {
+		return valor
+	}
+	return porDefecto
+}
@@ -0,0 +1,93 @@
+package almacenamiento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local guarda los archivos en un directorio del disco, reproduciendo la estructura de las claves.
+type Local struct {
+	Directorio string
+}
+
+// NewLocal crea un almacenamiento en el directorio indicado.
+func NewLocal(directorio string) *Local {
+	return &Local{Directorio: directorio}
+}
+
+// ruta convierte una clave en una ruta dentro del directorio, rechazando las que intentan salir de él.
+func (l *Local) ruta(clave string) (string, error) {
+	if clave == "" || strings.HasPrefix(clave, "/") {
+		return "", fmt.Errorf("clave de almacenamiento inválida: %q", clave)
+	}
+	for _, segmento := range strings.Split(clave, "/") {
+		if segmento == "" || segmento == "." || segmento == ".." {
+			return "", fmt.Errorf("clave de almacenamiento inválida: %q", clave)
+		}
+	}
+	return filepath.Join(l.Directorio, filepath.FromSlash(clave)), nil
+}
+
+func (l *Local) Guardar(ctx context.Context, clave string, contenido io.Reader) (int64, error) {
+	ruta, err := l.ruta(clave)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(ruta), 0o755); err != nil {
+		return 0, err
+	}
+
+	// Se escribe en un archivo temporal y se renombra para no dejar archivos a medio escribir
+	temporal, err := os.CreateTemp(filepath.Dir(ruta), ".subida-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(temporal.Name())
+
+	escritos, err := io.Copy(temporal, contenido)
+	if cerrarErr := temporal.Close(); err == nil {
+		err = cerrarErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(temporal.Name(), ruta); err != nil {
+		return 0, err
+	}
+	return escritos, nil
+}
+
+func (l *Local) Abrir(ctx context.Context, clave string) (io.ReadCloser, error) {
+	ruta, err := l.ruta(clave)
+	if err != nil {
+		return nil, err
+	}
+
+	archivo, err := os.Open(ruta)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoEncontrado
+	}
+	return archivo, err
+}
+
+func (l *Local) Eliminar(ctx context.Context, clave string) error {
+	ruta, err := l.ruta(clave)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(ruta)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNoEncontrado
+	}
+	return err
+}
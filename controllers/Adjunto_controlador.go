@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdjuntoControlador gestiona las rutas de los archivos adjuntos de las clases.
+type AdjuntoControlador struct {
+	servicio *services.AdjuntoService
+}
+
+// NewAdjuntoControlador crea un nuevo controlador para los adjuntos de clase.
+func NewAdjuntoControlador(servicio *services.AdjuntoService) *AdjuntoControlador {
+	return &AdjuntoControlador{servicio: servicio}
+}
+
+// SubirAdjunto sube un archivo adjunto a una clase.
+// @Summary Subir un adjunto a una clase
+// @Description Sube un archivo (PDF, texto, imágenes, documentos de Office o ZIP, 50 MB como máximo) y lo agrega a los adjuntos de la clase con su suma SHA-256. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Clases
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param email formData string true "Correo del instructor"
+// @Param password formData string true "Contraseña del instructor"
+// @Param archivo formData file true "Archivo adjunto"
+// @Success 201 {object} models.Adjunto
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 415 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/adjuntos [post]
+func (ctrl *AdjuntoControlador) SubirAdjunto(c *gin.Context) {
+	claseID := c.Param("id")
+	if !leerFormularioSubida(c, services.MaxTamanoAdjunto, "el archivo") {
+		return
+	}
+	email := c.PostForm("email")
+	password := c.PostForm("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	encabezado, err := c.FormFile("archivo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El archivo es requerido"})
+		return
+	}
+
+	archivo, err := encabezado.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer archivo.Close()
+
+	adjunto, err := ctrl.servicio.SubirAdjunto(c.Request.Context(), claseID, email, password, encabezado.Filename, encabezado.Size, archivo)
+	if err != nil {
+		responderErrorAdjunto(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, adjunto)
+}
+
+// DescargarAdjunto descarga un archivo adjunto de una clase.
+// @Summary Descargar un adjunto de una clase
+// @Description Devuelve el archivo adjunto con su suma SHA-256 como ETag. Solo pueden descargarlo los inscritos en el curso, su instructor y los administradores; los inscritos no pueden descargar los adjuntos de una unidad bloqueada.
+// @Tags Clases
+// @Produce octet-stream
+// @Param id path string true "ID de la clase"
+// @Param adjunto_id path string true "ID del adjunto"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {file} file
+// @Success 304 "El archivo no cambió"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/adjuntos/{adjunto_id} [get]
+func (ctrl *AdjuntoControlador) DescargarAdjunto(c *gin.Context) {
+	claseID := c.Param("id")
+	adjuntoID := c.Param("adjunto_id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	adjunto, archivo, err := ctrl.servicio.AbrirAdjunto(c.Request.Context(), claseID, adjuntoID, email, password)
+	if err != nil {
+		responderErrorAdjunto(c, err)
+		return
+	}
+	defer archivo.Close()
+
+	etag := `"` + adjunto.SHA256 + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, max-age=3600")
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, adjunto.Tamano, adjunto.TipoContenido, archivo, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": adjunto.Nombre}),
+	})
+}
+
+// EliminarAdjunto elimina un archivo adjunto de una clase.
+// @Summary Eliminar un adjunto de una clase
+// @Description Quita el adjunto de la clase y borra su archivo. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Clases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param adjunto_id path string true "ID del adjunto"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/adjuntos/{adjunto_id} [delete]
+func (ctrl *AdjuntoControlador) EliminarAdjunto(c *gin.Context) {
+	claseID := c.Param("id")
+	adjuntoID := c.Param("adjunto_id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.EliminarAdjunto(c.Request.Context(), claseID, adjuntoID, input.Email, input.Password); err != nil {
+		responderErrorAdjunto(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Adjunto eliminado exitosamente"})
+}
+
+func responderErrorAdjunto(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "clase no encontrada", "unidad no encontrada", "curso no encontrado", "adjunto no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede gestionar los adjuntos", "el usuario no está inscrito en este curso":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "ID de clase inválido", "ID de curso inválido":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "tipo de archivo no permitido":
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		if strings.HasPrefix(err.Error(), "el archivo supera el tamaño máximo") {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "la clase está bloqueada") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// margenFormularioSubida es lo que se admite en el cuerpo de una subida además del archivo, para los
+// demás campos del formulario y los encabezados de cada parte.
+const margenFormularioSubida = 1 << 20
+
+// leerFormularioSubida limita el cuerpo de la petición al tamaño máximo del archivo más un margen y
+// analiza el formulario, para no leer a memoria ni a disco un cuerpo más grande de lo permitido. Debe
+// llamarse antes de leer cualquier campo del formulario. Si el cuerpo supera el límite responde con
+// un error 413, y si el formulario es inválido con un 400; en ambos casos devuelve false.
+func leerFormularioSubida(c *gin.Context, limite int64, objeto string) bool {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limite+margenFormularioSubida)
+	if _, err := c.MultipartForm(); err != nil {
+		var excedido *http.MaxBytesError
+		if errors.As(err, &excedido) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("%s supera el tamaño máximo de %d MB", objeto, limite>>20)})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Formulario inválido: " + err.Error()})
+		return false
+	}
+	return true
+}
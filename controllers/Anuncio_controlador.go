@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"net/http"
+
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnuncioControlador gestiona las rutas de los anuncios de los cursos.
+type AnuncioControlador struct {
+	servicio *services.AnuncioService
+}
+
+// NewAnuncioControlador crea un nuevo controlador para los anuncios.
+func NewAnuncioControlador(servicio *services.AnuncioService) *AnuncioControlador {
+	return &AnuncioControlador{servicio: servicio}
+}
+
+// CrearAnuncio publica un anuncio en un curso.
+// @Summary Publicar un anuncio
+// @Description Publica un anuncio en el curso y notifica a todos sus inscritos. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Anuncios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param anuncio body request.AnuncioRequest true "Credenciales del instructor, título y contenido"
+// @Success 201 {object} models.Anuncio
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/anuncios [post]
+func (ctrl *AnuncioControlador) CrearAnuncio(c *gin.Context) {
+	cursoID := c.Param("id")
+
+	var input request.AnuncioRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	anuncio, err := ctrl.servicio.CrearAnuncio(c.Request.Context(), cursoID, input.Email, input.Password, input.Titulo, input.Contenido)
+	if err != nil {
+		responderErrorAnuncio(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, anuncio)
+}
+
+// ObtenerAnunciosPorCurso obtiene los anuncios de un curso.
+// @Summary Obtener los anuncios de un curso
+// @Description Devuelve los anuncios de un curso, del más reciente al más antiguo. Los de un curso no publicado solo los ve quien puede ver el curso.
+// @Tags Anuncios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.Anuncio
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/anuncios [get]
+func (ctrl *AnuncioControlador) ObtenerAnunciosPorCurso(c *gin.Context) {
+	anuncios, err := ctrl.servicio.ObtenerAnunciosPorCurso(c.Request.Context(), c.Param("id"), c.Query("email"), c.Query("password"))
+	if err != nil {
+		responderErrorAnuncio(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, anuncios)
+}
+
+// EditarAnuncio cambia el título y el contenido de un anuncio.
+// @Summary Editar un anuncio
+// @Description Cambia el título y el contenido de un anuncio. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Anuncios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del anuncio"
+// @Param anuncio body request.AnuncioRequest true "Credenciales del instructor, título y contenido"
+// @Success 200 {object} models.Anuncio
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/anuncios/{id} [put]
+func (ctrl *AnuncioControlador) EditarAnuncio(c *gin.Context) {
+	anuncioID := c.Param("id")
+
+	var input request.AnuncioRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	anuncio, err := ctrl.servicio.EditarAnuncio(c.Request.Context(), anuncioID, input.Email, input.Password, input.Titulo, input.Contenido)
+	if err != nil {
+		responderErrorAnuncio(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, anuncio)
+}
+
+// EliminarAnuncio elimina un anuncio.
+// @Summary Eliminar un anuncio
+// @Description Elimina un anuncio. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Anuncios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del anuncio"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/anuncios/{id} [delete]
+func (ctrl *AnuncioControlador) EliminarAnuncio(c *gin.Context) {
+	anuncioID := c.Param("id")
+
+	var credenciales request.CredencialesRequest
+	if err := c.ShouldBindJSON(&credenciales); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.EliminarAnuncio(c.Request.Context(), anuncioID, credenciales.Email, credenciales.Password); err != nil {
+		responderErrorAnuncio(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Anuncio eliminado exitosamente"})
+}
+
+// ObtenerFeed obtiene los anuncios de los cursos en los que el usuario está inscrito.
+// @Summary Feed de anuncios
+// @Description Devuelve los anuncios más recientes de los cursos en los que el usuario está inscrito, indicando cuáles ya leyó.
+// @Tags Anuncios
+// @Accept json
+// @Produce json
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Param limite query int false "Cantidad máxima de anuncios (10 por defecto, máximo 50)"
+// @Success 200 {array} models.AnuncioFeed
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/usuarios/anuncios [get]
+func (ctrl *AnuncioControlador) ObtenerFeed(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	limite, ok := parsearLimite(c)
+	if !ok {
+		return
+	}
+
+	feed, err := ctrl.servicio.ObtenerFeed(c.Request.Context(), email, password, limite)
+	if err != nil {
+		responderErrorAnuncio(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// MarcarAnunciosLeidos marca anuncios como leídos por el usuario.
+// @Summary Marcar anuncios como leídos
+// @Description Marca como leídos los anuncios indicados, o todos los de los cursos inscritos si no se indica ninguno.
+// @Tags Anuncios
+// @Accept json
+// @Produce json
+// @Param anuncios body request.MarcarAnunciosLeidosRequest true "Credenciales del usuario e IDs de los anuncios"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/usuarios/anuncios/leidos [post]
+func (ctrl *AnuncioControlador) MarcarAnunciosLeidos(c *gin.Context) {
+	var input request.MarcarAnunciosLeidosRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.MarcarAnunciosLeidos(c.Request.Context(), input.Email, input.Password, input.IDs); err != nil {
+		responderErrorAnuncio(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Anuncios marcados como leídos"})
+}
+
+func responderErrorAnuncio(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "curso no encontrado", "anuncio no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "ID inválido", "ID de anuncio inválido":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede gestionar sus anuncios":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"go-API/models"
+	"go-API/request"
 	"go-API/services"
 
 	"github.com/gin-gonic/gin"
@@ -21,11 +22,13 @@ func NewClaseControlador(servicio *services.ClaseService) *ClaseControlador {
 
 // ObtenerClasesPorUnidad obtiene todas las clases de una unidad.
 // @Summary Devuelve las clases de una unidad
-// @Description Devuelve todas las clases asociadas a una unidad
+// @Description Devuelve todas las clases asociadas a una unidad. Si la unidad está bloqueada para el usuario (o para un usuario no inscrito, sin credenciales) las clases se devuelven sin video, adjuntos ni subtítulos y con la fecha en que se desbloquean, si se conoce
 // @Tags Clases
 // @Accept json
 // @Produce json
 // @Param id path string true "ID de la unidad"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
 // @Success 200 {array} response.ClaseResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
@@ -34,9 +37,9 @@ func NewClaseControlador(servicio *services.ClaseService) *ClaseControlador {
 func (cc *ClaseControlador) ObtenerClasesPorUnidad(c *gin.Context) {
 	id := c.Param("id") // ID de la unidad
 
-	clases, err := cc.servicio.ObtenerClasesPorUnidad(id)
+	clases, err := cc.servicio.ObtenerClasesPorUnidad(id, c.Query("email"), c.Query("password"))
 	if err != nil {
-		if err.Error() == "unidad no encontrada" {
+		if err.Error() == "unidad no encontrada" || err.Error() == "usuario no encontrado" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -62,12 +65,20 @@ func (cc *ClaseControlador) ObtenerClasesPorUnidad(c *gin.Context) {
 func (cc *ClaseControlador) CrearClaseParaUnidad(c *gin.Context) {
 	unidadID := c.Param("id") // ID de la unidad
 
-	var clase models.Clase
-	if err := c.ShouldBindJSON(&clase); err != nil {
+	var input request.CreateClaseRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
 		return
 	}
 
+	// Los contadores de "me gusta" se calculan a partir de las reacciones de los usuarios
+	clase := models.Clase{
+		Nombre:      input.Nombre,
+		Descripcion: input.Descripcion,
+		VideoURL:    input.VideoURL,
+		Duracion:    input.Duracion,
+	}
+
 	// Llamar al servicio para crear la clase
 	result, err := cc.servicio.CrearClaseParaUnidad(unidadID, &clase)
 	if err != nil {
@@ -77,3 +88,84 @@ func (cc *ClaseControlador) CrearClaseParaUnidad(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"inserted_id": result.InsertedID})
 }
+
+// ActualizarClase edita una clase.
+// @Summary Editar una clase
+// @Description Reemplaza el nombre, la descripción, el video y la duración de una clase. La duración de la unidad y del curso se actualiza. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Clases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param clase body request.UpdateClaseRequest true "Credenciales del instructor y datos de la clase"
+// @Success 200 {object} models.Clase
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id} [put]
+func (cc *ClaseControlador) ActualizarClase(c *gin.Context) {
+	claseID := c.Param("id")
+
+	var input request.UpdateClaseRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	clase, err := cc.servicio.ActualizarClase(c.Request.Context(), claseID, input.Email, input.Password, models.Clase{
+		Nombre:      input.Nombre,
+		Descripcion: input.Descripcion,
+		VideoURL:    input.VideoURL,
+		Duracion:    input.Duracion,
+	})
+	if err != nil {
+		responderErrorClase(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, clase)
+}
+
+// EliminarClase elimina una clase.
+// @Summary Eliminar una clase
+// @Description Elimina una clase con sus comentarios, adjuntos, subtítulos y las notas y marcadores de los usuarios, y la descuenta de la cantidad de clases y la duración de su unidad y su curso. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Clases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id} [delete]
+func (cc *ClaseControlador) EliminarClase(c *gin.Context) {
+	claseID := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := cc.servicio.EliminarClase(c.Request.Context(), claseID, input.Email, input.Password); err != nil {
+		responderErrorClase(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Clase eliminada exitosamente"})
+}
+
+func responderErrorClase(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "clase no encontrada", "unidad no encontrada", "curso no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede modificar las clases":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "ID de clase inválido":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
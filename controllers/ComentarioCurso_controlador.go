@@ -3,6 +3,8 @@ package controllers
 import (
 	"net/http"
 
+	"go-API/request"
+	"go-API/response"
 	"go-API/services"
 
 	"github.com/gin-gonic/gin"
@@ -18,38 +20,40 @@ func NewComentarioCursoControlador(servicio *services.ComentarioCursoService) *C
 
 // CrearComentarioCurso crea un comentario para un curso.
 // @Summary Crear un comentario para un curso
-// @Description Agrega un comentario a un curso por su ID. El usuario se identifica por email.
+// @Description Agrega un comentario a un curso por su ID. El usuario se identifica por email y password y debe estar inscrito en el curso.
 // @Tags ComentariosCurso
 // @Accept json
 // @Produce json
-// @Param comentario body models.ComentarioCurso true "Comentario a crear (usuario (email), cursoID, texto)"
-// @Success 200 {object} map[string]string "message: Comentario creado exitosamente"
+// @Param comentario body request.CreateComentarioCursoRequest true "Comentario a crear (usuario (email), password, cursoID, texto)"
+// @Success 200 {object} map[string]string "message: Comentario creado exitosamente, id: ID del comentario, moderacion: aprobado o pendiente"
 // @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 403 {object} map[string]string "error: Forbidden"
+// @Failure 404 {object} map[string]string "error: Not Found"
 // @Failure 500 {object} map[string]string "error: Internal Server Error"
 // @Router /api/comentarios_curso [post]
 func (ctrl *ComentarioCursoControlador) CrearComentarioCurso(c *gin.Context) {
-	var request struct {
-		Email   string `json:"email"`
-		CursoID string `json:"curso_id"`
-		Texto   string `json:"texto"`
-	}
-
-	if err := c.ShouldBindJSON(&request); err != nil {
+	var input request.CreateComentarioCursoRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err := ctrl.servicio.CrearComentarioCurso(request.Email, request.CursoID, request.Texto)
+	id, moderacion, err := ctrl.servicio.CrearComentarioCurso(input.Email, input.Password, input.CursoID, input.Texto)
 	if err != nil {
-		if err.Error() == "el comentario debe tener al menos 15 caracteres" {
+		switch err.Error() {
+		case "el comentario debe tener al menos 15 caracteres", "ID de curso inválido":
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		} else {
+		case "el usuario no está inscrito en este curso":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "usuario no encontrado", "curso no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Comentario creado exitosamente"})
+	c.JSON(http.StatusOK, gin.H{"message": "Comentario creado exitosamente", "id": id, "moderacion": moderacion})
 }
 
 // ObtenerComentariosCursoPorUsuario obtiene todos los comentarios hechos por un usuario.
@@ -59,7 +63,7 @@ func (ctrl *ComentarioCursoControlador) CrearComentarioCurso(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param email path string true "Email del usuario"
-// @Success 200 {array} map[string]interface{} "curso: nombre del curso, comentario: texto del comentario"
+// @Success 200 {array} models.ComentarioCurso
 // @Failure 400 {object} map[string]string "error: Bad Request"
 // @Failure 500 {object} map[string]string "error: Internal Server Error"
 // @Router /api/comentarios_curso/usuarios/{email} [get]
@@ -74,3 +78,163 @@ func (ctrl *ComentarioCursoControlador) ObtenerComentariosCursoPorUsuario(c *gin
 
 	c.JSON(http.StatusOK, comentarios)
 }
+
+// ObtenerComentariosPorCurso obtiene los comentarios de un curso con paginación por cursor.
+// @Summary Obtener los comentarios de un curso
+// @Description Devuelve los comentarios visibles de un curso con su fecha y el nombre del autor, ordenados del más reciente al más antiguo (recientes) o al revés (antiguos). Para obtener la página siguiente se envía el siguiente_cursor de la respuesta anterior. Los comentarios de un borrador solo los ven su instructor y los administradores, y los de un curso archivado también sus inscritos.
+// @Tags ComentariosCurso
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param orden query string false "recientes (por defecto) o antiguos"
+// @Param cursor query string false "Cursor de la página a obtener (vacío para la primera)"
+// @Param limite query int false "Comentarios por página (máximo 50)"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {object} response.ComentariosCursoPaginadosResponse
+// @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/cursos/{id}/comentarios [get]
+func (ctrl *ComentarioCursoControlador) ObtenerComentariosPorCurso(c *gin.Context) {
+	cursoID := c.Param("id")
+
+	orden := c.DefaultQuery("orden", "recientes")
+	if orden != "recientes" && orden != "antiguos" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El parámetro orden debe ser recientes o antiguos"})
+		return
+	}
+
+	limite, ok := parsearLimite(c)
+	if !ok {
+		return
+	}
+
+	comentarios, siguiente, err := ctrl.servicio.ObtenerComentariosPorCurso(cursoID, c.Query("email"), c.Query("password"), orden, c.Query("cursor"), limite)
+	if err != nil {
+		switch err.Error() {
+		case "cursor inválido":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "curso no encontrado", "usuario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ComentariosCursoPaginadosResponse{
+		Comentarios:     comentarios,
+		Limite:          limite,
+		SiguienteCursor: siguiente,
+	})
+}
+
+// EditarComentarioCurso edita el texto de un comentario de curso.
+// @Summary Editar un comentario de curso
+// @Description Reemplaza el texto de un comentario de curso. Solo el autor puede editarlo; el texto anterior queda en el historial de revisiones.
+// @Tags ComentariosCurso
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param comentario body request.UpdateComentarioCursoRequest true "Credenciales del autor y nuevo texto"
+// @Success 200 {object} models.ComentarioCurso
+// @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 403 {object} map[string]string "error: Forbidden"
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/comentarios_curso/{id} [put]
+func (ctrl *ComentarioCursoControlador) EditarComentarioCurso(c *gin.Context) {
+	id := c.Param("id")
+
+	var input request.UpdateComentarioCursoRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comentario, err := ctrl.servicio.EditarComentarioCurso(id, input.Email, input.Password, input.Texto)
+	if err != nil {
+		switch err.Error() {
+		case "el comentario debe tener al menos 15 caracteres":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "solo el autor puede editar el comentario":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "usuario no encontrado", "comentario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, comentario)
+}
+
+// EliminarComentarioCurso elimina un comentario de curso.
+// @Summary Eliminar un comentario de curso
+// @Description Elimina un comentario de curso. Puede hacerlo su autor o un moderador.
+// @Tags ComentariosCurso
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del autor o del moderador"
+// @Success 200 {object} map[string]string "message: Comentario eliminado exitosamente"
+// @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 403 {object} map[string]string "error: Forbidden"
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/comentarios_curso/{id} [delete]
+func (ctrl *ComentarioCursoControlador) EliminarComentarioCurso(c *gin.Context) {
+	id := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := ctrl.servicio.EliminarComentarioCurso(id, input.Email, input.Password)
+	if err != nil {
+		switch err.Error() {
+		case "solo el autor o un moderador puede eliminar el comentario":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "usuario no encontrado", "comentario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comentario eliminado exitosamente"})
+}
+
+// ObtenerRevisionesComentarioCurso obtiene el historial de revisiones de un comentario de curso.
+// @Summary Obtener el historial de un comentario de curso
+// @Description Devuelve los textos anteriores de un comentario de curso con la fecha en que se escribió cada uno, si el usuario puede ver el curso
+// @Tags ComentariosCurso
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.RevisionComentario
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/comentarios_curso/{id}/revisiones [get]
+func (ctrl *ComentarioCursoControlador) ObtenerRevisionesComentarioCurso(c *gin.Context) {
+	id := c.Param("id")
+
+	revisiones, err := ctrl.servicio.ObtenerRevisionesComentarioCurso(c.Request.Context(), id, c.Query("email"), c.Query("password"))
+	if err != nil {
+		if err.Error() == "comentario no encontrado" || err.Error() == "curso no encontrado" || err.Error() == "usuario no encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, revisiones)
+}
@@ -2,9 +2,12 @@ package controllers
 
 import (
     "go-API/models"
+    "go-API/request"
     "go-API/services"
     "go-API/response"
     "net/http"
+    "strconv"
+    "strings"
 
     "github.com/gin-gonic/gin"
 )
@@ -19,19 +22,37 @@ func NewComentarioControlador(servicio *services.ComentarioService) *ComentarioC
 
 // ObtenerComentariosPorClase
 // @Summary Devuelve los comentarios de una clase
-// @Description Devuelve todos los comentarios asociados a una clase por su ID
+// @Description Devuelve los hilos de comentarios de una clase por su ID, con sus respuestas anidadas y la cantidad de respuestas de cada comentario. Las clases de un curso no publicado solo las ven quienes pueden ver el curso
 // @Tags Comentarios
 // @Accept json
 // @Produce json
 // @Param id path string true "ID de la clase"
-// @Success 200 {array} models.Comentario "Lista de comentarios"
+// @Param profundidad query int false "Niveles de respuestas a incluir (0: solo comentarios principales; sin límite por defecto)"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.Comentario "Lista de hilos de comentarios"
+// @Failure 400 {object} response.ErrorResponse "Profundidad inválida"
+// @Failure 403 {object} response.ErrorResponse "La clase está bloqueada"
+// @Failure 404 {object} response.ErrorResponse "Clase o curso no encontrado"
 // @Failure 500 {object} response.ErrorResponse "Error interno del servidor"
 // @Router /api/clases/{id}/comentarios [get]
 func (c *ComentarioControlador) ObtenerComentariosPorClase(ctx *gin.Context) {
     claseID := ctx.Param("id")
-    comentarios, err := c.servicio.ObtenerComentariosPorClase(ctx.Request.Context(), claseID)
+    profundidad, ok := parsearProfundidad(ctx)
+    if !ok {
+        return
+    }
+
+    comentarios, err := c.servicio.ObtenerComentariosPorClase(ctx.Request.Context(), claseID, ctx.Query("email"), ctx.Query("password"), profundidad)
     if err != nil {
-        ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+        switch {
+        case strings.HasPrefix(err.Error(), "la clase está bloqueada"):
+            ctx.JSON(http.StatusForbidden, response.ErrorResponse{Message: err.Error()})
+        case err.Error() == "clase no encontrada", err.Error() == "curso no encontrado", err.Error() == "usuario no encontrado":
+            ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+        default:
+            ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+        }
         return
     }
     ctx.JSON(http.StatusOK, comentarios)
@@ -39,12 +60,12 @@ func (c *ComentarioControlador) ObtenerComentariosPorClase(ctx *gin.Context) {
 
 // CrearComentarioParaClase
 // @Summary Crear un comentario para una clase
-// @Description Agrega un comentario a una clase por su ID. Se requiere autor (email del usuario), password del usuario, titulo, detalle, meGusta, noMeGusta. La fecha se asigna automáticamente.
+// @Description Agrega un comentario a una clase por su ID. Se requiere autor (email del usuario), password del usuario, titulo y detalle. La fecha se asigna automáticamente y los contadores de "me gusta" comienzan en 0. Para responder a otro comentario de la misma clase se indica su ID en padre_id. Los comentarios principales pueden marcarse como pregunta con es_pregunta.
 // @Tags Comentarios
 // @Accept json
 // @Produce json
 // @Param id path string true "ID de la clase"
-// @Param comentario body models.Comentario true "Comentario a crear (autor, password, titulo, detalle, meGusta, noMeGusta)"
+// @Param comentario body request.CreateComentarioRequest true "Comentario a crear (autor, password, titulo, detalle, padre_id)"
 // @Success 201 {object} models.Comentario "Comentario creado exitosamente"
 // @Failure 400 {object} response.ErrorResponse "Datos inválidos o faltan campos requeridos"
 // @Failure 404 {object} response.ErrorResponse "Clase no encontrada o usuario no encontrado/credenciales inválidas"
@@ -52,25 +73,28 @@ func (c *ComentarioControlador) ObtenerComentariosPorClase(ctx *gin.Context) {
 // @Router /api/clases/{id}/comentarios [post]
 func (c *ComentarioControlador) CrearComentarioParaClase(ctx *gin.Context) {
     claseID := ctx.Param("id")
-    var comentario models.Comentario
 
-    if err := ctx.ShouldBindJSON(&comentario); err != nil {
+    var input request.CreateComentarioRequest
+    if err := ctx.ShouldBindJSON(&input); err != nil {
         ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Datos inválidos: " + err.Error()})
         return
     }
 
-    // Validar campos requeridos
-    if comentario.Autor == "" || comentario.Password == "" || comentario.Titulo == "" || comentario.Detalle == "" {
-        ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Campos autor, password, titulo y detalle son requeridos"})
-        return
+    comentario := models.Comentario{
+        Autor:    input.Autor,
+        Password: input.Password,
+        Titulo:   input.Titulo,
+        Detalle:  input.Detalle,
+        PadreID:  input.PadreID,
+        EsPregunta: input.EsPregunta,
     }
 
-    // meGusta y noMeGusta pueden ser 0 por defecto
-
     creado, err := c.servicio.CrearComentarioParaClase(ctx.Request.Context(), claseID, &comentario)
     if err != nil {
-        if err.Error() == "clase no encontrada" || err.Error() == "usuario no encontrado o credenciales inválidas" {
+        if err.Error() == "clase no encontrada" || err.Error() == "usuario no encontrado o credenciales inválidas" || err.Error() == "comentario padre no encontrado" {
             ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+        } else if err.Error() == "no se puede responder un comentario eliminado" || err.Error() == "una respuesta no puede ser una pregunta" {
+            ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
         } else {
             ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
         }
@@ -79,3 +103,165 @@ func (c *ComentarioControlador) CrearComentarioParaClase(ctx *gin.Context) {
 
     ctx.JSON(http.StatusCreated, creado)
 }
+
+// ObtenerHilo
+// @Summary Devuelve un hilo de comentarios
+// @Description Devuelve un comentario de clase con sus respuestas anidadas, si el usuario puede ver el curso de la clase y la clase no está bloqueada para él
+// @Tags Comentarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param profundidad query int false "Niveles de respuestas a incluir (sin límite por defecto)"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {object} models.Comentario "Hilo de comentarios"
+// @Failure 400 {object} response.ErrorResponse "Profundidad inválida"
+// @Failure 403 {object} response.ErrorResponse "La clase está bloqueada"
+// @Failure 404 {object} response.ErrorResponse "Comentario no encontrado"
+// @Failure 500 {object} response.ErrorResponse "Error interno del servidor"
+// @Router /api/comentarios/{id}/hilo [get]
+func (c *ComentarioControlador) ObtenerHilo(ctx *gin.Context) {
+    comentarioID := ctx.Param("id")
+    profundidad, ok := parsearProfundidad(ctx)
+    if !ok {
+        return
+    }
+
+    hilo, err := c.servicio.ObtenerHilo(ctx.Request.Context(), comentarioID, ctx.Query("email"), ctx.Query("password"), profundidad)
+    if err != nil {
+        if strings.HasPrefix(err.Error(), "la clase está bloqueada") {
+            ctx.JSON(http.StatusForbidden, response.ErrorResponse{Message: err.Error()})
+        } else if err.Error() == "comentario no encontrado" || err.Error() == "clase no encontrada" || err.Error() == "curso no encontrado" || err.Error() == "usuario no encontrado" {
+            ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+        } else {
+            ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+        }
+        return
+    }
+    ctx.JSON(http.StatusOK, hilo)
+}
+
+// EliminarComentario
+// @Summary Eliminar un comentario de una clase
+// @Description Elimina un comentario a pedido de su autor o de un moderador. Si el comentario tiene respuestas, se muestra como "comentario eliminado" para conservar el hilo.
+// @Tags Comentarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del autor o del moderador"
+// @Success 200 {object} response.MessageResponse "Comentario eliminado exitosamente"
+// @Failure 400 {object} response.ErrorResponse "Datos inválidos"
+// @Failure 403 {object} response.ErrorResponse "El usuario no es el autor ni un moderador"
+// @Failure 404 {object} response.ErrorResponse "Comentario o usuario no encontrado"
+// @Failure 500 {object} response.ErrorResponse "Error interno del servidor"
+// @Router /api/comentarios/{id} [delete]
+func (c *ComentarioControlador) EliminarComentario(ctx *gin.Context) {
+    comentarioID := ctx.Param("id")
+
+    var credenciales request.CredencialesRequest
+    if err := ctx.ShouldBindJSON(&credenciales); err != nil {
+        ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Datos inválidos: " + err.Error()})
+        return
+    }
+
+    err := c.servicio.EliminarComentario(ctx.Request.Context(), comentarioID, credenciales.Email, credenciales.Password)
+    if err != nil {
+        switch err.Error() {
+        case "comentario no encontrado", "usuario no encontrado":
+            ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+        case "solo el autor o un moderador puede eliminar el comentario":
+            ctx.JSON(http.StatusForbidden, response.ErrorResponse{Message: err.Error()})
+        default:
+            ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+        }
+        return
+    }
+
+    ctx.JSON(http.StatusOK, response.MessageResponse{Message: "Comentario eliminado exitosamente"})
+}
+
+// EditarComentario
+// @Summary Editar un comentario de una clase
+// @Description Reemplaza el título y el detalle de un comentario. Solo el autor puede editarlo; la versión anterior queda en el historial de revisiones.
+// @Tags Comentarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param comentario body request.UpdateComentarioRequest true "Credenciales del autor, nuevo título y nuevo detalle"
+// @Success 200 {object} models.Comentario "Comentario editado"
+// @Failure 400 {object} response.ErrorResponse "Datos inválidos"
+// @Failure 403 {object} response.ErrorResponse "El usuario no es el autor"
+// @Failure 404 {object} response.ErrorResponse "Comentario o usuario no encontrado"
+// @Failure 500 {object} response.ErrorResponse "Error interno del servidor"
+// @Router /api/comentarios/{id} [put]
+func (c *ComentarioControlador) EditarComentario(ctx *gin.Context) {
+    comentarioID := ctx.Param("id")
+
+    var input request.UpdateComentarioRequest
+    if err := ctx.ShouldBindJSON(&input); err != nil {
+        ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Datos inválidos: " + err.Error()})
+        return
+    }
+
+    comentario, err := c.servicio.EditarComentario(ctx.Request.Context(), comentarioID, input.Email, input.Password, input.Titulo, input.Detalle)
+    if err != nil {
+        switch err.Error() {
+        case "comentario no encontrado", "usuario no encontrado":
+            ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+        case "solo el autor puede editar el comentario":
+            ctx.JSON(http.StatusForbidden, response.ErrorResponse{Message: err.Error()})
+        default:
+            ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+        }
+        return
+    }
+
+    ctx.JSON(http.StatusOK, comentario)
+}
+
+// ObtenerRevisionesComentario
+// @Summary Devuelve el historial de un comentario de clase
+// @Description Devuelve las versiones anteriores (título y detalle) de un comentario con la fecha en que se escribió cada una, si el usuario puede ver el curso de la clase y la clase no está bloqueada para él
+// @Tags Comentarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.RevisionComentario "Versiones anteriores"
+// @Failure 403 {object} response.ErrorResponse "La clase está bloqueada"
+// @Failure 404 {object} response.ErrorResponse "Comentario, clase, curso o usuario no encontrado"
+// @Failure 500 {object} response.ErrorResponse "Error interno del servidor"
+// @Router /api/comentarios/{id}/revisiones [get]
+func (c *ComentarioControlador) ObtenerRevisionesComentario(ctx *gin.Context) {
+    comentarioID := ctx.Param("id")
+
+    revisiones, err := c.servicio.ObtenerRevisionesComentario(ctx.Request.Context(), comentarioID, ctx.Query("email"), ctx.Query("password"))
+    if err != nil {
+        if strings.HasPrefix(err.Error(), "la clase está bloqueada") {
+            ctx.JSON(http.StatusForbidden, response.ErrorResponse{Message: err.Error()})
+        } else if err.Error() == "comentario no encontrado" || err.Error() == "clase no encontrada" || err.Error() == "curso no encontrado" || err.Error() == "usuario no encontrado" {
+            ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+        } else {
+            ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+        }
+        return
+    }
+    ctx.JSON(http.StatusOK, revisiones)
+}
+
+// parsearProfundidad lee el parámetro de consulta profundidad (-1 si no se indica). Si es
+// inválido responde con un error 400 y devuelve ok = false.
+func parsearProfundidad(ctx *gin.Context) (int, bool) {
+    valor := ctx.Query("profundidad")
+    if valor == "" {
+        return -1, true
+    }
+
+    profundidad, err := strconv.Atoi(valor)
+    if err != nil || profundidad < 0 {
+        ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "El parámetro profundidad debe ser un número mayor o igual a 0"})
+        return 0, false
+    }
+    return profundidad, true
+}
@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go-API/models"
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CuestionarioControlador gestiona las rutas de los cuestionarios de clases y unidades.
+type CuestionarioControlador struct {
+	servicio *services.CuestionarioService
+}
+
+// NewCuestionarioControlador crea un nuevo controlador para los cuestionarios.
+func NewCuestionarioControlador(servicio *services.CuestionarioService) *CuestionarioControlador {
+	return &CuestionarioControlador{servicio: servicio}
+}
+
+// CrearCuestionarioParaClase crea un cuestionario en una clase.
+// @Summary Crear el cuestionario de una clase
+// @Description Crea un cuestionario de opción múltiple, verdadero o falso y respuesta corta en la clase. Si es requerido, aprobarlo es necesario para marcar la clase como vista. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Cuestionarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param cuestionario body request.CreateCuestionarioRequest true "Credenciales del instructor y cuestionario"
+// @Success 201 {object} models.Cuestionario
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/cuestionarios [post]
+func (ctrl *CuestionarioControlador) CrearCuestionarioParaClase(c *gin.Context) {
+	ctrl.crear(c, ctrl.servicio.CrearCuestionarioParaClase)
+}
+
+// CrearCuestionarioParaUnidad crea un cuestionario en una unidad.
+// @Summary Crear el cuestionario de una unidad
+// @Description Crea un cuestionario de opción múltiple, verdadero o falso y respuesta corta en la unidad. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Cuestionarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la unidad"
+// @Param cuestionario body request.CreateCuestionarioRequest true "Credenciales del instructor y cuestionario"
+// @Success 201 {object} models.Cuestionario
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/unidades/{id}/cuestionarios [post]
+func (ctrl *CuestionarioControlador) CrearCuestionarioParaUnidad(c *gin.Context) {
+	ctrl.crear(c, ctrl.servicio.CrearCuestionarioParaUnidad)
+}
+
+type crearCuestionarioFunc func(ctx context.Context, id, email, password string, cuestionario *models.Cuestionario) (*models.Cuestionario, error)
+
+func (ctrl *CuestionarioControlador) crear(c *gin.Context, crear crearCuestionarioFunc) {
+	var input request.CreateCuestionarioRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	cuestionario := &models.Cuestionario{
+		Titulo:            input.Titulo,
+		IntentosMaximos:   input.IntentosMaximos,
+		PuntajeAprobacion: 60,
+		Requerido:         input.Requerido,
+	}
+	if input.PuntajeAprobacion != nil {
+		cuestionario.PuntajeAprobacion = *input.PuntajeAprobacion
+	}
+	for _, pregunta := range input.Preguntas {
+		cuestionario.Preguntas = append(cuestionario.Preguntas, models.PreguntaCuestionario{
+			Tipo:                pregunta.Tipo,
+			Enunciado:           pregunta.Enunciado,
+			Opciones:            pregunta.Opciones,
+			Puntos:              pregunta.Puntos,
+			OpcionCorrecta:      pregunta.OpcionCorrecta,
+			RespuestaVF:         pregunta.RespuestaVF,
+			RespuestasAceptadas: pregunta.RespuestasAceptadas,
+		})
+	}
+
+	creado, err := crear(c.Request.Context(), c.Param("id"), input.Email, input.Password, cuestionario)
+	if err != nil {
+		responderErrorCuestionario(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, creado)
+}
+
+// ObtenerCuestionariosPorClase obtiene los cuestionarios de una clase.
+// @Summary Obtener los cuestionarios de una clase
+// @Description Devuelve los cuestionarios de la clase sin sus respuestas correctas. Requiere poder ver el curso de la clase y que la unidad de la clase no esté bloqueada para el usuario.
+// @Tags Cuestionarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.Cuestionario
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/cuestionarios [get]
+func (ctrl *CuestionarioControlador) ObtenerCuestionariosPorClase(c *gin.Context) {
+	cuestionarios, err := ctrl.servicio.ObtenerCuestionariosPorClase(c.Request.Context(), c.Param("id"), c.Query("email"), c.Query("password"))
+	if err != nil {
+		responderErrorCuestionario(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cuestionarios)
+}
+
+// ObtenerCuestionariosPorUnidad obtiene los cuestionarios de una unidad.
+// @Summary Obtener los cuestionarios de una unidad
+// @Description Devuelve los cuestionarios de la unidad sin sus respuestas correctas. Requiere poder ver el curso de la unidad y que la unidad no esté bloqueada para el usuario.
+// @Tags Cuestionarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la unidad"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.Cuestionario
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/unidades/{id}/cuestionarios [get]
+func (ctrl *CuestionarioControlador) ObtenerCuestionariosPorUnidad(c *gin.Context) {
+	cuestionarios, err := ctrl.servicio.ObtenerCuestionariosPorUnidad(c.Request.Context(), c.Param("id"), c.Query("email"), c.Query("password"))
+	if err != nil {
+		responderErrorCuestionario(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cuestionarios)
+}
+
+// ObtenerCuestionario obtiene un cuestionario.
+// @Summary Obtener un cuestionario
+// @Description Devuelve el cuestionario sin sus respuestas correctas. Requiere poder ver su curso y que su unidad no esté bloqueada para el usuario.
+// @Tags Cuestionarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del cuestionario"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {object} models.Cuestionario
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cuestionarios/{id} [get]
+func (ctrl *CuestionarioControlador) ObtenerCuestionario(c *gin.Context) {
+	cuestionario, err := ctrl.servicio.ObtenerCuestionario(c.Request.Context(), c.Param("id"), c.Query("email"), c.Query("password"))
+	if err != nil {
+		responderErrorCuestionario(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cuestionario)
+}
+
+// EnviarIntento envía las respuestas de un usuario a un cuestionario.
+// @Summary Enviar un intento
+// @Description Corrige automáticamente las respuestas y registra el intento. Las preguntas sin responder cuentan como incorrectas. Solo para usuarios inscritos en el curso, si la unidad del cuestionario no está bloqueada para ellos, y dentro del límite de intentos.
+// @Tags Cuestionarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del cuestionario"
+// @Param intento body request.IntentoCuestionarioRequest true "Credenciales del usuario y respuestas"
+// @Success 201 {object} models.IntentoCuestionario
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cuestionarios/{id}/intentos [post]
+func (ctrl *CuestionarioControlador) EnviarIntento(c *gin.Context) {
+	var input request.IntentoCuestionarioRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	respuestas := []models.RespuestaIntento{}
+	for _, respuesta := range input.Respuestas {
+		respuestas = append(respuestas, models.RespuestaIntento{
+			PreguntaID: respuesta.PreguntaID,
+			Opcion:     respuesta.Opcion,
+			Valor:      respuesta.Valor,
+			Texto:      respuesta.Texto,
+		})
+	}
+
+	intento, err := ctrl.servicio.EnviarIntento(c.Request.Context(), c.Param("id"), input.Email, input.Password, respuestas)
+	if err != nil {
+		responderErrorCuestionario(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, intento)
+}
+
+// ObtenerResultados obtiene los intentos de un usuario sobre un cuestionario.
+// @Summary Obtener los resultados de un cuestionario
+// @Description Devuelve los intentos del usuario, su mejor puntaje, si aprobó y los intentos que le quedan.
+// @Tags Cuestionarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del cuestionario"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {object} models.ResultadosCuestionario
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cuestionarios/{id}/intentos [get]
+func (ctrl *CuestionarioControlador) ObtenerResultados(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	resultados, err := ctrl.servicio.ObtenerResultados(c.Request.Context(), c.Param("id"), email, password)
+	if err != nil {
+		responderErrorCuestionario(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resultados)
+}
+
+func responderErrorCuestionario(c *gin.Context, err error) {
+	switch {
+	case err.Error() == "usuario no encontrado", err.Error() == "cuestionario no encontrado",
+		err.Error() == "clase no encontrada", err.Error() == "unidad no encontrada", err.Error() == "curso no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err.Error() == "solo el instructor del curso o un administrador puede crear cuestionarios",
+		err.Error() == "el usuario no está inscrito en este curso",
+		err.Error() == "se alcanzó el límite de intentos del cuestionario",
+		strings.HasPrefix(err.Error(), "la clase está bloqueada"):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "ID "), strings.HasPrefix(err.Error(), "pregunta "),
+		err.Error() == "el cuestionario debe tener al menos una pregunta",
+		err.Error() == "la cantidad de intentos no puede ser negativa",
+		err.Error() == "el puntaje de aprobación debe estar entre 0 y 100":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"go-API/models"
+	"go-API/request"
+	"go-API/response"
 	"go-API/services"
 
 	"github.com/gin-gonic/gin"
@@ -19,39 +21,58 @@ func NewCursoControlador(servicio *services.CursoService) *CursoControlador {
 
 // ObtenerCursos devuelve todos los cursos disponibles.
 // @Summary Devuelve todos los cursos
-// @Description Devuelve todos los cursos disponibles
+// @Description Devuelve los cursos publicados. Con credenciales incluye también los cursos en cualquier estado de los que el usuario es instructor, o todos si es administrador
 // @Tags Cursos
 // @Accept json
 // @Produce json
+// @Param orden query string false "Orden del catálogo (valoracion: por valoración ponderada)"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
 // @Success 200 {array} response.CursoResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/cursos [get]
 func (ctrl *CursoControlador) ObtenerCursos(c *gin.Context) {
-	cursos, err := ctrl.servicio.ObtenerCursos()
+	cursos, err := ctrl.servicio.ObtenerCursos(c.Query("orden"), c.Query("email"), c.Query("password"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err.Error() == "usuario no encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
-	c.JSON(http.StatusOK, cursos)
+
+	respuesta := make([]response.CursoResponse, len(cursos))
+	for i, curso := range cursos {
+		respuesta[i] = response.NewCursoResponse(curso)
+	}
+	c.JSON(http.StatusOK, respuesta)
 }
 
 // CrearCurso crea un nuevo curso.
 // @Summary Crear un curso
-// @Description Agrega un curso a la base de datos
+// @Description Agrega un curso a la base de datos. El curso se crea como borrador y no aparece en el catálogo hasta que se publica. El instructor es el usuario de las credenciales; solo un administrador puede indicar otro instructor
 // @Tags Cursos
 // @Param curso body request.CreateCursoRequest true "Curso a crear"
 // @Accept json
 // @Produce json
 // @Success 200 {object} response.CrearCurso
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/cursos [post]
 func (ctrl *CursoControlador) CrearCurso(c *gin.Context) {
 	var request struct {
+		Email       string  `json:"email"`
+		Password    string  `json:"password"`
 		Nombre      string  `json:"nombre"`
 		Descripcion string  `json:"descripcion"`
 		Imagen      string  `json:"imagen_url"`
 		Valoracion  float32 `json:"valoracion"`
+		Instructor  string  `json:"instructor"`
 	}
 
 	// Verificar si los datos enviados son correctos
@@ -59,13 +80,24 @@ func (ctrl *CursoControlador) CrearCurso(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if request.Email == "" || request.Password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
 
 	// Crear un nuevo curso usando el constructor
-	curso := models.NewCurso(request.Nombre, request.Descripcion, request.Imagen, request.Valoracion)
+	curso := models.NewCurso(request.Nombre, request.Descripcion, request.Imagen, request.Valoracion, request.Instructor)
 
-	result, err := ctrl.servicio.CrearCurso(curso)
+	result, err := ctrl.servicio.CrearCurso(curso, request.Email, request.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "usuario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "solo un administrador puede crear un curso para otro instructor":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -74,11 +106,13 @@ func (ctrl *CursoControlador) CrearCurso(c *gin.Context) {
 
 // ObtenerCursoPorID devuelve un curso específico por su ID.
 // @Summary Devuelve un curso según su ID
-// @Description Devuelve un curso en específico dado su ID
+// @Description Devuelve un curso en específico dado su ID. Los borradores solo son visibles para su instructor y los administradores, y los cursos archivados también para sus inscritos
 // @Tags Cursos
 // @Accept json
 // @Produce json
 // @Param id path string true "ID del curso"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
 // @Success 200 {object} response.CursoResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
@@ -86,58 +120,110 @@ func (ctrl *CursoControlador) CrearCurso(c *gin.Context) {
 // @Router /api/cursos/{id} [get]
 func (ctrl *CursoControlador) ObtenerCursoPorID(c *gin.Context) {
 	id := c.Param("id")
-	curso, err := ctrl.servicio.ObtenerCursoPorID(id)
+	curso, err := ctrl.servicio.ObtenerCursoPorID(id, c.Query("email"), c.Query("password"))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, curso)
+	c.JSON(http.StatusOK, response.NewCursoResponse(*curso))
 }
 
-// ActualizarValoracion actualiza la valoración promedio de un curso.
+// ActualizarValoracion sobrescribe manualmente la valoración de un curso.
 // @Summary Actualiza la valoración de un curso
-// @Description Actualiza la valoración de un curso según la nueva valoración proporcionada
+// @Description Sobrescribe la valoración de un curso. Solo disponible para administradores, que deben indicar el motivo del cambio; el cambio queda registrado en la auditoría.
 // @Tags Cursos
 // @Accept json
 // @Produce json
 // @Param id path string true "ID del curso"
-// @Param valoracion body request.UpdateValoracionRequest true "Nueva valoración del curso"
+// @Param valoracion body request.UpdateValoracionRequest true "Credenciales del administrador, nueva valoración y motivo"
 // @Success 200 {object} response.UpdateValoracionResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/cursos/{id}/valoracion [patch]
 func (cc *CursoControlador) ActualizarValoracion(c *gin.Context) {
 	id := c.Param("id") // ID del curso
 
-	// Estructura para recibir la nueva valoración
-	var body struct {
-		Valoracion float32 `json:"valoracion"`
-	}
-
 	// Validar el cuerpo de la solicitud
+	var body request.UpdateValoracionRequest
 	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
 		return
 	}
 
 	// Llamar al servicio para actualizar la valoración
-	err := cc.servicio.ActualizarValoracion(id, body.Valoracion)
+	err := cc.servicio.ActualizarValoracion(id, *body.Valoracion, body.Email, body.Password, body.Motivo)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "acceso restringido a administradores":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "usuario no encontrado", "curso no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "ID inválido", "la valoración debe estar entre 0 y 5", "el motivo es requerido":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Valoración actualizada exitosamente"})
-	
+	c.JSON(http.StatusOK, response.UpdateValoracionResponse{
+		Message:               "Valoración actualizada exitosamente",
+		ValoracionActualizada: *body.Valoracion,
+	})
+}
+
+// AsignarInstructor asigna el instructor de un curso.
+// @Summary Asigna el instructor de un curso
+// @Description Asigna o reemplaza el instructor de un curso, identificado por su email. Solo disponible para administradores.
+// @Tags Cursos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param instructor body request.AsignarInstructorRequest true "Credenciales del administrador y email del instructor"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/instructor [put]
+func (cc *CursoControlador) AsignarInstructor(c *gin.Context) {
+	id := c.Param("id")
+
+	var body request.AsignarInstructorRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	err := cc.servicio.AsignarInstructor(id, body.Instructor, body.Email, body.Password)
+	if err != nil {
+		switch err.Error() {
+		case "acceso restringido a administradores":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "usuario no encontrado", "curso no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "ID inválido":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Instructor asignado exitosamente"})
 }
 
 // ObtenerClasesPorCurso devuelve todas las clases de un curso dado su ID.
 // @Summary Devuelve todas las clases de un curso
-// @Description Devuelve todas las clases asociadas a un curso dado su ID
+// @Description Devuelve todas las clases asociadas a un curso dado su ID. Las clases de las unidades bloqueadas para el usuario (o para un usuario no inscrito, sin credenciales) se devuelven sin video, adjuntos ni subtítulos y con la fecha en que se desbloquean, si se conoce
 // @Tags Cursos
 // @Accept json
 // @Produce json
 // @Param id path string true "ID del curso"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
 // @Success 200 {array} response.ClaseResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
@@ -145,9 +231,9 @@ func (cc *CursoControlador) ActualizarValoracion(c *gin.Context) {
 // @Router /api/cursos/{id}/clases [get]
 func (ctrl *CursoControlador) ObtenerClasesPorCurso(c *gin.Context) {
     id := c.Param("id")
-    clases, err := ctrl.servicio.ObtenerClasesPorCurso(id)
+    clases, err := ctrl.servicio.ObtenerClasesPorCurso(id, c.Query("email"), c.Query("password"))
     if err != nil {
-        if err.Error() == "curso no encontrado" {
+        if err.Error() == "curso no encontrado" || err.Error() == "usuario no encontrado" {
             c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
         } else {
             c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -157,3 +243,117 @@ func (ctrl *CursoControlador) ObtenerClasesPorCurso(c *gin.Context) {
     c.JSON(http.StatusOK, clases)
 }
 
+// PublicarCurso publica un curso o programa su publicación.
+// @Summary Publicar un curso
+// @Description Publica un curso en borrador o archivado para que aparezca en el catálogo. Si se indica una fecha futura programa la publicación del borrador para esa fecha. El curso debe tener al menos una unidad con una clase. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Cursos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param publicacion body request.PublicarCursoRequest true "Credenciales del instructor y fecha de publicación opcional"
+// @Success 200 {object} response.CursoResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/publicar [post]
+func (ctrl *CursoControlador) PublicarCurso(c *gin.Context) {
+	id := c.Param("id")
+
+	var input request.PublicarCursoRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	curso, err := ctrl.servicio.PublicarCurso(c.Request.Context(), id, input.Email, input.Password, input.Fecha)
+	if err != nil {
+		responderErrorEstadoCurso(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewCursoResponse(*curso))
+}
+
+// DespublicarCurso vuelve un curso a borrador.
+// @Summary Despublicar un curso
+// @Description Vuelve un curso publicado a borrador, o cancela la publicación programada de un borrador. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Cursos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} response.CursoResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/despublicar [post]
+func (ctrl *CursoControlador) DespublicarCurso(c *gin.Context) {
+	id := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	curso, err := ctrl.servicio.DespublicarCurso(c.Request.Context(), id, input.Email, input.Password)
+	if err != nil {
+		responderErrorEstadoCurso(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewCursoResponse(*curso))
+}
+
+// ArchivarCurso archiva un curso.
+// @Summary Archivar un curso
+// @Description Archiva un curso: deja de aparecer en el catálogo y no admite inscripciones, pero sus inscritos conservan el acceso. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Cursos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} response.CursoResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/archivar [post]
+func (ctrl *CursoControlador) ArchivarCurso(c *gin.Context) {
+	id := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	curso, err := ctrl.servicio.ArchivarCurso(c.Request.Context(), id, input.Email, input.Password)
+	if err != nil {
+		responderErrorEstadoCurso(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewCursoResponse(*curso))
+}
+
+func responderErrorEstadoCurso(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "curso no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede cambiar el estado del curso":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "el curso ya está publicado", "el curso no está publicado", "el curso ya está archivado",
+		"solo se puede programar la publicación de un curso en borrador":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "ID inválido", "el curso debe tener al menos una unidad con una clase para publicarse":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
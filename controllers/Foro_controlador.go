@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"net/http"
+
+	"go-API/request"
+	"go-API/response"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ForoControlador gestiona las rutas de los foros de discusión de los cursos.
+type ForoControlador struct {
+	servicio *services.ForoService
+}
+
+// NewForoControlador crea un nuevo controlador para los foros.
+func NewForoControlador(servicio *services.ForoService) *ForoControlador {
+	return &ForoControlador{servicio: servicio}
+}
+
+// ObtenerCategorias obtiene las categorías del foro de un curso.
+// @Summary Obtener las categorías del foro de un curso
+// @Description Devuelve las categorías del foro del curso con su cantidad de hilos. El foro de un borrador solo lo ven su instructor y los administradores, y el de un curso archivado también sus inscritos.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.CategoriaForo
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/foro [get]
+func (ctrl *ForoControlador) ObtenerCategorias(c *gin.Context) {
+	categorias, err := ctrl.servicio.ObtenerCategorias(c.Request.Context(), c.Param("id"), c.Query("email"), c.Query("password"))
+	if err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categorias)
+}
+
+// CrearCategoria crea una categoría en el foro de un curso.
+// @Summary Crear una categoría del foro
+// @Description Crea una categoría en el foro del curso. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param categoria body request.CreateCategoriaForoRequest true "Credenciales del instructor, nombre y descripción"
+// @Success 201 {object} models.CategoriaForo
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/foro/categorias [post]
+func (ctrl *ForoControlador) CrearCategoria(c *gin.Context) {
+	cursoID := c.Param("id")
+
+	var input request.CreateCategoriaForoRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	categoria, err := ctrl.servicio.CrearCategoria(c.Request.Context(), cursoID, input.Email, input.Password, input.Nombre, input.Descripcion)
+	if err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, categoria)
+}
+
+// ObtenerHilos obtiene una página de los hilos de una categoría del foro.
+// @Summary Obtener los hilos de una categoría
+// @Description Devuelve los hilos de la categoría: primero los fijados y luego los de actividad más reciente. Requiere poder ver el curso de la categoría.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la categoría"
+// @Param pagina query int false "Número de página (1 por defecto)"
+// @Param limite query int false "Hilos por página (10 por defecto, máximo 50)"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {object} response.HilosForoPaginadosResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/foro/categorias/{id}/hilos [get]
+func (ctrl *ForoControlador) ObtenerHilos(c *gin.Context) {
+	pagina, limite, ok := parsearPaginacion(c)
+	if !ok {
+		return
+	}
+
+	hilos, total, err := ctrl.servicio.ObtenerHilos(c.Request.Context(), c.Param("id"), c.Query("email"), c.Query("password"), pagina, limite)
+	if err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.HilosForoPaginadosResponse{
+		Hilos:  hilos,
+		Pagina: pagina,
+		Limite: limite,
+		Total:  total,
+	})
+}
+
+// CrearHilo inicia un hilo en una categoría del foro.
+// @Summary Iniciar un hilo
+// @Description Crea un hilo en la categoría con su primera publicación. Pueden hacerlo los inscritos en el curso, su instructor y los administradores.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la categoría"
+// @Param hilo body request.CreateHiloForoRequest true "Credenciales del usuario, título y contenido"
+// @Success 201 {object} models.HiloForo
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/foro/categorias/{id}/hilos [post]
+func (ctrl *ForoControlador) CrearHilo(c *gin.Context) {
+	categoriaID := c.Param("id")
+
+	var input request.CreateHiloForoRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	hilo, err := ctrl.servicio.CrearHilo(c.Request.Context(), categoriaID, input.Email, input.Password, input.Titulo, input.Contenido)
+	if err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, hilo)
+}
+
+// ObtenerHilo obtiene un hilo del foro con una página de sus publicaciones.
+// @Summary Obtener un hilo
+// @Description Devuelve el hilo y sus publicaciones, de la más antigua a la más reciente. Requiere poder ver el curso del hilo.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del hilo"
+// @Param pagina query int false "Número de página (1 por defecto)"
+// @Param limite query int false "Publicaciones por página (10 por defecto, máximo 50)"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {object} response.HiloForoResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/foro/hilos/{id} [get]
+func (ctrl *ForoControlador) ObtenerHilo(c *gin.Context) {
+	pagina, limite, ok := parsearPaginacion(c)
+	if !ok {
+		return
+	}
+
+	hilo, publicaciones, total, err := ctrl.servicio.ObtenerHilo(c.Request.Context(), c.Param("id"), c.Query("email"), c.Query("password"), pagina, limite)
+	if err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.HiloForoResponse{
+		Hilo:          *hilo,
+		Publicaciones: publicaciones,
+		Pagina:        pagina,
+		Limite:        limite,
+		Total:         total,
+	})
+}
+
+// CrearPublicacion publica un mensaje en un hilo del foro.
+// @Summary Publicar en un hilo
+// @Description Agrega una publicación al hilo. Pueden hacerlo los inscritos en el curso; en los hilos bloqueados, solo el instructor del curso o un administrador.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del hilo"
+// @Param publicacion body request.CreatePublicacionForoRequest true "Credenciales del usuario y contenido"
+// @Success 201 {object} models.PublicacionForo
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/foro/hilos/{id}/publicaciones [post]
+func (ctrl *ForoControlador) CrearPublicacion(c *gin.Context) {
+	hiloID := c.Param("id")
+
+	var input request.CreatePublicacionForoRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	publicacion, err := ctrl.servicio.CrearPublicacion(c.Request.Context(), hiloID, input.Email, input.Password, input.Contenido)
+	if err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, publicacion)
+}
+
+// ActualizarEstadoHilo fija o bloquea un hilo del foro.
+// @Summary Fijar o bloquear un hilo
+// @Description Fija o bloquea un hilo; los campos omitidos no se modifican. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del hilo"
+// @Param estado body request.EstadoHiloForoRequest true "Credenciales del instructor y nuevo estado"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/foro/hilos/{id} [patch]
+func (ctrl *ForoControlador) ActualizarEstadoHilo(c *gin.Context) {
+	hiloID := c.Param("id")
+
+	var input request.EstadoHiloForoRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.ActualizarEstadoHilo(c.Request.Context(), hiloID, input.Email, input.Password, input.Fijado, input.Bloqueado); err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Hilo actualizado exitosamente"})
+}
+
+// EliminarHilo elimina un hilo del foro con todas sus publicaciones.
+// @Summary Eliminar un hilo
+// @Description Elimina el hilo y todas sus publicaciones. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del hilo"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/foro/hilos/{id} [delete]
+func (ctrl *ForoControlador) EliminarHilo(c *gin.Context) {
+	hiloID := c.Param("id")
+
+	var credenciales request.CredencialesRequest
+	if err := c.ShouldBindJSON(&credenciales); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.EliminarHilo(c.Request.Context(), hiloID, credenciales.Email, credenciales.Password); err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Hilo eliminado exitosamente"})
+}
+
+// EliminarPublicacion elimina una publicación del foro.
+// @Summary Eliminar una publicación
+// @Description Elimina una publicación a pedido de su autor, del instructor del curso o de un administrador. La publicación se conserva sin contenido.
+// @Tags Foros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la publicación"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del autor o del instructor"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/foro/publicaciones/{id} [delete]
+func (ctrl *ForoControlador) EliminarPublicacion(c *gin.Context) {
+	publicacionID := c.Param("id")
+
+	var credenciales request.CredencialesRequest
+	if err := c.ShouldBindJSON(&credenciales); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.EliminarPublicacion(c.Request.Context(), publicacionID, credenciales.Email, credenciales.Password); err != nil {
+		responderErrorForo(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Publicación eliminada exitosamente"})
+}
+
+func responderErrorForo(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "curso no encontrado", "categoría no encontrada", "hilo no encontrado", "publicación no encontrada":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "el usuario no está inscrito en este curso", "el hilo está bloqueado",
+		"solo el instructor del curso o un administrador puede moderar el foro",
+		"solo el autor o un moderador del curso puede eliminar la publicación":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"net/http"
+
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModeracionControlador gestiona las rutas de reportes y moderación de comentarios de clase y de curso.
+type ModeracionControlador struct {
+	servicio *services.ModeracionService
+}
+
+// NewModeracionControlador crea un nuevo controlador para la moderación de comentarios.
+func NewModeracionControlador(servicio *services.ModeracionService) *ModeracionControlador {
+	return &ModeracionControlador{servicio: servicio}
+}
+
+// ReportarComentario registra el reporte de un usuario sobre un comentario de clase.
+// @Summary Reportar un comentario de clase
+// @Description Reporta un comentario de clase para que lo revise un moderador. Un usuario tiene como máximo un reporte abierto por comentario.
+// @Tags Moderacion
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param reporte body request.ReporteComentarioRequest true "Credenciales del usuario y motivo del reporte"
+// @Success 201 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/comentarios/{id}/reportes [post]
+func (ctrl *ModeracionControlador) ReportarComentario(c *gin.Context) {
+	ctrl.reportar(c, services.TipoComentarioClase)
+}
+
+// ReportarComentarioCurso registra el reporte de un usuario sobre un comentario de curso.
+// @Summary Reportar un comentario de curso
+// @Description Reporta un comentario de curso para que lo revise un moderador. Un usuario tiene como máximo un reporte abierto por comentario.
+// @Tags Moderacion
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param reporte body request.ReporteComentarioRequest true "Credenciales del usuario y motivo del reporte"
+// @Success 201 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/comentarios_curso/{id}/reportes [post]
+func (ctrl *ModeracionControlador) ReportarComentarioCurso(c *gin.Context) {
+	ctrl.reportar(c, services.TipoComentarioCurso)
+}
+
+func (ctrl *ModeracionControlador) reportar(c *gin.Context, tipo string) {
+	comentarioID := c.Param("id")
+
+	var input request.ReporteComentarioRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	err := ctrl.servicio.ReportarComentario(c.Request.Context(), input.Email, input.Password, tipo, comentarioID, input.Motivo)
+	if err != nil {
+		switch err.Error() {
+		case "usuario no encontrado", "comentario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "no puede reportar su propio comentario":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "el usuario ya reportó este comentario":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Comentario reportado exitosamente"})
+}
+
+// ObtenerColaModeracion obtiene los comentarios que esperan revisión de un moderador.
+// @Summary Cola de moderación
+// @Description Devuelve los comentarios de clase y de curso retenidos por el filtro de palabras o con reportes abiertos, los más reportados primero. Solo para moderadores.
+// @Tags Moderacion
+// @Accept json
+// @Produce json
+// @Param email query string true "Correo del moderador"
+// @Param password query string true "Contraseña del moderador"
+// @Success 200 {array} models.ElementoModeracion
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/moderacion/cola [get]
+func (ctrl *ModeracionControlador) ObtenerColaModeracion(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	cola, err := ctrl.servicio.ObtenerColaModeracion(c.Request.Context(), email, password)
+	if err != nil {
+		responderErrorModeracion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cola)
+}
+
+// ModerarComentario aplica la decisión de un moderador sobre un comentario de clase.
+// @Summary Moderar un comentario de clase
+// @Description Aprueba, oculta o elimina un comentario de clase y resuelve sus reportes abiertos. Solo para moderadores.
+// @Tags Moderacion
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param moderacion body request.ModeracionRequest true "Credenciales del moderador y acción (aprobar, ocultar o eliminar)"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/moderacion/comentarios/{id} [post]
+func (ctrl *ModeracionControlador) ModerarComentario(c *gin.Context) {
+	ctrl.moderar(c, services.TipoComentarioClase)
+}
+
+// ModerarComentarioCurso aplica la decisión de un moderador sobre un comentario de curso.
+// @Summary Moderar un comentario de curso
+// @Description Aprueba, oculta o elimina un comentario de curso y resuelve sus reportes abiertos. Solo para moderadores.
+// @Tags Moderacion
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param moderacion body request.ModeracionRequest true "Credenciales del moderador y acción (aprobar, ocultar o eliminar)"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/moderacion/comentarios_curso/{id} [post]
+func (ctrl *ModeracionControlador) ModerarComentarioCurso(c *gin.Context) {
+	ctrl.moderar(c, services.TipoComentarioCurso)
+}
+
+func (ctrl *ModeracionControlador) moderar(c *gin.Context, tipo string) {
+	comentarioID := c.Param("id")
+
+	var input request.ModeracionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	err := ctrl.servicio.ModerarComentario(c.Request.Context(), input.Email, input.Password, tipo, comentarioID, input.Accion)
+	if err != nil {
+		responderErrorModeracion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comentario moderado exitosamente"})
+}
+
+func responderErrorModeracion(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "comentario no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "acceso restringido a moderadores":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "acción de moderación inválida", "tipo de comentario inválido":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
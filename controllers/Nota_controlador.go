@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotaControlador gestiona las rutas de las notas y los marcadores privados de los usuarios.
+type NotaControlador struct {
+	servicio *services.NotaService
+}
+
+// NewNotaControlador crea un nuevo controlador para las notas y los marcadores.
+func NewNotaControlador(servicio *services.NotaService) *NotaControlador {
+	return &NotaControlador{servicio: servicio}
+}
+
+// CrearNota agrega una nota del usuario sobre una clase.
+// @Summary Crear una nota
+// @Description Agrega una nota privada del usuario en un momento del video de la clase. El usuario debe estar inscrito en el curso y la unidad de la clase no debe estar bloqueada.
+// @Tags Notas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param nota body request.NotaRequest true "Credenciales del usuario, momento del video y texto"
+// @Success 201 {object} models.Nota
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/notas [post]
+func (ctrl *NotaControlador) CrearNota(c *gin.Context) {
+	claseID := c.Param("id")
+
+	var input request.NotaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	nota, err := ctrl.servicio.CrearNota(c.Request.Context(), claseID, input.Email, input.Password, input.Segundo, input.Texto)
+	if err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, nota)
+}
+
+// EditarNota cambia el momento y el texto de una nota.
+// @Summary Editar una nota
+// @Description Cambia el momento del video y el texto de una nota. Solo su autor puede hacerlo.
+// @Tags Notas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la nota"
+// @Param nota body request.NotaRequest true "Credenciales del usuario, momento del video y texto"
+// @Success 200 {object} models.Nota
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/notas/{id} [put]
+func (ctrl *NotaControlador) EditarNota(c *gin.Context) {
+	notaID := c.Param("id")
+
+	var input request.NotaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	nota, err := ctrl.servicio.EditarNota(c.Request.Context(), notaID, input.Email, input.Password, input.Segundo, input.Texto)
+	if err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nota)
+}
+
+// EliminarNota elimina una nota.
+// @Summary Eliminar una nota
+// @Description Elimina una nota. Solo su autor puede hacerlo.
+// @Tags Notas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la nota"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del usuario"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/notas/{id} [delete]
+func (ctrl *NotaControlador) EliminarNota(c *gin.Context) {
+	notaID := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.EliminarNota(c.Request.Context(), notaID, input.Email, input.Password); err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Nota eliminada exitosamente"})
+}
+
+// ObtenerNotasPorCurso obtiene las notas del usuario en un curso.
+// @Summary Obtener las notas de un curso
+// @Description Devuelve las notas del usuario en el curso, en el orden de las clases y, dentro de cada clase, por momento del video.
+// @Tags Notas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {array} models.Nota
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/notas [get]
+func (ctrl *NotaControlador) ObtenerNotasPorCurso(c *gin.Context) {
+	cursoID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	notas, err := ctrl.servicio.ObtenerNotasPorCurso(c.Request.Context(), cursoID, email, password)
+	if err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notas)
+}
+
+// ExportarNotas descarga las notas del usuario en un curso como documento Markdown.
+// @Summary Exportar las notas de un curso
+// @Description Devuelve un documento Markdown con todas las notas del usuario en el curso, agrupadas por unidad y clase.
+// @Tags Notas
+// @Produce text/markdown
+// @Param id path string true "ID del curso"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {file} file
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/notas/exportar [get]
+func (ctrl *NotaControlador) ExportarNotas(c *gin.Context) {
+	cursoID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	curso, documento, err := ctrl.servicio.ExportarNotas(c.Request.Context(), cursoID, email, password)
+	if err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "Notas - " + curso + ".md"}))
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", documento)
+}
+
+// GuardarMarcador agrega una clase a los marcadores del usuario.
+// @Summary Guardar una clase en los marcadores
+// @Description Agrega la clase a los marcadores del usuario. Si ya estaba guardada devuelve el marcador existente. El usuario debe estar inscrito en el curso.
+// @Tags Notas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del usuario"
+// @Success 200 {object} models.Marcador
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/marcador [put]
+func (ctrl *NotaControlador) GuardarMarcador(c *gin.Context) {
+	claseID := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	marcador, err := ctrl.servicio.GuardarMarcador(c.Request.Context(), claseID, input.Email, input.Password)
+	if err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, marcador)
+}
+
+// EliminarMarcador quita una clase de los marcadores del usuario.
+// @Summary Quitar una clase de los marcadores
+// @Description Quita la clase de los marcadores del usuario.
+// @Tags Notas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del usuario"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/marcador [delete]
+func (ctrl *NotaControlador) EliminarMarcador(c *gin.Context) {
+	claseID := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.EliminarMarcador(c.Request.Context(), claseID, input.Email, input.Password); err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Marcador eliminado exitosamente"})
+}
+
+// ObtenerMarcadoresPorCurso obtiene los marcadores del usuario en un curso.
+// @Summary Obtener los marcadores de un curso
+// @Description Devuelve las clases del curso que el usuario guardó en sus marcadores, en el orden del curso.
+// @Tags Notas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {array} models.Marcador
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/marcadores [get]
+func (ctrl *NotaControlador) ObtenerMarcadoresPorCurso(c *gin.Context) {
+	cursoID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	marcadores, err := ctrl.servicio.ObtenerMarcadoresPorCurso(c.Request.Context(), cursoID, email, password)
+	if err != nil {
+		responderErrorNota(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, marcadores)
+}
+
+func responderErrorNota(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "clase no encontrada", "unidad no encontrada", "curso no encontrado",
+		"nota no encontrada", "marcador no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "el usuario no está inscrito en este curso":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "ID inválido", "ID de clase inválido", "ID de curso inválido",
+		"el momento de la nota está fuera de la duración de la clase", "el texto de la nota no puede estar vacío":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		if strings.HasPrefix(err.Error(), "la clase está bloqueada") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"go-API/request"
+	"go-API/response"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificacionControlador gestiona las rutas de las notificaciones dentro de la aplicación.
+type NotificacionControlador struct {
+	servicio *services.NotificacionService
+}
+
+// NewNotificacionControlador crea un nuevo controlador para las notificaciones.
+func NewNotificacionControlador(servicio *services.NotificacionService) *NotificacionControlador {
+	return &NotificacionControlador{servicio: servicio}
+}
+
+// ObtenerNotificaciones obtiene las notificaciones de un usuario.
+// @Summary Obtener notificaciones
+// @Description Devuelve las notificaciones del usuario, de la más reciente a la más antigua.
+// @Tags Notificaciones
+// @Accept json
+// @Produce json
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Param solo_no_leidas query bool false "Solo las notificaciones sin leer"
+// @Param limite query int false "Cantidad máxima de notificaciones (10 por defecto, máximo 50)"
+// @Success 200 {array} models.Notificacion
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/notificaciones [get]
+func (ctrl *NotificacionControlador) ObtenerNotificaciones(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	limite, ok := parsearLimite(c)
+	if !ok {
+		return
+	}
+	soloNoLeidas := c.Query("solo_no_leidas") == "true"
+
+	notificaciones, err := ctrl.servicio.ObtenerNotificaciones(c.Request.Context(), email, password, soloNoLeidas, limite)
+	if err != nil {
+		responderErrorNotificacion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notificaciones)
+}
+
+// ContarNoLeidas obtiene la cantidad de notificaciones sin leer de un usuario.
+// @Summary Contar notificaciones sin leer
+// @Description Devuelve la cantidad de notificaciones que el usuario todavía no leyó.
+// @Tags Notificaciones
+// @Accept json
+// @Produce json
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {object} response.NotificacionesNoLeidasResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/notificaciones/no_leidas [get]
+func (ctrl *NotificacionControlador) ContarNoLeidas(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	noLeidas, err := ctrl.servicio.ContarNoLeidas(c.Request.Context(), email, password)
+	if err != nil {
+		responderErrorNotificacion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NotificacionesNoLeidasResponse{NoLeidas: noLeidas})
+}
+
+// MarcarLeidas marca notificaciones de un usuario como leídas.
+// @Summary Marcar notificaciones como leídas
+// @Description Marca como leídas las notificaciones indicadas, o todas si no se indica ninguna.
+// @Tags Notificaciones
+// @Accept json
+// @Produce json
+// @Param notificaciones body request.MarcarNotificacionesLeidasRequest true "Credenciales del usuario e IDs de las notificaciones"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/notificaciones/leidas [post]
+func (ctrl *NotificacionControlador) MarcarLeidas(c *gin.Context) {
+	var input request.MarcarNotificacionesLeidasRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.MarcarLeidas(c.Request.Context(), input.Email, input.Password, input.IDs); err != nil {
+		responderErrorNotificacion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notificaciones marcadas como leídas"})
+}
+
+// ObtenerPreferencias obtiene las preferencias de notificación de un usuario.
+// @Summary Obtener preferencias de notificación
+// @Description Devuelve qué tipos de notificación (respuesta, mencion, inscripcion, certificado, anuncio) tiene activados el usuario.
+// @Tags Notificaciones
+// @Accept json
+// @Produce json
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/notificaciones/preferencias [get]
+func (ctrl *NotificacionControlador) ObtenerPreferencias(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	preferencias, err := ctrl.servicio.ObtenerPreferencias(c.Request.Context(), email, password)
+	if err != nil {
+		responderErrorNotificacion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferencias)
+}
+
+// ActualizarPreferencias activa o desactiva tipos de notificación para un usuario.
+// @Summary Actualizar preferencias de notificación
+// @Description Activa o desactiva tipos de notificación. Los tipos no incluidos conservan su preferencia actual.
+// @Tags Notificaciones
+// @Accept json
+// @Produce json
+// @Param preferencias body request.PreferenciasNotificacionRequest true "Credenciales del usuario y preferencias por tipo"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/notificaciones/preferencias [put]
+func (ctrl *NotificacionControlador) ActualizarPreferencias(c *gin.Context) {
+	var input request.PreferenciasNotificacionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	preferencias, err := ctrl.servicio.ActualizarPreferencias(c.Request.Context(), input.Email, input.Password, input.Preferencias)
+	if err != nil {
+		responderErrorNotificacion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferencias)
+}
+
+func responderErrorNotificacion(c *gin.Context, err error) {
+	switch {
+	case err.Error() == "usuario no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "tipo de notificación inválido"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortadaControlador gestiona las rutas de las imágenes de portada de los cursos.
+type PortadaControlador struct {
+	servicio *services.PortadaService
+}
+
+// NewPortadaControlador crea un nuevo controlador para las portadas de curso.
+func NewPortadaControlador(servicio *services.PortadaService) *PortadaControlador {
+	return &PortadaControlador{servicio: servicio}
+}
+
+// SubirPortada sube la imagen de portada de un curso.
+// @Summary Subir la portada de un curso
+// @Description Sube una imagen JPEG, PNG o GIF de entre 320x180 y 4096x4096 píxeles (10 MB como máximo) como portada del curso y genera sus miniaturas pequeña (240 px de ancho) y mediana (640 px). Reemplaza la imagen del curso. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Cursos
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param email formData string true "Correo del instructor"
+// @Param password formData string true "Contraseña del instructor"
+// @Param imagen formData file true "Imagen de portada"
+// @Success 200 {object} models.PortadaCurso
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 415 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/portada [put]
+func (ctrl *PortadaControlador) SubirPortada(c *gin.Context) {
+	cursoID := c.Param("id")
+	if !leerFormularioSubida(c, services.MaxTamanoPortada, "la imagen") {
+		return
+	}
+	email := c.PostForm("email")
+	password := c.PostForm("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	encabezado, err := c.FormFile("imagen")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "La imagen es requerida"})
+		return
+	}
+
+	archivo, err := encabezado.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer archivo.Close()
+
+	portada, err := ctrl.servicio.SubirPortada(c.Request.Context(), cursoID, email, password, archivo)
+	if err != nil {
+		switch err.Error() {
+		case "usuario no encontrado", "curso no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "solo el instructor del curso o un administrador puede cambiar la portada":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "formato de imagen no permitido":
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		case "ID de curso inválido":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			if strings.HasPrefix(err.Error(), "la imagen debe medir") {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			} else if strings.HasPrefix(err.Error(), "la imagen supera el tamaño máximo") {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, portada)
+}
+
+// ObtenerPortada devuelve la imagen de portada de un curso.
+// @Summary Obtener la portada de un curso
+// @Description Devuelve la portada del curso en su tamaño original o como miniatura JPEG. Si se pide la versión vigente (parámetro v de las URLs del curso), la respuesta puede guardarse en caché sin vencimiento. La portada de un curso no publicado solo la ve quien puede ver el curso.
+// @Tags Cursos
+// @Produce image/jpeg,image/png,image/gif
+// @Param id path string true "ID del curso"
+// @Param tamano path string true "original, pequena o mediana"
+// @Param v query string false "Versión de la portada"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {file} file
+// @Success 304 "La imagen no cambió"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/portada/{tamano} [get]
+func (ctrl *PortadaControlador) ObtenerPortada(c *gin.Context) {
+	cursoID := c.Param("id")
+	tamano := c.Param("tamano")
+
+	archivo, tipoContenido, version, err := ctrl.servicio.AbrirPortada(c.Request.Context(), cursoID, tamano, c.Query("email"), c.Query("password"))
+	if err != nil {
+		switch err.Error() {
+		case "curso no encontrado", "el curso no tiene portada", "usuario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "ID de curso inválido", "tamaño de portada inválido":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	defer archivo.Close()
+
+	// Las URLs versionadas no cambian de contenido; sin versión se revalida a los pocos minutos
+	etag := `"` + version + "-" + tamano + `"`
+	c.Header("ETag", etag)
+	if c.Query("v") == version {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "public, max-age=300")
+	}
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, -1, tipoContenido, archivo, nil)
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"net/http"
+
+	"go-API/request"
+	"go-API/response"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreguntaControlador gestiona las rutas del modo de preguntas y respuestas de los comentarios de clase.
+type PreguntaControlador struct {
+	servicio *services.PreguntaService
+}
+
+// NewPreguntaControlador crea un nuevo controlador para las preguntas.
+func NewPreguntaControlador(servicio *services.PreguntaService) *PreguntaControlador {
+	return &PreguntaControlador{servicio: servicio}
+}
+
+// ObtenerPreguntasPorCurso obtiene las preguntas de las clases de un curso.
+// @Summary Obtener las preguntas de un curso
+// @Description Devuelve las preguntas hechas en las clases de un curso, de la más antigua a la más reciente. Por defecto solo las que no tienen respuesta aceptada ni respuesta del instructor. Requiere poder ver el curso.
+// @Tags Preguntas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param estado query string false "sin_responder (por defecto), respondidas o todas"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.Pregunta
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/preguntas [get]
+func (ctrl *PreguntaControlador) ObtenerPreguntasPorCurso(c *gin.Context) {
+	cursoID := c.Param("id")
+
+	estado := c.DefaultQuery("estado", services.EstadoPreguntaSinResponder)
+	if estado != services.EstadoPreguntaSinResponder && estado != services.EstadoPreguntaRespondida && estado != services.EstadoPreguntaTodas {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El parámetro estado debe ser sin_responder, respondidas o todas"})
+		return
+	}
+
+	preguntas, err := ctrl.servicio.ObtenerPreguntasPorCurso(c.Request.Context(), cursoID, c.Query("email"), c.Query("password"), estado)
+	if err != nil {
+		if err.Error() == "curso no encontrado" || err.Error() == "usuario no encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, preguntas)
+}
+
+// ObtenerPanelInstructor obtiene las preguntas sin responder de los cursos de un instructor.
+// @Summary Panel de preguntas del instructor
+// @Description Devuelve las preguntas sin responder de todos los cursos que dicta el usuario, agrupadas por curso, y el total pendiente.
+// @Tags Preguntas
+// @Accept json
+// @Produce json
+// @Param email query string true "Correo del instructor"
+// @Param password query string true "Contraseña del instructor"
+// @Success 200 {object} response.PanelInstructorResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/instructores/preguntas [get]
+func (ctrl *PreguntaControlador) ObtenerPanelInstructor(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	cursos, total, err := ctrl.servicio.ObtenerPanelInstructor(c.Request.Context(), email, password)
+	if err != nil {
+		if err.Error() == "usuario no encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.PanelInstructorResponse{
+		TotalSinResponder: total,
+		Cursos:            cursos,
+	})
+}
+
+// AceptarRespuesta marca una respuesta como la respuesta aceptada de una pregunta.
+// @Summary Aceptar la respuesta de una pregunta
+// @Description Marca una respuesta directa de la pregunta como respuesta aceptada. Solo el instructor del curso o un administrador puede hacerlo; aceptar otra respuesta reemplaza la anterior.
+// @Tags Preguntas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la pregunta"
+// @Param respuesta body request.AceptarRespuestaRequest true "Credenciales del instructor y ID de la respuesta"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/comentarios/{id}/respuesta_aceptada [post]
+func (ctrl *PreguntaControlador) AceptarRespuesta(c *gin.Context) {
+	preguntaID := c.Param("id")
+
+	var input request.AceptarRespuestaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	err := ctrl.servicio.AceptarRespuesta(c.Request.Context(), preguntaID, input.RespuestaID, input.Email, input.Password)
+	if err != nil {
+		switch err.Error() {
+		case "usuario no encontrado", "comentario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "el comentario no es una pregunta", "la respuesta no pertenece a la pregunta":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "solo el instructor del curso o un administrador puede aceptar respuestas":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Respuesta aceptada exitosamente"})
+}
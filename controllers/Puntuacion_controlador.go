@@ -3,6 +3,7 @@ package controllers
 import (
     "net/http"
 
+    "go-API/request"
     "go-API/services"
 
     "github.com/gin-gonic/gin"
@@ -60,11 +61,13 @@ func (ctrl *PuntuacionesControlador) CrearPuntuacionParaCurso(c *gin.Context) {
 
 // ObtenerPromedioPuntuacion obtiene el promedio de puntuaciones de un curso.
 // @Summary Obtener el promedio de puntuaciones de un curso
-// @Description Devuelve el promedio de puntuaciones de un curso por su ID
+// @Description Devuelve el promedio de puntuaciones de un curso por su ID, si el usuario puede ver el curso
 // @Tags Puntuaciones
 // @Accept json
 // @Produce json
 // @Param id path string true "ID del curso"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
 // @Success 200 {object} map[string]float64 "promedio: 0.0"
 // @Failure 400 {object} map[string]string "error: Bad Request"
 // @Failure 404 {object} map[string]string "error: Not Found"
@@ -73,9 +76,9 @@ func (ctrl *PuntuacionesControlador) CrearPuntuacionParaCurso(c *gin.Context) {
 func (ctrl *PuntuacionesControlador) ObtenerPromedioPuntuacion(c *gin.Context) {
     id := c.Param("id")
 
-    promedio, err := ctrl.servicio.ObtenerPromedioPuntuacion(id)
+    promedio, err := ctrl.servicio.ObtenerPromedioPuntuacion(id, c.Query("email"), c.Query("password"))
     if err != nil {
-        if err.Error() == "no se encontraron puntuaciones" {
+        if err.Error() == "no se encontraron puntuaciones" || err.Error() == "curso no encontrado" || err.Error() == "usuario no encontrado" {
             c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
         } else {
             c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -86,6 +89,70 @@ func (ctrl *PuntuacionesControlador) ObtenerPromedioPuntuacion(c *gin.Context) {
     c.JSON(http.StatusOK, gin.H{"promedio": promedio})
 }
 
+// ObtenerEstadisticasPuntuacion obtiene la distribución de puntuaciones de un curso.
+// @Summary Obtener las estadísticas de puntuación de un curso
+// @Description Devuelve el histograma de puntuaciones (0 a 5 estrellas), la cantidad, el promedio, la mediana, la desviación estándar y el promedio bayesiano de un curso. Requiere poder ver el curso
+// @Tags Puntuaciones
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {object} models.EstadisticasPuntuacion
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/puntuaciones/cursos/{id}/estadisticas [get]
+func (ctrl *PuntuacionesControlador) ObtenerEstadisticasPuntuacion(c *gin.Context) {
+    id := c.Param("id")
+
+    estadisticas, err := ctrl.servicio.ObtenerEstadisticasPuntuacion(id, c.Query("email"), c.Query("password"))
+    if err != nil {
+        if err.Error() == "curso no encontrado" || err.Error() == "usuario no encontrado" {
+            c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+        } else {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        }
+        return
+    }
+
+    c.JSON(http.StatusOK, estadisticas)
+}
+
+// ReconciliarValoraciones recalcula la valoración de todos los cursos desde Neo4j.
+// @Summary Reconciliar las valoraciones de los cursos
+// @Description Recalcula la valoración y la valoración ponderada de todos los cursos a partir de sus puntuaciones en Neo4j y devuelve los cursos cuya valoración almacenada no coincidía. Solo disponible para administradores.
+// @Tags Puntuaciones
+// @Accept json
+// @Produce json
+// @Param credenciales body request.CredencialesRequest true "Credenciales del administrador"
+// @Success 200 {array} models.DiferenciaValoracion
+// @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 403 {object} map[string]string "error: Forbidden"
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/puntuaciones/reconciliar [post]
+func (ctrl *PuntuacionesControlador) ReconciliarValoraciones(c *gin.Context) {
+    var input request.CredencialesRequest
+    if err := c.ShouldBindJSON(&input); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    diferencias, err := ctrl.servicio.ReconciliarValoraciones(input.Email, input.Password)
+    if err != nil {
+        if err.Error() == "acceso restringido a administradores" {
+            c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+        } else if err.Error() == "usuario no encontrado" {
+            c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+        } else {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        }
+        return
+    }
+
+    c.JSON(http.StatusOK, diferencias)
+}
+
 // ObtenerPuntuacionesPorUsuario obtiene todas las puntuaciones hechas por un usuario.
 // @Summary Obtener todas las puntuaciones hechas por un usuario
 // @Description Devuelve todas las puntuaciones hechas por un usuario por su email
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"net/http"
+
+	"go-API/request"
+	"go-API/response"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReaccionControlador gestiona las rutas de reacciones a clases y comentarios de clase.
+type ReaccionControlador struct {
+	servicio *services.ReaccionService
+}
+
+// NewReaccionControlador crea un nuevo controlador para las reacciones.
+func NewReaccionControlador(servicio *services.ReaccionService) *ReaccionControlador {
+	return &ReaccionControlador{servicio: servicio}
+}
+
+// ReaccionarComentario registra la reacción de un usuario a un comentario de clase.
+// @Summary Reaccionar a un comentario de clase
+// @Description Registra un "me_gusta" o "no_me_gusta" del usuario sobre un comentario. Cada usuario tiene una única reacción: repetirla la quita y elegir la opuesta la reemplaza.
+// @Tags Reacciones
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param reaccion body request.ReaccionRequest true "Reacción"
+// @Success 200 {object} response.ReaccionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/comentarios/{id}/reaccion [post]
+func (ctrl *ReaccionControlador) ReaccionarComentario(c *gin.Context) {
+	comentarioID := c.Param("id")
+
+	var input request.ReaccionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	reaccion, meGusta, noMeGusta, err := ctrl.servicio.ReaccionarComentario(c.Request.Context(), input.Email, input.Password, comentarioID, input.Tipo)
+	if err != nil {
+		responderErrorReaccion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ReaccionResponse{Reaccion: reaccion, MeGusta: meGusta, NoMeGusta: noMeGusta})
+}
+
+// ReaccionarClase registra la reacción de un usuario a una clase.
+// @Summary Reaccionar a una clase
+// @Description Registra un "me_gusta" o "no_me_gusta" del usuario sobre una clase. Cada usuario tiene una única reacción: repetirla la quita y elegir la opuesta la reemplaza.
+// @Tags Reacciones
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param reaccion body request.ReaccionRequest true "Reacción"
+// @Success 200 {object} response.ReaccionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/reaccion [post]
+func (ctrl *ReaccionControlador) ReaccionarClase(c *gin.Context) {
+	claseID := c.Param("id")
+
+	var input request.ReaccionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	reaccion, meGusta, noMeGusta, err := ctrl.servicio.ReaccionarClase(c.Request.Context(), input.Email, input.Password, claseID, input.Tipo)
+	if err != nil {
+		responderErrorReaccion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ReaccionResponse{Reaccion: reaccion, MeGusta: meGusta, NoMeGusta: noMeGusta})
+}
+
+func responderErrorReaccion(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "clase no encontrada", "comentario no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "ID de clase inválido":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
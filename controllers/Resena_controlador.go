@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-API/request"
+	"go-API/response"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResenaControlador gestiona las rutas relacionadas con las reseñas de cursos.
+type ResenaControlador struct {
+	servicio *services.ResenaService
+}
+
+// NewResenaControlador crea un nuevo controlador para las reseñas.
+func NewResenaControlador(servicio *services.ResenaService) *ResenaControlador {
+	return &ResenaControlador{servicio: servicio}
+}
+
+// CrearResena crea una reseña (puntuación + texto) para un curso.
+// @Summary Crear una reseña para un curso
+// @Description Agrega una reseña a un curso. El usuario se identifica por email y password y debe estar inscrito en el curso. La puntuación de la reseña reemplaza la puntuación previa del usuario, si existía.
+// @Tags Reseñas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param resena body request.CreateResenaRequest true "Reseña a crear"
+// @Success 201 {object} models.Resena
+// @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/cursos/{id}/resenas [post]
+func (ctrl *ResenaControlador) CrearResena(c *gin.Context) {
+	cursoID := c.Param("id")
+
+	var input request.CreateResenaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	resena, err := ctrl.servicio.CrearResena(c.Request.Context(), input.Email, input.Password, cursoID, input.Valor, input.Texto)
+	if err != nil {
+		switch err.Error() {
+		case "usuario no encontrado", "usuario o curso no encontrado", "el usuario no está inscrito en este curso":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "la puntuación debe estar entre 0 y 5", "la reseña debe tener al menos 15 caracteres",
+			"el usuario ya escribió una reseña para este curso", "ID de curso inválido":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, resena)
+}
+
+// ObtenerResenasPorCurso obtiene las reseñas de un curso de forma paginada.
+// @Summary Obtener las reseñas de un curso
+// @Description Devuelve las reseñas de un curso paginadas, ordenadas por fecha (recientes) o por utilidad. Si el curso no está publicado, solo las ve quien puede ver el curso
+// @Tags Reseñas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param orden query string false "recientes (por defecto) o utilidad"
+// @Param pagina query int false "Número de página (desde 1)"
+// @Param limite query int false "Reseñas por página (máximo 50)"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {object} response.ResenasPaginadasResponse
+// @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/cursos/{id}/resenas [get]
+func (ctrl *ResenaControlador) ObtenerResenasPorCurso(c *gin.Context) {
+	cursoID := c.Param("id")
+
+	pagina, limite, ok := parsearPaginacion(c)
+	if !ok {
+		return
+	}
+
+	resenas, total, err := ctrl.servicio.ObtenerResenasPorCurso(c.Request.Context(), cursoID, c.Query("email"), c.Query("password"), c.Query("orden"), pagina, limite)
+	if err != nil {
+		switch err.Error() {
+		case "curso no encontrado", "usuario no encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ResenasPaginadasResponse{
+		Resenas: resenas,
+		Pagina:  pagina,
+		Limite:  limite,
+		Total:   total,
+	})
+}
+
+// VotarResena registra si una reseña le resultó útil a un usuario.
+// @Summary Votar la utilidad de una reseña
+// @Description Registra un voto "útil" o "no útil" sobre una reseña. Cada usuario tiene un único voto por reseña y no puede votar la suya.
+// @Tags Reseñas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la reseña"
+// @Param voto body request.VotoResenaRequest true "Voto"
+// @Success 200 {object} models.Resena
+// @Failure 400 {object} map[string]string "error: Bad Request"
+// @Failure 404 {object} map[string]string "error: Not Found"
+// @Failure 500 {object} map[string]string "error: Internal Server Error"
+// @Router /api/resenas/{id}/votos [post]
+func (ctrl *ResenaControlador) VotarResena(c *gin.Context) {
+	resenaID := c.Param("id")
+
+	var input request.VotoResenaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	resena, err := ctrl.servicio.VotarResena(c.Request.Context(), input.Email, input.Password, resenaID, *input.Util)
+	if err != nil {
+		switch err.Error() {
+		case "usuario no encontrado", "reseña no encontrada":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "no puede votar su propia reseña":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resena)
+}
+
+// parsearPaginacion lee los parámetros de consulta pagina y limite. Si son inválidos
+// responde con un error 400 y devuelve ok = false.
+func parsearPaginacion(c *gin.Context) (pagina, limite int, ok bool) {
+	pagina, err := strconv.Atoi(c.DefaultQuery("pagina", "1"))
+	if err != nil || pagina < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El parámetro pagina debe ser un número mayor que 0"})
+		return 0, 0, false
+	}
+
+	limite, ok = parsearLimite(c)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return pagina, limite, true
+}
+
+// parsearLimite lee el parámetro de consulta limite (10 por defecto, máximo 50). Si es inválido
+// responde con un error 400 y devuelve ok = false.
+func parsearLimite(c *gin.Context) (limite int, ok bool) {
+	limite, err := strconv.Atoi(c.DefaultQuery("limite", "10"))
+	if err != nil || limite < 1 || limite > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El parámetro limite debe ser un número entre 1 y 50"})
+		return 0, false
+	}
+
+	return limite, true
+}
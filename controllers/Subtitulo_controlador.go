@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubtituloControlador gestiona las rutas de los subtítulos de las clases y la búsqueda en sus transcripciones.
+type SubtituloControlador struct {
+	servicio *services.SubtituloService
+}
+
+// NewSubtituloControlador crea un nuevo controlador para los subtítulos.
+func NewSubtituloControlador(servicio *services.SubtituloService) *SubtituloControlador {
+	return &SubtituloControlador{servicio: servicio}
+}
+
+// SubirSubtitulos sube los subtítulos de una clase en un idioma.
+// @Summary Subir los subtítulos de una clase
+// @Description Sube un archivo WebVTT (.vtt) o SRT (.srt) de 2 MB como máximo con los subtítulos de la clase en un idioma, reemplazando los anteriores de ese idioma, e indexa su texto para la búsqueda. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Subtitulos
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param email formData string true "Correo del instructor"
+// @Param password formData string true "Contraseña del instructor"
+// @Param idioma formData string true "Código de idioma (por ejemplo es o en-US)"
+// @Param archivo formData file true "Archivo de subtítulos"
+// @Success 201 {object} models.PistaSubtitulos
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 415 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/subtitulos [post]
+func (ctrl *SubtituloControlador) SubirSubtitulos(c *gin.Context) {
+	claseID := c.Param("id")
+	if !leerFormularioSubida(c, services.MaxTamanoSubtitulos, "el archivo") {
+		return
+	}
+	email := c.PostForm("email")
+	password := c.PostForm("password")
+	idioma := c.PostForm("idioma")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	encabezado, err := c.FormFile("archivo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El archivo es requerido"})
+		return
+	}
+
+	archivo, err := encabezado.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer archivo.Close()
+
+	pista, err := ctrl.servicio.SubirSubtitulos(c.Request.Context(), claseID, email, password, idioma, encabezado.Filename, archivo)
+	if err != nil {
+		responderErrorSubtitulos(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, pista)
+}
+
+// ObtenerSubtitulos obtiene las pistas de subtítulos de una clase.
+// @Summary Obtener los subtítulos de una clase
+// @Description Devuelve las pistas de subtítulos disponibles de la clase, una por idioma, con la URL de su archivo WebVTT. Solo pueden verlas los inscritos en el curso, su instructor y los administradores; los inscritos no pueden ver las de una unidad bloqueada.
+// @Tags Subtitulos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {array} models.PistaSubtitulos
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/subtitulos [get]
+func (ctrl *SubtituloControlador) ObtenerSubtitulos(c *gin.Context) {
+	claseID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	pistas, err := ctrl.servicio.ObtenerSubtitulos(c.Request.Context(), claseID, email, password)
+	if err != nil {
+		responderErrorSubtitulos(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pistas)
+}
+
+// DescargarSubtitulos devuelve el archivo WebVTT de los subtítulos de una clase.
+// @Summary Descargar los subtítulos de una clase
+// @Description Devuelve los subtítulos de la clase en el idioma indicado en formato WebVTT, para usarlos en el elemento track de un reproductor. Tiene los mismos permisos que la lista de pistas. Si se pide la versión vigente (parámetro v de la URL de la pista), la respuesta puede guardarse en caché sin vencimiento.
+// @Tags Subtitulos
+// @Produce text/vtt
+// @Param id path string true "ID de la clase"
+// @Param idioma path string true "Código de idioma"
+// @Param v query string false "Versión de la pista"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {file} file
+// @Success 304 "Los subtítulos no cambiaron"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/subtitulos/{idioma} [get]
+func (ctrl *SubtituloControlador) DescargarSubtitulos(c *gin.Context) {
+	claseID := c.Param("id")
+	idioma := c.Param("idioma")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	pista, archivo, err := ctrl.servicio.AbrirSubtitulos(c.Request.Context(), claseID, idioma, email, password)
+	if err != nil {
+		responderErrorSubtitulos(c, err)
+		return
+	}
+	defer archivo.Close()
+
+	// Los reproductores cargan las pistas con CORS cuando el video está en otro origen
+	etag := `"` + pista.Version + `"`
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Header("ETag", etag)
+	if c.Query("v") == pista.Version {
+		c.Header("Cache-Control", "private, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "private, max-age=300")
+	}
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, -1, "text/vtt; charset=utf-8", archivo, nil)
+}
+
+// EliminarSubtitulos elimina los subtítulos de una clase en un idioma.
+// @Summary Eliminar los subtítulos de una clase
+// @Description Elimina la pista de subtítulos de la clase en el idioma indicado y su transcripción. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Subtitulos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la clase"
+// @Param idioma path string true "Código de idioma"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/clases/{id}/subtitulos/{idioma} [delete]
+func (ctrl *SubtituloControlador) EliminarSubtitulos(c *gin.Context) {
+	claseID := c.Param("id")
+	idioma := c.Param("idioma")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	if err := ctrl.servicio.EliminarSubtitulos(c.Request.Context(), claseID, idioma, input.Email, input.Password); err != nil {
+		responderErrorSubtitulos(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Subtítulos eliminados exitosamente"})
+}
+
+// BuscarEnTranscripciones busca una frase en los subtítulos de las clases de un curso.
+// @Summary Buscar en las transcripciones de un curso
+// @Description Devuelve los segmentos de subtítulos de las clases del curso en los que se dice la frase buscada, con la clase y el segundo del video en que empieza cada uno. Ignora mayúsculas, tildes y puntuación. Solo pueden buscar los inscritos en el curso, su instructor y los administradores; a los inscritos no se les devuelven las clases de unidades bloqueadas.
+// @Tags Subtitulos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param q query string true "Frase a buscar"
+// @Param idioma query string false "Buscar solo en los subtítulos de este idioma"
+// @Param limite query int false "Cantidad máxima de resultados (1 a 50, por defecto 10)"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {array} models.ResultadoTranscripcion
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/transcripciones [get]
+func (ctrl *SubtituloControlador) BuscarEnTranscripciones(c *gin.Context) {
+	cursoID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	limite, ok := parsearLimite(c)
+	if !ok {
+		return
+	}
+
+	resultados, err := ctrl.servicio.BuscarEnTranscripciones(c.Request.Context(), cursoID, c.Query("q"), c.Query("idioma"), email, password, limite)
+	if err != nil {
+		responderErrorSubtitulos(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resultados)
+}
+
+func responderErrorSubtitulos(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "clase no encontrada", "unidad no encontrada", "curso no encontrado", "subtítulos no encontrados":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede gestionar los subtítulos", "el usuario no está inscrito en este curso":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "ID de clase inválido", "ID de curso inválido", "código de idioma inválido", "la búsqueda no puede estar vacía":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "formato de subtítulos no permitido":
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		if strings.HasPrefix(err.Error(), "subtítulos inválidos") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if strings.HasPrefix(err.Error(), "el archivo supera el tamaño máximo") {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		} else if strings.HasPrefix(err.Error(), "la clase está bloqueada") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"go-API/models"
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TareaControlador gestiona las rutas de las tareas de las unidades y de sus entregas.
+type TareaControlador struct {
+	servicio *services.TareaService
+}
+
+// NewTareaControlador crea un nuevo controlador para las tareas.
+func NewTareaControlador(servicio *services.TareaService) *TareaControlador {
+	return &TareaControlador{servicio: servicio}
+}
+
+// CrearTarea crea una tarea en una unidad.
+// @Summary Crear una tarea
+// @Description Crea una tarea con fecha de entrega y puntaje máximo en la unidad. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Tareas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la unidad"
+// @Param tarea body request.CreateTareaRequest true "Credenciales del instructor y datos de la tarea"
+// @Success 201 {object} models.Tarea
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/unidades/{id}/tareas [post]
+func (ctrl *TareaControlador) CrearTarea(c *gin.Context) {
+	unidadID := c.Param("id")
+
+	var input request.CreateTareaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	tarea := &models.Tarea{
+		Titulo:        input.Titulo,
+		Descripcion:   input.Descripcion,
+		FechaEntrega:  input.FechaEntrega,
+		PuntajeMaximo: input.PuntajeMaximo,
+	}
+	tarea, err := ctrl.servicio.CrearTarea(c.Request.Context(), unidadID, input.Email, input.Password, tarea)
+	if err != nil {
+		responderErrorTarea(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tarea)
+}
+
+// ObtenerTareasPorUnidad obtiene las tareas de una unidad.
+// @Summary Obtener las tareas de una unidad
+// @Description Devuelve las tareas de la unidad ordenadas por fecha de entrega. Requiere poder ver el curso de la unidad y que la unidad no esté bloqueada para el usuario.
+// @Tags Tareas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la unidad"
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.Tarea
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/unidades/{id}/tareas [get]
+func (ctrl *TareaControlador) ObtenerTareasPorUnidad(c *gin.Context) {
+	unidadID := c.Param("id")
+
+	tareas, err := ctrl.servicio.ObtenerTareasPorUnidad(c.Request.Context(), unidadID, c.Query("email"), c.Query("password"))
+	if err != nil {
+		responderErrorTarea(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tareas)
+}
+
+// EntregarTarea sube el archivo de la entrega de una tarea.
+// @Summary Entregar una tarea
+// @Description Sube el archivo de la entrega de un usuario inscrito en el curso, si la unidad de la tarea no está bloqueada para él. Si ya había entregado y la entrega no fue calificada, la reemplaza. Las entregas posteriores a la fecha límite se marcan como atrasadas.
+// @Tags Tareas
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param email formData string true "Correo del usuario"
+// @Param password formData string true "Contraseña del usuario"
+// @Param archivo formData file true "Archivo de la entrega (PDF, texto, imágenes, documentos de Office o ZIP, 25 MB como máximo)"
+// @Success 201 {object} models.Entrega
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/tareas/{id}/entregas [post]
+func (ctrl *TareaControlador) EntregarTarea(c *gin.Context) {
+	tareaID := c.Param("id")
+	if !leerFormularioSubida(c, services.MaxTamanoEntrega, "el archivo") {
+		return
+	}
+	email := c.PostForm("email")
+	password := c.PostForm("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	encabezado, err := c.FormFile("archivo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El archivo es requerido"})
+		return
+	}
+	if encabezado.Size > services.MaxTamanoEntrega {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "el archivo supera el tamaño máximo de 25 MB"})
+		return
+	}
+
+	archivo, err := encabezado.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer archivo.Close()
+
+	entrega, err := ctrl.servicio.EntregarTarea(c.Request.Context(), tareaID, email, password, encabezado.Filename, encabezado.Size, archivo)
+	if err != nil {
+		responderErrorTarea(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entrega)
+}
+
+// ObtenerEntregas obtiene las entregas de una tarea.
+// @Summary Obtener las entregas de una tarea
+// @Description Devuelve las entregas de la tarea con su calificación. Solo el instructor del curso o un administrador puede verlas.
+// @Tags Tareas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param email query string true "Correo del instructor"
+// @Param password query string true "Contraseña del instructor"
+// @Success 200 {array} models.Entrega
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/tareas/{id}/entregas [get]
+func (ctrl *TareaControlador) ObtenerEntregas(c *gin.Context) {
+	tareaID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	entregas, err := ctrl.servicio.ObtenerEntregas(c.Request.Context(), tareaID, email, password)
+	if err != nil {
+		responderErrorTarea(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entregas)
+}
+
+// DescargarEntrega descarga el archivo de una entrega.
+// @Summary Descargar el archivo de una entrega
+// @Description Devuelve el archivo entregado. Pueden descargarlo su autor, el instructor del curso y los administradores.
+// @Tags Tareas
+// @Produce octet-stream
+// @Param id path string true "ID de la entrega"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {file} file
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/entregas/{id}/archivo [get]
+func (ctrl *TareaControlador) DescargarEntrega(c *gin.Context) {
+	entregaID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	entrega, archivo, err := ctrl.servicio.AbrirArchivoEntrega(c.Request.Context(), entregaID, email, password)
+	if err != nil {
+		responderErrorTarea(c, err)
+		return
+	}
+	defer archivo.Close()
+
+	c.DataFromReader(http.StatusOK, entrega.Tamano, entrega.TipoContenido, archivo, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": entrega.NombreArchivo}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// CalificarEntrega califica una entrega.
+// @Summary Calificar una entrega
+// @Description Registra la calificación y el comentario del instructor sobre una entrega. Una entrega calificada ya no puede reemplazarse. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Tareas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la entrega"
+// @Param calificacion body request.CalificarEntregaRequest true "Credenciales del instructor, calificación y comentario"
+// @Success 200 {object} models.Entrega
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/entregas/{id}/calificacion [put]
+func (ctrl *TareaControlador) CalificarEntrega(c *gin.Context) {
+	entregaID := c.Param("id")
+
+	var input request.CalificarEntregaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	entrega, err := ctrl.servicio.CalificarEntrega(c.Request.Context(), entregaID, input.Email, input.Password, *input.Calificacion, input.Comentario)
+	if err != nil {
+		responderErrorTarea(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entrega)
+}
+
+// ObtenerEntregasUsuario obtiene las entregas de un usuario.
+// @Summary Obtener mis entregas
+// @Description Devuelve las entregas del usuario, de la más reciente a la más antigua, con su calificación y el comentario del instructor.
+// @Tags Tareas
+// @Accept json
+// @Produce json
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {array} models.Entrega
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/usuarios/entregas [get]
+func (ctrl *TareaControlador) ObtenerEntregasUsuario(c *gin.Context) {
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	entregas, err := ctrl.servicio.ObtenerEntregasUsuario(c.Request.Context(), email, password)
+	if err != nil {
+		responderErrorTarea(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entregas)
+}
+
+func responderErrorTarea(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "unidad no encontrada", "tarea no encontrada", "entrega no encontrada",
+		"curso no encontrado", "archivo de la entrega no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede gestionar las tareas", "el usuario no está inscrito en este curso":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "la entrega ya fue calificada":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "ID inválido", "ID de unidad inválido", "ID de curso inválido", "el puntaje máximo debe ser mayor que 0",
+		"la calificación debe estar entre 0 y el puntaje máximo de la tarea", "tipo de archivo no permitido":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		if strings.HasPrefix(err.Error(), "el archivo supera el tamaño máximo") {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "la clase está bloqueada") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"go-API/models"
+	"go-API/request"
 	"go-API/services"
 
 	"github.com/gin-gonic/gin"
@@ -21,19 +22,21 @@ func NewUnidadControlador(servicio *services.UnidadService) *UnidadControlador {
 
 // ObtenerUnidadesPorCurso obtiene las unidades de un curso.
 // @Summary Devuelve unidades de un curso
-// @Description Devuelve una unidades de un curso en específico dado su ID
+// @Description Devuelve una unidades de un curso en específico dado su ID, indicando cuáles están bloqueadas para el usuario (o para un usuario no inscrito, sin credenciales) y cuándo se desbloquean, si se conoce
 // @Tags Unidades
 // @Accept json
 // @Produce json
 // @Param id path string true "ID del curso"
-// @Success 200 {object} response.CursoResponse
+// @Param email query string false "Correo del usuario"
+// @Param password query string false "Contraseña del usuario"
+// @Success 200 {array} models.Unidad
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/cursos/{id}/unidades [get]
 func (ctrl *UnidadControlador) ObtenerUnidadesPorCurso(c *gin.Context) {
 	id := c.Param("id")
-	unidades, err := ctrl.servicio.ObtenerUnidadesPorCurso(id)
+	unidades, err := ctrl.servicio.ObtenerUnidadesPorCurso(id, c.Query("email"), c.Query("password"))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -69,3 +72,86 @@ func (ctrl *UnidadControlador) CrearUnidad(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"inserted_id": result.InsertedID})
 }
+
+// ActualizarDesbloqueo define la regla de desbloqueo de una unidad.
+// @Summary Definir la regla de desbloqueo de una unidad
+// @Description Define cuándo se desbloquea la unidad para los inscritos: en una fecha fija (fecha), una cantidad de días después de su inscripción (dias_inscripcion) o al completar la unidad anterior del curso (unidad_anterior). Reemplaza la regla anterior. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Unidades
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la unidad"
+// @Param regla body request.DesbloqueoUnidadRequest true "Credenciales del instructor y regla de desbloqueo"
+// @Success 200 {object} models.Unidad
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/unidades/{id}/desbloqueo [put]
+func (ctrl *UnidadControlador) ActualizarDesbloqueo(c *gin.Context) {
+	id := c.Param("id")
+
+	var input request.DesbloqueoUnidadRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	regla := &models.ReglaDesbloqueo{
+		Tipo:  input.Tipo,
+		Fecha: input.Fecha,
+		Dias:  input.Dias,
+	}
+	unidad, err := ctrl.servicio.ActualizarDesbloqueo(c.Request.Context(), id, input.Email, input.Password, regla)
+	if err != nil {
+		responderErrorUnidad(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, unidad)
+}
+
+// EliminarDesbloqueo quita la regla de desbloqueo de una unidad.
+// @Summary Quitar la regla de desbloqueo de una unidad
+// @Description Quita la regla de desbloqueo de la unidad, que queda disponible para todos los inscritos. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Unidades
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la unidad"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor"
+// @Success 200 {object} models.Unidad
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/unidades/{id}/desbloqueo [delete]
+func (ctrl *UnidadControlador) EliminarDesbloqueo(c *gin.Context) {
+	id := c.Param("id")
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	unidad, err := ctrl.servicio.ActualizarDesbloqueo(c.Request.Context(), id, input.Email, input.Password, nil)
+	if err != nil {
+		responderErrorUnidad(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, unidad)
+}
+
+func responderErrorUnidad(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "unidad no encontrada", "curso no encontrado":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede modificar las unidades":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "ID inválido", "tipo de regla de desbloqueo inválido", "la regla de fecha requiere la fecha de desbloqueo",
+		"la regla de días desde la inscripción requiere una cantidad de días mayor que cero":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -5,6 +5,7 @@ import (
     "go-API/models"
     "go-API/request"
     "net/http"
+    "strings"
 
     "github.com/gin-gonic/gin"
 )
@@ -65,6 +66,7 @@ func (uc *UsuarioControlador) CrearUsuario(c *gin.Context) {
         Nombre:   input.Nombre,
         Email:    input.Email,
         Password: input.Password,
+        Idioma:   input.Idioma,
     }
 
     // Llamar al servicio para crear el usuario
@@ -126,7 +128,14 @@ func (uc *UsuarioControlador) InscribirseACurso(c *gin.Context) {
     // Llamar al servicio para inscribir al usuario en el curso
     err := uc.servicio.InscribirseACurso(inscripcion.Email, inscripcion.Password, inscripcion.CursoID)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        switch err.Error() {
+        case "usuario no encontrado", "curso no encontrado":
+            c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+        case "el curso no está publicado":
+            c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+        default:
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        }
         return
     }
 
@@ -135,13 +144,13 @@ func (uc *UsuarioControlador) InscribirseACurso(c *gin.Context) {
 
 // ObtenerCursosInscritos obtiene los cursos en los que un usuario está inscrito.
 // @Summary Obtener cursos inscritos de un usuario
-// @Description Devuelve la lista de cursos en los que un usuario está inscrito
+// @Description Devuelve la lista de cursos en los que un usuario está inscrito, con la cantidad de anuncios sin leer de cada uno
 // @Tags Usuarios
 // @Accept json
 // @Produce json
 // @Param email query string true "Correo del usuario"
 // @Param password query string true "Contraseña del usuario"
-// @Success 200 {array} models.Curso
+// @Success 200 {array} models.CursoInscrito
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/usuarios/cursos [get]
 func (uc *UsuarioControlador) ObtenerCursosInscritos(c *gin.Context) {
@@ -164,7 +173,7 @@ func (uc *UsuarioControlador) ObtenerCursosInscritos(c *gin.Context) {
 
 // VerClase permite que un usuario vea una clase y actualiza su progreso en el curso.
 // @Summary Ver una clase
-// @Description Permite que un usuario vea una clase y actualiza su progreso en el curso
+// @Description Permite que un usuario vea una clase y actualiza su progreso en el curso. Las clases de una unidad bloqueada se rechazan indicando hasta cuándo están bloqueadas
 // @Tags Usuarios
 // @Accept json
 // @Produce json
@@ -173,6 +182,7 @@ func (uc *UsuarioControlador) ObtenerCursosInscritos(c *gin.Context) {
 // @Param clase_id path string true "ID de la clase"
 // @Success 200 {object} response.VerClaseResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/usuarios/{email}/{password}/clases/{clase_id} [post]
 func (uc *UsuarioControlador) VerClase(c *gin.Context) {
@@ -182,7 +192,11 @@ func (uc *UsuarioControlador) VerClase(c *gin.Context) {
 
     err := uc.servicio.VerClase(email, password, claseID)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        if err.Error() == "debe aprobar el cuestionario de la clase para completarla" || strings.HasPrefix(err.Error(), "la clase está bloqueada") {
+            c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+        } else {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        }
         return
     }
     c.JSON(http.StatusOK, gin.H{"message": "Clase vista exitosamente"})
@@ -190,13 +204,13 @@ func (uc *UsuarioControlador) VerClase(c *gin.Context) {
 
 // ObtenerProgresoCursos obtiene el progreso de los cursos en los que un usuario está inscrito.
 // @Summary Devuelve el progreso de los cursos de un usuario
-// @Description Devuelve el progreso de los cursos en los que un usuario está inscrito
+// @Description Devuelve el progreso de los cursos en los que un usuario está inscrito, con la duración total de cada curso y el tiempo restante de las clases que aún no vio, en segundos
 // @Tags Usuarios
 // @Accept json
 // @Produce json
 // @Param email query string true "Email del usuario"
 // @Param password query string true "Contraseña del usuario"
-// @Success 200 {array} models.ProgresoCurso
+// @Success 200 {array} models.ProgresoCursoDetallado
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
@@ -216,4 +230,60 @@ func (uc *UsuarioControlador) ObtenerProgresoCursos(c *gin.Context) {
     }
 
     c.JSON(http.StatusOK, progresos)
-}
\ No newline at end of file
+}
+
+// SolicitarRestablecimientoPassword envía al usuario un correo con un código para restablecer su contraseña.
+// @Summary Solicitar el restablecimiento de la contraseña
+// @Description Envía un código de un solo uso, válido por una hora, al correo del usuario. La respuesta es la misma exista o no el usuario.
+// @Tags Usuarios
+// @Accept json
+// @Produce json
+// @Param solicitud body request.SolicitudRestablecimientoRequest true "Correo del usuario"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/usuarios/password/solicitud [post]
+func (uc *UsuarioControlador) SolicitarRestablecimientoPassword(c *gin.Context) {
+    var input request.SolicitudRestablecimientoRequest
+    if err := c.ShouldBindJSON(&input); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+        return
+    }
+
+    if err := uc.servicio.SolicitarRestablecimientoPassword(c.Request.Context(), input.Email); err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Si el correo está registrado, recibirás un código para restablecer tu contraseña"})
+}
+
+// RestablecerPassword cambia la contraseña de un usuario con el código recibido por correo.
+// @Summary Restablecer la contraseña
+// @Description Cambia la contraseña del usuario usando el código enviado a su correo. El código solo puede usarse una vez.
+// @Tags Usuarios
+// @Accept json
+// @Produce json
+// @Param restablecimiento body request.RestablecerPasswordRequest true "Código recibido y nueva contraseña"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/usuarios/password/restablecer [post]
+func (uc *UsuarioControlador) RestablecerPassword(c *gin.Context) {
+    var input request.RestablecerPasswordRequest
+    if err := c.ShouldBindJSON(&input); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+        return
+    }
+
+    if err := uc.servicio.RestablecerPassword(c.Request.Context(), input.Token, input.Password); err != nil {
+        if err.Error() == "código inválido o vencido" {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        } else {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        }
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Contraseña restablecida exitosamente"})
+}
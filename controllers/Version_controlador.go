@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-API/request"
+	"go-API/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionControlador gestiona las rutas del historial de versiones de los cursos.
+type VersionControlador struct {
+	servicio *services.VersionService
+}
+
+// NewVersionControlador crea un nuevo controlador para las versiones de los cursos.
+func NewVersionControlador(servicio *services.VersionService) *VersionControlador {
+	return &VersionControlador{servicio: servicio}
+}
+
+// ObtenerVersiones obtiene las versiones guardadas de un curso.
+// @Summary Obtener las versiones de un curso
+// @Description Devuelve las versiones de la estructura del curso, de la más reciente a la más antigua, sin la estructura de cada una. Se guarda una versión nueva con cada cambio en el curso, sus unidades o sus clases. Solo el instructor del curso o un administrador puede consultarlas.
+// @Tags Versiones
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {array} models.VersionCurso
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/versiones [get]
+func (ctrl *VersionControlador) ObtenerVersiones(c *gin.Context) {
+	cursoID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	versiones, err := ctrl.servicio.ObtenerVersiones(c.Request.Context(), cursoID, email, password)
+	if err != nil {
+		responderErrorVersion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versiones)
+}
+
+// ObtenerVersion obtiene una versión de un curso con su estructura.
+// @Summary Obtener una versión de un curso
+// @Description Devuelve una versión del curso con sus datos, sus unidades y sus clases tal como estaban en ese momento. Solo el instructor del curso o un administrador puede consultarla.
+// @Tags Versiones
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param numero path int true "Número de la versión"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {object} models.VersionCurso
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/versiones/{numero} [get]
+func (ctrl *VersionControlador) ObtenerVersion(c *gin.Context) {
+	cursoID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	numero, ok := parsearNumeroVersion(c, c.Param("numero"), "numero")
+	if !ok {
+		return
+	}
+
+	version, err := ctrl.servicio.ObtenerVersion(c.Request.Context(), cursoID, numero, email, password)
+	if err != nil {
+		responderErrorVersion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// CompararVersiones obtiene los cambios entre dos versiones de un curso.
+// @Summary Comparar dos versiones de un curso
+// @Description Devuelve los datos del curso, las unidades y las clases que se agregaron, se eliminaron o se modificaron entre dos versiones, con los valores anteriores y posteriores de los campos modificados. Si no se indica hasta se compara con la versión más reciente. Solo el instructor del curso o un administrador puede compararlas.
+// @Tags Versiones
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param desde query int true "Número de la versión anterior"
+// @Param hasta query int false "Número de la versión posterior (por defecto, la más reciente)"
+// @Param email query string true "Correo del usuario"
+// @Param password query string true "Contraseña del usuario"
+// @Success 200 {object} models.DiferenciaVersiones
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/versiones/diferencias [get]
+func (ctrl *VersionControlador) CompararVersiones(c *gin.Context) {
+	cursoID := c.Param("id")
+	email := c.Query("email")
+	password := c.Query("password")
+
+	if email == "" || password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email y password son requeridos"})
+		return
+	}
+
+	desde, ok := parsearNumeroVersion(c, c.Query("desde"), "desde")
+	if !ok {
+		return
+	}
+	hasta := 0
+	if valor := c.Query("hasta"); valor != "" {
+		if hasta, ok = parsearNumeroVersion(c, valor, "hasta"); !ok {
+			return
+		}
+	}
+
+	diferencia, err := ctrl.servicio.CompararVersiones(c.Request.Context(), cursoID, desde, hasta, email, password)
+	if err != nil {
+		responderErrorVersion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diferencia)
+}
+
+// RestaurarVersion devuelve un curso a una versión anterior.
+// @Summary Restaurar una versión de un curso
+// @Description Devuelve los datos, las unidades y las clases del curso a los de una versión anterior y guarda el resultado como una versión nueva. Las clases que siguen existiendo conservan su ID, sus comentarios, sus adjuntos y el progreso de los inscritos; las unidades y clases que no están en la versión se quitan del curso sin eliminarse y las que se habían eliminado se vuelven a crear sin comentarios ni adjuntos. Los datos del curso, sus unidades y sus clases se restauran en una sola transacción. La portada no forma parte de las versiones y no cambia. Solo el instructor del curso o un administrador puede hacerlo.
+// @Tags Versiones
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del curso"
+// @Param numero path int true "Número de la versión a restaurar"
+// @Param credenciales body request.CredencialesRequest true "Credenciales del instructor o administrador"
+// @Success 200 {object} models.VersionCurso
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/cursos/{id}/versiones/{numero}/restaurar [post]
+func (ctrl *VersionControlador) RestaurarVersion(c *gin.Context) {
+	cursoID := c.Param("id")
+
+	numero, ok := parsearNumeroVersion(c, c.Param("numero"), "numero")
+	if !ok {
+		return
+	}
+
+	var input request.CredencialesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos: " + err.Error()})
+		return
+	}
+
+	version, err := ctrl.servicio.RestaurarVersion(c.Request.Context(), cursoID, numero, input.Email, input.Password)
+	if err != nil {
+		responderErrorVersion(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// parsearNumeroVersion lee un número de versión. Si es inválido responde con un error 400 y devuelve
+// ok = false.
+func parsearNumeroVersion(c *gin.Context, valor, parametro string) (numero int, ok bool) {
+	numero, err := strconv.Atoi(valor)
+	if err != nil || numero < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "El parámetro " + parametro + " debe ser un número de versión mayor que 0"})
+		return 0, false
+	}
+
+	return numero, true
+}
+
+func responderErrorVersion(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuario no encontrado", "curso no encontrado", "versión no encontrada":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "solo el instructor del curso o un administrador puede gestionar las versiones del curso":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "el curso ya tiene la estructura de esa versión":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "ID inválido":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,124 @@
+package correo
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrColaLlena se devuelve al encolar un correo cuando la cola alcanzó su capacidad.
+var ErrColaLlena = errors.New("cola de correo llena")
+
+type trabajo struct {
+	mensaje  Mensaje
+	intentos int
+}
+
+// Cola envía los correos en segundo plano a través de un transporte. Los envíos fallidos se
+// reintentan con espera exponencial hasta agotar los intentos. La cola vive en memoria: los correos
+// pendientes al detener el servidor se descartan.
+type Cola struct {
+	transporte  Transporte
+	pendientes  chan trabajo
+	maxIntentos int
+	espera      time.Duration
+
+	ctx      context.Context
+	cancelar context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewCola crea una cola con la capacidad, la cantidad máxima de intentos por correo y la espera
+// antes del primer reintento indicadas.
+func NewCola(transporte Transporte, capacidad, maxIntentos int, espera time.Duration) *Cola {
+	ctx, cancelar := context.WithCancel(context.Background())
+	return &Cola{
+		transporte:  transporte,
+		pendientes:  make(chan trabajo, capacidad),
+		maxIntentos: maxIntentos,
+		espera:      espera,
+		ctx:         ctx,
+		cancelar:    cancelar,
+	}
+}
+
+// Iniciar lanza los trabajadores que envían los correos encolados.
+func (c *Cola) Iniciar(trabajadores int) {
+	for i := 0; i < trabajadores; i++ {
+		c.wg.Add(1)
+		go c.trabajar()
+	}
+}
+
+// Detener deja de enviar correos y espera a que terminen los envíos en curso.
+func (c *Cola) Detener() {
+	c.cancelar()
+	c.wg.Wait()
+	if descartados := len(c.pendientes); descartados > 0 {
+		log.Printf("Cola de correo detenida con %d correos sin enviar", descartados)
+	}
+}
+
+// Encolar agrega un correo a la cola sin esperar su envío.
+func (c *Cola) Encolar(mensaje Mensaje) error {
+	if err := mensaje.validar(); err != nil {
+		return err
+	}
+	select {
+	case c.pendientes <- trabajo{mensaje: mensaje}:
+		return nil
+	default:
+		return ErrColaLlena
+	}
+}
+
+func (c *Cola) trabajar() {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case t := <-c.pendientes:
+			c.enviar(t)
+		}
+	}
+}
+
+func (c *Cola) enviar(t trabajo) {
+	ctx, cancel := context.WithTimeout(c.ctx, 30*time.Second)
+	defer cancel()
+
+	err := c.transporte.Enviar(ctx, t.mensaje)
+	if err == nil {
+		return
+	}
+
+	t.intentos++
+	if t.intentos >= c.maxIntentos {
+		log.Printf("Correo a %s descartado tras %d intentos: %v", t.mensaje.Para, t.intentos, err)
+		return
+	}
+
+	espera := c.espera << (t.intentos - 1)
+	log.Printf("Error al enviar correo a %s (intento %d), reintento en %s: %v", t.mensaje.Para, t.intentos, espera, err)
+	go c.reintentar(t, espera)
+}
+
+// reintentar vuelve a encolar un correo fallido tras la espera indicada.
+func (c *Cola) reintentar(t trabajo, espera time.Duration) {
+	temporizador := time.NewTimer(espera)
+	defer temporizador.Stop()
+
+	select {
+	case <-c.ctx.Done():
+		log.Printf("Correo a %s descartado al detener la cola", t.mensaje.Para)
+	case <-temporizador.C:
+		select {
+		case c.pendientes <- t:
+		default:
+			log.Printf("Correo a %s descartado: %v", t.mensaje.Para, ErrColaLlena)
+		}
+	}
+}
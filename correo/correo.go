@@ -0,0 +1,56 @@
+package correo
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// NewColaDesdeEntorno crea la cola de correo con el transporte configurado en las variables de
+// entorno:
+//   - CORREO_TRANSPORTE: "archivo" (por defecto) o "smtp"
+//   - CORREO_REMITENTE: dirección del remitente
+//   - CORREO_OUTBOX: directorio del transporte de archivo (por defecto "outbox")
+//   - SMTP_HOST, SMTP_PUERTO, SMTP_USUARIO, SMTP_PASSWORD: servidor del transporte SMTP
+//   - CORREO_REINTENTOS: intentos por correo (por defecto 5)
+func NewColaDesdeEntorno() (*Cola, error) {
+	remitente := valorEntorno("CORREO_REMITENTE", "no-responder@localhost")
+
+	var transporte Transporte
+	switch tipo := valorEntorno("CORREO_TRANSPORTE", "archivo"); tipo {
+	case "archivo":
+		transporte = &TransporteArchivo{
+			Directorio: valorEntorno("CORREO_OUTBOX", "outbox"),
+			Remitente:  remitente,
+		}
+	case "smtp":
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			return nil, fmt.Errorf("SMTP_HOST es requerido para el transporte smtp")
+		}
+		transporte = &TransporteSMTP{
+			Host:      host,
+			Puerto:    valorEntorno("SMTP_PUERTO", "25"),
+			Usuario:   os.Getenv("SMTP_USUARIO"),
+			Password:  os.Getenv("SMTP_PASSWORD"),
+			Remitente: remitente,
+		}
+	default:
+		return nil, fmt.Errorf("transporte de correo desconocido: %s", tipo)
+	}
+
+	intentos, err := strconv.Atoi(valorEntorno("CORREO_REINTENTOS", "5"))
+	if err != nil || intentos < 1 {
+		return nil, fmt.Errorf("CORREO_REINTENTOS debe ser un número mayor a 0")
+	}
+
+	return NewCola(transporte, 1000, intentos, 30*time.Second), nil
+}
+
+func valorEntorno(clave, porDefecto string) string {
+	if valor := os.Getenv(clave); valor != "" {
+		return valor
+	}
+	return porDefecto
+}
@@ -0,0 +1,48 @@
+package correo
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+	"strings"
+	"time"
+)
+
+// ErrDestinatarioInvalido se devuelve al encolar o enviar un correo cuyo destinatario tiene saltos
+// de línea, con los que se podrían agregar encabezados al mensaje.
+var ErrDestinatarioInvalido = errors.New("destinatario de correo inválido")
+
+// Mensaje es un correo listo para enviar.
+type Mensaje struct {
+	Para   string
+	Asunto string
+	HTML   string
+}
+
+// validar verifica que el destinatario no tenga saltos de línea.
+func (m Mensaje) validar() error {
+	if strings.ContainsAny(m.Para, "\r\n") {
+		return ErrDestinatarioInvalido
+	}
+	return nil
+}
+
+// valorEncabezado deja un valor de encabezado en una sola línea.
+func valorEncabezado(valor string) string {
+	return strings.Join(strings.Fields(valor), " ")
+}
+
+// bytes construye el mensaje en formato MIME con el remitente indicado. Los saltos de línea de los
+// encabezados se reemplazan por espacios.
+func (m Mensaje) bytes(remitente string) []byte {
+	var b bytes.Buffer
+	b.WriteString("From: " + valorEncabezado(remitente) + "\r\n")
+	b.WriteString("To: " + valorEncabezado(m.Para) + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", valorEncabezado(m.Asunto)) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(m.HTML)
+	return b.Bytes()
+}
@@ -0,0 +1,79 @@
+package correo
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+)
+
+const (
+	PlantillaInscripcion         = "inscripcion"
+	PlantillaCertificado         = "certificado"
+	PlantillaRespuesta           = "respuesta"
+	PlantillaRestablecerPassword = "restablecer_password"
+
+	// IdiomaPorDefecto se usa cuando el usuario no tiene idioma o no hay plantillas en su idioma
+	IdiomaPorDefecto = "es"
+)
+
+// Idiomas son los idiomas con plantillas disponibles.
+var Idiomas = []string{"es", "en"}
+
+var nombresPlantillas = []string{PlantillaInscripcion, PlantillaCertificado, PlantillaRespuesta, PlantillaRestablecerPassword}
+
+//go:embed plantillas
+var archivosPlantillas embed.FS
+
+// plantillas guarda las plantillas ya interpretadas por idioma y nombre. Cada plantilla define
+// "asunto" y "contenido", y se combina con el "documento" base de su idioma.
+var plantillas = cargarPlantillas()
+
+func cargarPlantillas() map[string]map[string]*template.Template {
+	cargadas := map[string]map[string]*template.Template{}
+	for _, idioma := range Idiomas {
+		cargadas[idioma] = map[string]*template.Template{}
+		for _, nombre := range nombresPlantillas {
+			cargadas[idioma][nombre] = template.Must(template.ParseFS(archivosPlantillas,
+				"plantillas/"+idioma+"/base.html",
+				"plantillas/"+idioma+"/"+nombre+".html"))
+		}
+	}
+	return cargadas
+}
+
+// DatosPlantilla son los datos disponibles dentro de las plantillas.
+type DatosPlantilla struct {
+	Nombre string            // Nombre del destinatario
+	Datos  map[string]string // Datos propios de cada plantilla (curso, autor, token...)
+}
+
+// Renderizar genera el correo de una plantilla en el idioma indicado, o en el idioma por defecto
+// si no hay plantillas en ese idioma.
+func Renderizar(nombre, idioma, para string, datos DatosPlantilla) (Mensaje, error) {
+	porIdioma, ok := plantillas[idioma]
+	if !ok {
+		porIdioma = plantillas[IdiomaPorDefecto]
+	}
+	plantilla, ok := porIdioma[nombre]
+	if !ok {
+		return Mensaje{}, fmt.Errorf("plantilla de correo inexistente: %s", nombre)
+	}
+
+	var asunto, cuerpo bytes.Buffer
+	if err := plantilla.ExecuteTemplate(&asunto, "asunto", datos); err != nil {
+		return Mensaje{}, err
+	}
+	if err := plantilla.ExecuteTemplate(&cuerpo, "documento", datos); err != nil {
+		return Mensaje{}, err
+	}
+
+	return Mensaje{
+		Para: para,
+		// El asunto no es HTML, por lo que se deshace el escapado de la plantilla; los saltos de línea
+		// que puedan traer los datos se reemplazan por espacios
+		Asunto: valorEncabezado(html.UnescapeString(asunto.String())),
+		HTML:   cuerpo.String(),
+	}, nil
+}
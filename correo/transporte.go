@@ -0,0 +1,124 @@
+package correo
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Transporte entrega los correos a su destino.
+type Transporte interface {
+	Enviar(ctx context.Context, mensaje Mensaje) error
+}
+
+// TransporteSMTP envía los correos a través de un servidor SMTP. Si no se indica usuario se envían
+// sin autenticación, como ocurre con los servidores SMTP falsos de desarrollo.
+type TransporteSMTP struct {
+	Host      string
+	Puerto    string
+	Usuario   string
+	Password  string
+	Remitente string
+}
+
+// tiempoMaximoSMTP limita la duración de un envío cuando el contexto no tiene fecha límite.
+const tiempoMaximoSMTP = time.Minute
+
+// Enviar entrega el mensaje con los mismos pasos que smtp.SendMail, pero sobre una conexión abierta
+// con el contexto: la conexión tiene como plazo la fecha límite del contexto y se cierra si este se
+// cancela, lo que interrumpe el envío en curso.
+func (t *TransporteSMTP) Enviar(ctx context.Context, mensaje Mensaje) error {
+	if err := mensaje.validar(); err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(t.Host, t.Puerto))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	limite, ok := ctx.Deadline()
+	if !ok {
+		limite = time.Now().Add(tiempoMaximoSMTP)
+	}
+	if err := conn.SetDeadline(limite); err != nil {
+		return err
+	}
+	detener := context.AfterFunc(ctx, func() { conn.Close() })
+	defer detener()
+
+	err = t.enviarPorConexion(conn, mensaje)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	// El plazo de la conexión puede vencer un instante antes que el del contexto
+	if ok && errors.Is(err, os.ErrDeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+func (t *TransporteSMTP) enviarPorConexion(conn net.Conn, mensaje Mensaje) error {
+	cliente, err := smtp.NewClient(conn, t.Host)
+	if err != nil {
+		return err
+	}
+	defer cliente.Close()
+
+	if ok, _ := cliente.Extension("STARTTLS"); ok {
+		if err := cliente.StartTLS(&tls.Config{ServerName: t.Host}); err != nil {
+			return err
+		}
+	}
+	if t.Usuario != "" {
+		if err := cliente.Auth(smtp.PlainAuth("", t.Usuario, t.Password, t.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := cliente.Mail(t.Remitente); err != nil {
+		return err
+	}
+	if err := cliente.Rcpt(mensaje.Para); err != nil {
+		return err
+	}
+	w, err := cliente.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(mensaje.bytes(t.Remitente)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return cliente.Quit()
+}
+
+// TransporteArchivo guarda cada correo como un archivo .eml en un directorio, para revisar en
+// desarrollo los correos que se enviarían.
+type TransporteArchivo struct {
+	Directorio string
+	Remitente  string
+}
+
+func (t *TransporteArchivo) Enviar(ctx context.Context, mensaje Mensaje) error {
+	if err := mensaje.validar(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.Directorio, 0o755); err != nil {
+		return err
+	}
+
+	destinatario := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(mensaje.Para)
+	nombre := time.Now().Format("20060102T150405.000000000") + "_" + destinatario + ".eml"
+	return os.WriteFile(filepath.Join(t.Directorio, nombre), mensaje.bytes(t.Remitente), 0o644)
+}
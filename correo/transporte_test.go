@@ -0,0 +1,254 @@
+package correo
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// servidorSMTP es un servidor SMTP falso que acepta todos los correos salvo en las primeras
+// conexiones indicadas en rechazos, en las que rechaza el destinatario con un error temporal. Si
+// mudo es true no responde nada, como un servidor colgado.
+type servidorSMTP struct {
+	listener net.Listener
+	rechazos int
+	mudo     bool
+
+	mu         sync.Mutex
+	conexiones int
+	recibidos  chan string
+}
+
+func nuevoServidorSMTP(t *testing.T, rechazos int, mudo bool) *servidorSMTP {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("no se pudo abrir el servidor SMTP falso: %v", err)
+	}
+	s := &servidorSMTP{listener: listener, rechazos: rechazos, mudo: mudo, recibidos: make(chan string, 10)}
+	t.Cleanup(func() { listener.Close() })
+	go s.aceptar()
+	return s
+}
+
+// transporte devuelve un transporte SMTP que envía al servidor falso.
+func (s *servidorSMTP) transporte() *TransporteSMTP {
+	return &TransporteSMTP{
+		Host:      "127.0.0.1",
+		Puerto:    strconv.Itoa(s.listener.Addr().(*net.TCPAddr).Port),
+		Remitente: "no-responder@example.com",
+	}
+}
+
+func (s *servidorSMTP) cantidadConexiones() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conexiones
+}
+
+func (s *servidorSMTP) aceptar() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conexiones++
+		rechazar := s.conexiones <= s.rechazos
+		s.mu.Unlock()
+		go s.atender(conn, rechazar)
+	}
+}
+
+func (s *servidorSMTP) atender(conn net.Conn, rechazar bool) {
+	defer conn.Close()
+	if s.mudo {
+		// Mantiene la conexión abierta sin responder hasta que el cliente la cierre
+		conn.Read(make([]byte, 1))
+		return
+	}
+
+	lector := bufio.NewReader(conn)
+	responder := func(linea string) { conn.Write([]byte(linea + "\r\n")) }
+	responder("220 localhost ESMTP")
+	for {
+		linea, err := lector.ReadString('\n')
+		if err != nil {
+			return
+		}
+		comando := strings.ToUpper(strings.TrimSpace(linea))
+		switch {
+		case strings.HasPrefix(comando, "EHLO"), strings.HasPrefix(comando, "HELO"):
+			responder("250 localhost")
+		case strings.HasPrefix(comando, "RCPT") && rechazar:
+			responder("451 intente más tarde")
+		case strings.HasPrefix(comando, "DATA"):
+			responder("354 envíe el mensaje")
+			var mensaje strings.Builder
+			for {
+				linea, err := lector.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if linea == ".\r\n" {
+					break
+				}
+				mensaje.WriteString(linea)
+			}
+			s.recibidos <- mensaje.String()
+			responder("250 recibido")
+		case strings.HasPrefix(comando, "QUIT"):
+			responder("221 adiós")
+			return
+		default:
+			responder("250 OK")
+		}
+	}
+}
+
+func TestTransporteSMTPEnviar(t *testing.T) {
+	servidor := nuevoServidorSMTP(t, 0, false)
+
+	err := servidor.transporte().Enviar(context.Background(), Mensaje{
+		Para:   "ana@example.com",
+		Asunto: "Te inscribiste en Go",
+		HTML:   "<p>Hola</p>",
+	})
+	if err != nil {
+		t.Fatalf("error al enviar: %v", err)
+	}
+
+	recibido := <-servidor.recibidos
+	for _, esperado := range []string{"From: no-responder@example.com\r\n", "To: ana@example.com\r\n", "Subject: Te inscribiste en Go\r\n", "<p>Hola</p>"} {
+		if !strings.Contains(recibido, esperado) {
+			t.Errorf("el mensaje recibido no contiene %q:\n%s", esperado, recibido)
+		}
+	}
+}
+
+func TestColaReintentaEnvioSMTP(t *testing.T) {
+	servidor := nuevoServidorSMTP(t, 2, false)
+
+	cola := NewCola(servidor.transporte(), 10, 3, 10*time.Millisecond)
+	cola.Iniciar(1)
+	defer cola.Detener()
+
+	if err := cola.Encolar(Mensaje{Para: "ana@example.com", Asunto: "Hola", HTML: "<p>Hola</p>"}); err != nil {
+		t.Fatalf("error al encolar: %v", err)
+	}
+
+	select {
+	case <-servidor.recibidos:
+	case <-time.After(5 * time.Second):
+		t.Fatal("el correo no se entregó tras los reintentos")
+	}
+	if conexiones := servidor.cantidadConexiones(); conexiones != 3 {
+		t.Errorf("conexiones = %d, se esperaban 3 (dos rechazos y el envío)", conexiones)
+	}
+}
+
+func TestColaDescartaTrasAgotarIntentos(t *testing.T) {
+	servidor := nuevoServidorSMTP(t, 10, false)
+
+	cola := NewCola(servidor.transporte(), 10, 2, 10*time.Millisecond)
+	cola.Iniciar(1)
+
+	if err := cola.Encolar(Mensaje{Para: "ana@example.com", Asunto: "Hola", HTML: "<p>Hola</p>"}); err != nil {
+		t.Fatalf("error al encolar: %v", err)
+	}
+
+	// Se espera más que el reintento para comprobar que no hay un tercer intento
+	time.Sleep(200 * time.Millisecond)
+	cola.Detener()
+	if conexiones := servidor.cantidadConexiones(); conexiones != 2 {
+		t.Errorf("conexiones = %d, se esperaban 2", conexiones)
+	}
+	select {
+	case <-servidor.recibidos:
+		t.Error("el servidor no debería haber recibido el correo")
+	default:
+	}
+}
+
+func TestTransporteSMTPCancelacion(t *testing.T) {
+	casos := []struct {
+		nombre   string
+		contexto func() (context.Context, context.CancelFunc)
+		error    error
+	}{
+		{
+			nombre: "cancelación",
+			contexto: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			error: context.Canceled,
+		},
+		{
+			nombre: "fecha límite",
+			contexto: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			error: context.DeadlineExceeded,
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			servidor := nuevoServidorSMTP(t, 0, true)
+			ctx, cancel := caso.contexto()
+			defer cancel()
+
+			inicio := time.Now()
+			err := servidor.transporte().Enviar(ctx, Mensaje{Para: "ana@example.com", Asunto: "Hola", HTML: "<p>Hola</p>"})
+			if !errors.Is(err, caso.error) {
+				t.Fatalf("error = %v, se esperaba %v", err, caso.error)
+			}
+			if duracion := time.Since(inicio); duracion > 5*time.Second {
+				t.Errorf("el envío tardó %s en interrumpirse", duracion)
+			}
+		})
+	}
+}
+
+func TestDestinatarioConSaltosDeLinea(t *testing.T) {
+	mensaje := Mensaje{Para: "ana@example.com\r\nBcc: otro@example.com", Asunto: "Hola", HTML: "<p>Hola</p>"}
+
+	cola := NewCola(&TransporteArchivo{Directorio: t.TempDir()}, 10, 1, time.Millisecond)
+	if err := cola.Encolar(mensaje); err != ErrDestinatarioInvalido {
+		t.Errorf("Encolar() = %v, se esperaba %v", err, ErrDestinatarioInvalido)
+	}
+
+	servidor := nuevoServidorSMTP(t, 0, false)
+	if err := servidor.transporte().Enviar(context.Background(), mensaje); err != ErrDestinatarioInvalido {
+		t.Errorf("Enviar() = %v, se esperaba %v", err, ErrDestinatarioInvalido)
+	}
+	if conexiones := servidor.cantidadConexiones(); conexiones != 0 {
+		t.Errorf("conexiones = %d, no se debería haber conectado", conexiones)
+	}
+}
+
+func TestAsuntoSinSaltosDeLinea(t *testing.T) {
+	mensaje, err := Renderizar(PlantillaRespuesta, "es", "ana@example.com", DatosPlantilla{
+		Nombre: "Ana",
+		Datos:  map[string]string{"autor": "Luis\r\nBcc: otro@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("error al renderizar: %v", err)
+	}
+	if strings.ContainsAny(mensaje.Asunto, "\r\n") {
+		t.Errorf("el asunto tiene saltos de línea: %q", mensaje.Asunto)
+	}
+
+	mensaje.Asunto = "Hola\r\nBcc: otro@example.com"
+	if encabezados, _, _ := strings.Cut(string(mensaje.bytes("no-responder@example.com")), "\r\n\r\n"); strings.Contains(encabezados, "\r\nBcc:") {
+		t.Errorf("el asunto agregó un encabezado:\n%s", encabezados)
+	}
+}
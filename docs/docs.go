@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/clases/{id}/comentarios": {
-            "get": {
-                "description": "Devuelve todos los comentarios asociados a una clase por su ID",
+        "/api/anuncios/{id}": {
+            "put": {
+                "description": "Cambia el título y el contenido de un anuncio. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,38 +25,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Comentarios"
+                    "Anuncios"
                 ],
-                "summary": "Devuelve los comentarios de una clase",
+                "summary": "Editar un anuncio",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de la clase",
+                        "description": "ID del anuncio",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor, título y contenido",
+                        "name": "anuncio",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AnuncioRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lista de comentarios",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Comentario"
-                            }
+                            "$ref": "#/definitions/models.Anuncio"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error interno del servidor",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Agrega un comentario a una clase por su ID. Se requiere autor (email del usuario), password del usuario, titulo, detalle, meGusta, noMeGusta. La fecha se asigna automáticamente.",
+            "delete": {
+                "description": "Elimina un anuncio. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,48 +88,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Comentarios"
+                    "Anuncios"
                 ],
-                "summary": "Crear un comentario para una clase",
+                "summary": "Eliminar un anuncio",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de la clase",
+                        "description": "ID del anuncio",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Comentario a crear (autor, password, titulo, detalle, meGusta, noMeGusta)",
-                        "name": "comentario",
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Comentario"
+                            "$ref": "#/definitions/request.CredencialesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Comentario creado exitosamente",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Comentario"
+                            "$ref": "#/definitions/response.MessageResponse"
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos o faltan campos requeridos",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Clase no encontrada o usuario no encontrado/credenciales inválidas",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error interno del servidor",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -113,9 +143,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/comentarios_curso": {
-            "post": {
-                "description": "Agrega un comentario a un curso por su ID. El usuario se identifica por email.",
+        "/api/clases/{id}": {
+            "put": {
+                "description": "Reemplaza el nombre, la descripción, el video y la duración de una clase. La duración de la unidad y del curso se actualiza. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -123,54 +153,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ComentariosCurso"
+                    "Clases"
                 ],
-                "summary": "Crear un comentario para un curso",
+                "summary": "Editar una clase",
                 "parameters": [
                     {
-                        "description": "Comentario a crear (usuario (email), cursoID, texto)",
-                        "name": "comentario",
+                        "type": "string",
+                        "description": "ID de la clase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y datos de la clase",
+                        "name": "clase",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ComentarioCurso"
+                            "$ref": "#/definitions/request.UpdateClaseRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Comentario creado exitosamente",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Clase"
                         }
                     },
                     "400": {
-                        "description": "error: Bad Request",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "error: Internal Server Error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/comentarios_curso/usuarios/{email}": {
-            "get": {
-                "description": "Devuelve todos los comentarios hechos por un usuario por su email",
+            },
+            "delete": {
+                "description": "Elimina una clase con sus comentarios, adjuntos, subtítulos y las notas y marcadores de los usuarios, y la descuenta de la cantidad de clases y la duración de su unidad y su curso. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -178,71 +216,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ComentariosCurso"
+                    "Clases"
                 ],
-                "summary": "Obtener todos los comentarios hechos por un usuario",
+                "summary": "Eliminar una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email del usuario",
-                        "name": "email",
+                        "description": "ID de la clase",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "curso: nombre del curso, comentario: texto del comentario",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "error: Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     },
-                    "500": {
-                        "description": "error: Internal Server Error",
+                    {
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/request.CredencialesRequest"
                         }
                     }
-                }
-            }
-        },
-        "/api/cursos": {
-            "get": {
-                "description": "Devuelve todos los cursos disponibles",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Cursos"
                 ],
-                "summary": "Devuelve todos los cursos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.CursoResponse"
-                            }
+                            "$ref": "#/definitions/response.MessageResponse"
                         }
                     },
                     "400": {
@@ -251,42 +250,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Agrega un curso a la base de datos",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Cursos"
-                ],
-                "summary": "Crear un curso",
-                "parameters": [
-                    {
-                        "description": "Curso a crear",
-                        "name": "curso",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateCursoRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/response.CrearCurso"
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
@@ -298,33 +271,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/cursos/{id}": {
-            "get": {
-                "description": "Devuelve un curso en específico dado su ID",
+        "/api/clases/{id}/adjuntos": {
+            "post": {
+                "description": "Sube un archivo (PDF, texto, imágenes, documentos de Office o ZIP, 50 MB como máximo) y lo agrega a los adjuntos de la clase con su suma SHA-256. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cursos"
+                    "Clases"
                 ],
-                "summary": "Devuelve un curso según su ID",
+                "summary": "Subir un adjunto a una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del curso",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del instructor",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del instructor",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Archivo adjunto",
+                        "name": "archivo",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.CursoResponse"
+                            "$ref": "#/definitions/models.Adjunto"
                         }
                     },
                     "400": {
@@ -333,12 +327,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -348,40 +360,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/cursos/{id}/clases": {
+        "/api/clases/{id}/adjuntos/{adjunto_id}": {
             "get": {
-                "description": "Devuelve todas las clases asociadas a un curso dado su ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Devuelve el archivo adjunto con su suma SHA-256 como ETag. Solo pueden descargarlo los inscritos en el curso, su instructor y los administradores; los inscritos no pueden descargar los adjuntos de una unidad bloqueada.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Cursos"
+                    "Clases"
                 ],
-                "summary": "Devuelve todas las clases de un curso",
+                "summary": "Descargar un adjunto de una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del curso",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.ClaseResponse"
-                            }
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    {
+                        "type": "string",
+                        "description": "ID del adjunto",
+                        "name": "adjunto_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "El archivo no cambió"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -399,11 +435,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/cursos/{id}/unidades": {
-            "get": {
-                "description": "Devuelve una unidades de un curso en específico dado su ID",
+            },
+            "delete": {
+                "description": "Quita el adjunto de la clase y borra su archivo. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -411,23 +445,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Unidades"
+                    "Clases"
                 ],
-                "summary": "Devuelve unidades de un curso",
+                "summary": "Eliminar un adjunto de una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del curso",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID del adjunto",
+                        "name": "adjunto_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CursoResponse"
+                            "$ref": "#/definitions/response.MessageResponse"
                         }
                     },
                     "400": {
@@ -436,6 +486,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -449,9 +505,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "agregar una unidad a un curso",
+            }
+        },
+        "/api/clases/{id}/comentarios": {
+            "get": {
+                "description": "Devuelve los hilos de comentarios de una clase por su ID, con sus respuestas anidadas y la cantidad de respuestas de cada comentario. Las clases de un curso no publicado solo las ven quienes pueden ver el curso",
                 "consumes": [
                     "application/json"
                 ],
@@ -459,46 +517,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Unidades"
+                    "Comentarios"
                 ],
-                "summary": "Crear unidad",
+                "summary": "Devuelve los comentarios de una clase",
                 "parameters": [
-                    {
-                        "description": "Unidad a crear",
-                        "name": "unidad",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateUnidadRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "ID del curso",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Niveles de respuestas a incluir (0: solo comentarios principales; sin límite por defecto)",
+                        "name": "profundidad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Lista de hilos de comentarios",
                         "schema": {
-                            "$ref": "#/definitions/response.CrearUnidad"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Comentario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Profundidad inválida",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "La clase está bloqueada",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Clase o curso no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/cursos/{id}/valoracion": {
-            "patch": {
-                "description": "Actualiza la valoración de un curso según la nueva valoración proporcionada",
+            },
+            "post": {
+                "description": "Agrega un comentario a una clase por su ID. Se requiere autor (email del usuario), password del usuario, titulo y detalle. La fecha se asigna automáticamente y los contadores de \"me gusta\" comienzan en 0. Para responder a otro comentario de la misma clase se indica su ID en padre_id. Los comentarios principales pueden marcarse como pregunta con es_pregunta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,42 +592,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cursos"
+                    "Comentarios"
                 ],
-                "summary": "Actualiza la valoración de un curso",
+                "summary": "Crear un comentario para una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del curso",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Nueva valoración del curso",
-                        "name": "valoracion",
+                        "description": "Comentario a crear (autor, password, titulo, detalle, padre_id)",
+                        "name": "comentario",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateValoracionRequest"
+                            "$ref": "#/definitions/request.CreateComentarioRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Comentario creado exitosamente",
                         "schema": {
-                            "$ref": "#/definitions/response.UpdateValoracionResponse"
+                            "$ref": "#/definitions/models.Comentario"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Datos inválidos o faltan campos requeridos",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Clase no encontrada o usuario no encontrado/credenciales inválidas",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -549,9 +641,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/puntuaciones/cursos/{id}": {
-            "post": {
-                "description": "Agrega una puntuación a un curso por su ID. El usuario se identifica por email y password, se verifica que esté inscrito en el curso. Solo se guarda el nombre del usuario en la relación.",
+        "/api/clases/{id}/cuestionarios": {
+            "get": {
+                "description": "Devuelve los cuestionarios de la clase sin sus respuestas correctas. Requiere poder ver el curso de la clase y que la unidad de la clase no esté bloqueada para el usuario.",
                 "consumes": [
                     "application/json"
                 ],
@@ -559,70 +651,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Puntuaciones"
+                    "Cuestionarios"
                 ],
-                "summary": "Crear una puntuación para un curso",
+                "summary": "Obtener los cuestionarios de una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del curso",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Puntuación a crear (usuario (email), password, valor)",
-                        "name": "puntuacion",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Puntuacion"
-                        }
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Puntuación creada exitosamente",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Cuestionario"
                             }
                         }
                     },
                     "400": {
-                        "description": "error: Bad Request",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "error: Not Found",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "error: Internal Server Error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/puntuaciones/cursos/{id}/promedio": {
-            "get": {
-                "description": "Devuelve el promedio de puntuaciones de un curso por su ID",
+            },
+            "post": {
+                "description": "Crea un cuestionario de opción múltiple, verdadero o falso y respuesta corta en la clase. Si es requerido, aprobarlo es necesario para marcar la clase como vista. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -630,61 +720,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Puntuaciones"
+                    "Cuestionarios"
                 ],
-                "summary": "Obtener el promedio de puntuaciones de un curso",
+                "summary": "Crear el cuestionario de una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del curso",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y cuestionario",
+                        "name": "cuestionario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCuestionarioRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "promedio: 0.0",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "number"
-                            }
+                            "$ref": "#/definitions/models.Cuestionario"
                         }
                     },
                     "400": {
-                        "description": "error: Bad Request",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "error: Not Found",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "error: Internal Server Error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/puntuaciones/usuarios/{email}": {
-            "get": {
-                "description": "Devuelve todas las puntuaciones hechas por un usuario por su email",
+        "/api/clases/{id}/marcador": {
+            "put": {
+                "description": "Agrega la clase a los marcadores del usuario. Si ya estaba guardada devuelve el marcador existente. El usuario debe estar inscrito en el curso.",
                 "consumes": [
                     "application/json"
                 ],
@@ -692,62 +785,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Puntuaciones"
+                    "Notas"
                 ],
-                "summary": "Obtener todas las puntuaciones hechas por un usuario",
+                "summary": "Guardar una clase en los marcadores",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email del usuario",
-                        "name": "email",
+                        "description": "ID de la clase",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "curso: nombre del curso, valoracion: valor de la puntuación",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
-                            }
+                            "$ref": "#/definitions/models.Marcador"
                         }
                     },
                     "400": {
-                        "description": "error: Bad Request",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "error: Not Found",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "error: Internal Server Error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/unidades/{id}/clases": {
-            "get": {
-                "description": "Devuelve todas las clases asociadas a una unidad",
+            },
+            "delete": {
+                "description": "Quita la clase de los marcadores del usuario.",
                 "consumes": [
                     "application/json"
                 ],
@@ -755,26 +848,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clases"
+                    "Notas"
                 ],
-                "summary": "Devuelve las clases de una unidad",
+                "summary": "Quitar una clase de los marcadores",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de la unidad",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.ClaseResponse"
-                            }
+                            "$ref": "#/definitions/response.MessageResponse"
                         }
                     },
                     "400": {
@@ -796,9 +895,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/clases/{id}/notas": {
             "post": {
-                "description": "Agrega una clase a la base de datos asociada a una unidad",
+                "description": "Agrega una nota privada del usuario en un momento del video de la clase. El usuario debe estar inscrito en el curso y la unidad de la clase no debe estar bloqueada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -806,32 +907,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clases"
+                    "Notas"
                 ],
-                "summary": "Crear una clase para una unidad",
+                "summary": "Crear una nota",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de la unidad",
+                        "description": "ID de la clase",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Clase a crear",
-                        "name": "clase",
+                        "description": "Credenciales del usuario, momento del video y texto",
+                        "name": "nota",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateClaseRequest"
+                            "$ref": "#/definitions/request.NotaRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.CrearClase"
+                            "$ref": "#/definitions/models.Nota"
                         }
                     },
                     "400": {
@@ -840,36 +941,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/usuarios": {
-            "get": {
-                "description": "Devuelve la lista completa de usuarios registrados",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Usuarios"
-                ],
-                "summary": "Devuelve todos los usuarios",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Usuario"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
@@ -879,9 +960,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/clases/{id}/reaccion": {
             "post": {
-                "description": "Agrega un usuario a la base de datos",
+                "description": "Registra un \"me_gusta\" o \"no_me_gusta\" del usuario sobre una clase. Cada usuario tiene una única reacción: repetirla la quita y elegir la opuesta la reemplaza.",
                 "consumes": [
                     "application/json"
                 ],
@@ -889,25 +972,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Reacciones"
                 ],
-                "summary": "Crear un nuevo usuario",
+                "summary": "Reaccionar a una clase",
                 "parameters": [
                     {
-                        "description": "Usuario a crear",
-                        "name": "usuario",
+                        "type": "string",
+                        "description": "ID de la clase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reacción",
+                        "name": "reaccion",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateUsuarioRequest"
+                            "$ref": "#/definitions/request.ReaccionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Usuario"
+                            "$ref": "#/definitions/response.ReaccionResponse"
                         }
                     },
                     "400": {
@@ -916,6 +1006,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -925,9 +1021,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/usuarios/cursos": {
+        "/api/clases/{id}/subtitulos": {
             "get": {
-                "description": "Devuelve la lista de cursos en los que un usuario está inscrito",
+                "description": "Devuelve las pistas de subtítulos disponibles de la clase, una por idioma, con la URL de su archivo WebVTT. Solo pueden verlas los inscritos en el curso, su instructor y los administradores; los inscritos no pueden ver las de una unidad bloqueada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -935,10 +1031,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Subtitulos"
                 ],
-                "summary": "Obtener cursos inscritos de un usuario",
+                "summary": "Obtener los subtítulos de una clase",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la clase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Correo del usuario",
@@ -960,52 +1063,24 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Curso"
+                                "$ref": "#/definitions/models.PistaSubtitulos"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/usuarios/inscripcion": {
-            "post": {
-                "description": "Inscribe a un usuario en un curso específico",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Usuarios"
-                ],
-                "summary": "Inscribir un usuario en un curso",
-                "parameters": [
-                    {
-                        "description": "Datos de inscripción",
-                        "name": "inscripcion",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.InscripcionRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.InscripcionResponse"
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -1017,45 +1092,61 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/usuarios/progreso": {
-            "get": {
-                "description": "Devuelve el progreso de los cursos en los que un usuario está inscrito",
+            },
+            "post": {
+                "description": "Sube un archivo WebVTT (.vtt) o SRT (.srt) de 2 MB como máximo con los subtítulos de la clase en un idioma, reemplazando los anteriores de ese idioma, e indexa su texto para la búsqueda. Solo el instructor del curso o un administrador puede hacerlo.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Subtitulos"
                 ],
-                "summary": "Devuelve el progreso de los cursos de un usuario",
+                "summary": "Subir los subtítulos de una clase",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email del usuario",
+                        "description": "ID de la clase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del instructor",
                         "name": "email",
-                        "in": "query",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Contraseña del usuario",
+                        "description": "Contraseña del instructor",
                         "name": "password",
-                        "in": "query",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código de idioma (por ejemplo es o en-US)",
+                        "name": "idioma",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Archivo de subtítulos",
+                        "name": "archivo",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ProgresoCurso"
-                            }
+                            "$ref": "#/definitions/models.PistaSubtitulos"
                         }
                     },
                     "400": {
@@ -1064,12 +1155,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1079,20 +1188,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/usuarios/usuario": {
+        "/api/clases/{id}/subtitulos/{idioma}": {
             "get": {
-                "description": "Devuelve un usuario en específico por su correo y contraseña",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Devuelve los subtítulos de la clase en el idioma indicado en formato WebVTT, para usarlos en el elemento track de un reproductor. Tiene los mismos permisos que la lista de pistas. Si se pide la versión vigente (parámetro v de la URL de la pista), la respuesta puede guardarse en caché sin vencimiento.",
                 "produces": [
-                    "application/json"
+                    "text/vtt"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Subtitulos"
                 ],
-                "summary": "Obtener un usuario por correo y contraseña",
+                "summary": "Descargar los subtítulos de una clase",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la clase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código de idioma",
+                        "name": "idioma",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versión de la pista",
+                        "name": "v",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Correo del usuario",
@@ -1112,10 +1238,101 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Usuario"
+                            "type": "file"
                         }
                     },
-                    "500": {
+                    "304": {
+                        "description": "Los subtítulos no cambiaron"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina la pista de subtítulos de la clase en el idioma indicado y su transcripción. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subtitulos"
+                ],
+                "summary": "Eliminar los subtítulos de una clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la clase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código de idioma",
+                        "name": "idioma",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
@@ -1124,102 +1341,7866 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/usuarios/{email}/{password}/clases/{clase_id}": {
-            "post": {
-                "description": "Permite que un usuario vea una clase y actualiza su progreso en el curso",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Usuarios"
-                ],
-                "summary": "Ver una clase",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Correo del usuario",
-                        "name": "email",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Contraseña del usuario",
-                        "name": "password",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ID de la clase",
-                        "name": "clase_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.VerClaseResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    }
+        "/api/comentarios/{id}": {
+            "put": {
+                "description": "Reemplaza el título y el detalle de un comentario. Solo el autor puede editarlo; la versión anterior queda en el historial de revisiones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comentarios"
+                ],
+                "summary": "Editar un comentario de una clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del autor, nuevo título y nuevo detalle",
+                        "name": "comentario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateComentarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comentario editado",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comentario"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "El usuario no es el autor",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comentario o usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina un comentario a pedido de su autor o de un moderador. Si el comentario tiene respuestas, se muestra como \"comentario eliminado\" para conservar el hilo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comentarios"
+                ],
+                "summary": "Eliminar un comentario de una clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del autor o del moderador",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comentario eliminado exitosamente",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "El usuario no es el autor ni un moderador",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comentario o usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios/{id}/hilo": {
+            "get": {
+                "description": "Devuelve un comentario de clase con sus respuestas anidadas, si el usuario puede ver el curso de la clase y la clase no está bloqueada para él",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comentarios"
+                ],
+                "summary": "Devuelve un hilo de comentarios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Niveles de respuestas a incluir (sin límite por defecto)",
+                        "name": "profundidad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hilo de comentarios",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comentario"
+                        }
+                    },
+                    "400": {
+                        "description": "Profundidad inválida",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "La clase está bloqueada",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comentario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios/{id}/reaccion": {
+            "post": {
+                "description": "Registra un \"me_gusta\" o \"no_me_gusta\" del usuario sobre un comentario. Cada usuario tiene una única reacción: repetirla la quita y elegir la opuesta la reemplaza.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reacciones"
+                ],
+                "summary": "Reaccionar a un comentario de clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reacción",
+                        "name": "reaccion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReaccionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ReaccionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios/{id}/reportes": {
+            "post": {
+                "description": "Reporta un comentario de clase para que lo revise un moderador. Un usuario tiene como máximo un reporte abierto por comentario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderacion"
+                ],
+                "summary": "Reportar un comentario de clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario y motivo del reporte",
+                        "name": "reporte",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReporteComentarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios/{id}/respuesta_aceptada": {
+            "post": {
+                "description": "Marca una respuesta directa de la pregunta como respuesta aceptada. Solo el instructor del curso o un administrador puede hacerlo; aceptar otra respuesta reemplaza la anterior.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Preguntas"
+                ],
+                "summary": "Aceptar la respuesta de una pregunta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la pregunta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y ID de la respuesta",
+                        "name": "respuesta",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AceptarRespuestaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios/{id}/revisiones": {
+            "get": {
+                "description": "Devuelve las versiones anteriores (título y detalle) de un comentario con la fecha en que se escribió cada una, si el usuario puede ver el curso de la clase y la clase no está bloqueada para él",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comentarios"
+                ],
+                "summary": "Devuelve el historial de un comentario de clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Versiones anteriores",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RevisionComentario"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "La clase está bloqueada",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comentario, clase, curso o usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios_curso": {
+            "post": {
+                "description": "Agrega un comentario a un curso por su ID. El usuario se identifica por email y password y debe estar inscrito en el curso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ComentariosCurso"
+                ],
+                "summary": "Crear un comentario para un curso",
+                "parameters": [
+                    {
+                        "description": "Comentario a crear (usuario (email), password, cursoID, texto)",
+                        "name": "comentario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateComentarioCursoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Comentario creado exitosamente, id: ID del comentario, moderacion: aprobado o pendiente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "error: Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios_curso/usuarios/{email}": {
+            "get": {
+                "description": "Devuelve todos los comentarios hechos por un usuario por su email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ComentariosCurso"
+                ],
+                "summary": "Obtener todos los comentarios hechos por un usuario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email del usuario",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ComentarioCurso"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios_curso/{id}": {
+            "put": {
+                "description": "Reemplaza el texto de un comentario de curso. Solo el autor puede editarlo; el texto anterior queda en el historial de revisiones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ComentariosCurso"
+                ],
+                "summary": "Editar un comentario de curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del autor y nuevo texto",
+                        "name": "comentario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateComentarioCursoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ComentarioCurso"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "error: Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina un comentario de curso. Puede hacerlo su autor o un moderador.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ComentariosCurso"
+                ],
+                "summary": "Eliminar un comentario de curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del autor o del moderador",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Comentario eliminado exitosamente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "error: Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios_curso/{id}/reportes": {
+            "post": {
+                "description": "Reporta un comentario de curso para que lo revise un moderador. Un usuario tiene como máximo un reporte abierto por comentario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderacion"
+                ],
+                "summary": "Reportar un comentario de curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario y motivo del reporte",
+                        "name": "reporte",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReporteComentarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comentarios_curso/{id}/revisiones": {
+            "get": {
+                "description": "Devuelve los textos anteriores de un comentario de curso con la fecha en que se escribió cada uno, si el usuario puede ver el curso",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ComentariosCurso"
+                ],
+                "summary": "Obtener el historial de un comentario de curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RevisionComentario"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cuestionarios/{id}": {
+            "get": {
+                "description": "Devuelve el cuestionario sin sus respuestas correctas. Requiere poder ver su curso y que su unidad no esté bloqueada para el usuario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cuestionarios"
+                ],
+                "summary": "Obtener un cuestionario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del cuestionario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cuestionario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cuestionarios/{id}/intentos": {
+            "get": {
+                "description": "Devuelve los intentos del usuario, su mejor puntaje, si aprobó y los intentos que le quedan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cuestionarios"
+                ],
+                "summary": "Obtener los resultados de un cuestionario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del cuestionario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResultadosCuestionario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Corrige automáticamente las respuestas y registra el intento. Las preguntas sin responder cuentan como incorrectas. Solo para usuarios inscritos en el curso, si la unidad del cuestionario no está bloqueada para ellos, y dentro del límite de intentos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cuestionarios"
+                ],
+                "summary": "Enviar un intento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del cuestionario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario y respuestas",
+                        "name": "intento",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.IntentoCuestionarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.IntentoCuestionario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos": {
+            "get": {
+                "description": "Devuelve los cursos publicados. Con credenciales incluye también los cursos en cualquier estado de los que el usuario es instructor, o todos si es administrador",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Devuelve todos los cursos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Orden del catálogo (valoracion: por valoración ponderada)",
+                        "name": "orden",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CursoResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Agrega un curso a la base de datos. El curso se crea como borrador y no aparece en el catálogo hasta que se publica. El instructor es el usuario de las credenciales; solo un administrador puede indicar otro instructor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Crear un curso",
+                "parameters": [
+                    {
+                        "description": "Curso a crear",
+                        "name": "curso",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCursoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CrearCurso"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}": {
+            "get": {
+                "description": "Devuelve un curso en específico dado su ID. Los borradores solo son visibles para su instructor y los administradores, y los cursos archivados también para sus inscritos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Devuelve un curso según su ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CursoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/anuncios": {
+            "get": {
+                "description": "Devuelve los anuncios de un curso, del más reciente al más antiguo. Los de un curso no publicado solo los ve quien puede ver el curso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anuncios"
+                ],
+                "summary": "Obtener los anuncios de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Anuncio"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Publica un anuncio en el curso y notifica a todos sus inscritos. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anuncios"
+                ],
+                "summary": "Publicar un anuncio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor, título y contenido",
+                        "name": "anuncio",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AnuncioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Anuncio"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/archivar": {
+            "post": {
+                "description": "Archiva un curso: deja de aparecer en el catálogo y no admite inscripciones, pero sus inscritos conservan el acceso. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Archivar un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CursoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/clases": {
+            "get": {
+                "description": "Devuelve todas las clases asociadas a un curso dado su ID. Las clases de las unidades bloqueadas para el usuario (o para un usuario no inscrito, sin credenciales) se devuelven sin video, adjuntos ni subtítulos y con la fecha en que se desbloquean, si se conoce",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Devuelve todas las clases de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClaseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/comentarios": {
+            "get": {
+                "description": "Devuelve los comentarios visibles de un curso con su fecha y el nombre del autor, ordenados del más reciente al más antiguo (recientes) o al revés (antiguos). Para obtener la página siguiente se envía el siguiente_cursor de la respuesta anterior. Los comentarios de un borrador solo los ven su instructor y los administradores, y los de un curso archivado también sus inscritos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ComentariosCurso"
+                ],
+                "summary": "Obtener los comentarios de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "recientes (por defecto) o antiguos",
+                        "name": "orden",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor de la página a obtener (vacío para la primera)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comentarios por página (máximo 50)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ComentariosCursoPaginadosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/despublicar": {
+            "post": {
+                "description": "Vuelve un curso publicado a borrador, o cancela la publicación programada de un borrador. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Despublicar un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CursoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/foro": {
+            "get": {
+                "description": "Devuelve las categorías del foro del curso con su cantidad de hilos. El foro de un borrador solo lo ven su instructor y los administradores, y el de un curso archivado también sus inscritos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Obtener las categorías del foro de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CategoriaForo"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/foro/categorias": {
+            "post": {
+                "description": "Crea una categoría en el foro del curso. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Crear una categoría del foro",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor, nombre y descripción",
+                        "name": "categoria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCategoriaForoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoriaForo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/instructor": {
+            "put": {
+                "description": "Asigna o reemplaza el instructor de un curso, identificado por su email. Solo disponible para administradores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Asigna el instructor de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del administrador y email del instructor",
+                        "name": "instructor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AsignarInstructorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/marcadores": {
+            "get": {
+                "description": "Devuelve las clases del curso que el usuario guardó en sus marcadores, en el orden del curso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notas"
+                ],
+                "summary": "Obtener los marcadores de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Marcador"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/notas": {
+            "get": {
+                "description": "Devuelve las notas del usuario en el curso, en el orden de las clases y, dentro de cada clase, por momento del video.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notas"
+                ],
+                "summary": "Obtener las notas de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Nota"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/notas/exportar": {
+            "get": {
+                "description": "Devuelve un documento Markdown con todas las notas del usuario en el curso, agrupadas por unidad y clase.",
+                "produces": [
+                    "text/markdown"
+                ],
+                "tags": [
+                    "Notas"
+                ],
+                "summary": "Exportar las notas de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/portada": {
+            "put": {
+                "description": "Sube una imagen JPEG, PNG o GIF de entre 320x180 y 4096x4096 píxeles (10 MB como máximo) como portada del curso y genera sus miniaturas pequeña (240 px de ancho) y mediana (640 px). Reemplaza la imagen del curso. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Subir la portada de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del instructor",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del instructor",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Imagen de portada",
+                        "name": "imagen",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PortadaCurso"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/portada/{tamano}": {
+            "get": {
+                "description": "Devuelve la portada del curso en su tamaño original o como miniatura JPEG. Si se pide la versión vigente (parámetro v de las URLs del curso), la respuesta puede guardarse en caché sin vencimiento. La portada de un curso no publicado solo la ve quien puede ver el curso.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Obtener la portada de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "original, pequena o mediana",
+                        "name": "tamano",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versión de la portada",
+                        "name": "v",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "La imagen no cambió"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/preguntas": {
+            "get": {
+                "description": "Devuelve las preguntas hechas en las clases de un curso, de la más antigua a la más reciente. Por defecto solo las que no tienen respuesta aceptada ni respuesta del instructor. Requiere poder ver el curso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Preguntas"
+                ],
+                "summary": "Obtener las preguntas de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sin_responder (por defecto), respondidas o todas",
+                        "name": "estado",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Pregunta"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/publicar": {
+            "post": {
+                "description": "Publica un curso en borrador o archivado para que aparezca en el catálogo. Si se indica una fecha futura programa la publicación del borrador para esa fecha. El curso debe tener al menos una unidad con una clase. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Publicar un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y fecha de publicación opcional",
+                        "name": "publicacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PublicarCursoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CursoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/resenas": {
+            "get": {
+                "description": "Devuelve las reseñas de un curso paginadas, ordenadas por fecha (recientes) o por utilidad. Si el curso no está publicado, solo las ve quien puede ver el curso",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reseñas"
+                ],
+                "summary": "Obtener las reseñas de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "recientes (por defecto) o utilidad",
+                        "name": "orden",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número de página (desde 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reseñas por página (máximo 50)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ResenasPaginadasResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Agrega una reseña a un curso. El usuario se identifica por email y password y debe estar inscrito en el curso. La puntuación de la reseña reemplaza la puntuación previa del usuario, si existía.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reseñas"
+                ],
+                "summary": "Crear una reseña para un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reseña a crear",
+                        "name": "resena",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateResenaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Resena"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/transcripciones": {
+            "get": {
+                "description": "Devuelve los segmentos de subtítulos de las clases del curso en los que se dice la frase buscada, con la clase y el segundo del video en que empieza cada uno. Ignora mayúsculas, tildes y puntuación. Solo pueden buscar los inscritos en el curso, su instructor y los administradores; a los inscritos no se les devuelven las clases de unidades bloqueadas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subtitulos"
+                ],
+                "summary": "Buscar en las transcripciones de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Frase a buscar",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Buscar solo en los subtítulos de este idioma",
+                        "name": "idioma",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cantidad máxima de resultados (1 a 50, por defecto 10)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ResultadoTranscripcion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/unidades": {
+            "get": {
+                "description": "Devuelve una unidades de un curso en específico dado su ID, indicando cuáles están bloqueadas para el usuario (o para un usuario no inscrito, sin credenciales) y cuándo se desbloquean, si se conoce",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unidades"
+                ],
+                "summary": "Devuelve unidades de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Unidad"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "agregar una unidad a un curso",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unidades"
+                ],
+                "summary": "Crear unidad",
+                "parameters": [
+                    {
+                        "description": "Unidad a crear",
+                        "name": "unidad",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateUnidadRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CrearUnidad"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/valoracion": {
+            "patch": {
+                "description": "Sobrescribe la valoración de un curso. Solo disponible para administradores, que deben indicar el motivo del cambio; el cambio queda registrado en la auditoría.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cursos"
+                ],
+                "summary": "Actualiza la valoración de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del administrador, nueva valoración y motivo",
+                        "name": "valoracion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateValoracionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UpdateValoracionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/versiones": {
+            "get": {
+                "description": "Devuelve las versiones de la estructura del curso, de la más reciente a la más antigua, sin la estructura de cada una. Se guarda una versión nueva con cada cambio en el curso, sus unidades o sus clases. Solo el instructor del curso o un administrador puede consultarlas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versiones"
+                ],
+                "summary": "Obtener las versiones de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VersionCurso"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/versiones/diferencias": {
+            "get": {
+                "description": "Devuelve los datos del curso, las unidades y las clases que se agregaron, se eliminaron o se modificaron entre dos versiones, con los valores anteriores y posteriores de los campos modificados. Si no se indica hasta se compara con la versión más reciente. Solo el instructor del curso o un administrador puede compararlas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versiones"
+                ],
+                "summary": "Comparar dos versiones de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número de la versión anterior",
+                        "name": "desde",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número de la versión posterior (por defecto, la más reciente)",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DiferenciaVersiones"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/versiones/{numero}": {
+            "get": {
+                "description": "Devuelve una versión del curso con sus datos, sus unidades y sus clases tal como estaban en ese momento. Solo el instructor del curso o un administrador puede consultarla.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versiones"
+                ],
+                "summary": "Obtener una versión de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número de la versión",
+                        "name": "numero",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VersionCurso"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cursos/{id}/versiones/{numero}/restaurar": {
+            "post": {
+                "description": "Devuelve los datos, las unidades y las clases del curso a los de una versión anterior y guarda el resultado como una versión nueva. Las clases que siguen existiendo conservan su ID, sus comentarios, sus adjuntos y el progreso de los inscritos; las unidades y clases que no están en la versión se quitan del curso sin eliminarse y las que se habían eliminado se vuelven a crear sin comentarios ni adjuntos. Los datos del curso, sus unidades y sus clases se restauran en una sola transacción. La portada no forma parte de las versiones y no cambia. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versiones"
+                ],
+                "summary": "Restaurar una versión de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número de la versión a restaurar",
+                        "name": "numero",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor o administrador",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VersionCurso"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/entregas/{id}/archivo": {
+            "get": {
+                "description": "Devuelve el archivo entregado. Pueden descargarlo su autor, el instructor del curso y los administradores.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Tareas"
+                ],
+                "summary": "Descargar el archivo de una entrega",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la entrega",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/entregas/{id}/calificacion": {
+            "put": {
+                "description": "Registra la calificación y el comentario del instructor sobre una entrega. Una entrega calificada ya no puede reemplazarse. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tareas"
+                ],
+                "summary": "Calificar una entrega",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la entrega",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor, calificación y comentario",
+                        "name": "calificacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CalificarEntregaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Entrega"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/foro/categorias/{id}/hilos": {
+            "get": {
+                "description": "Devuelve los hilos de la categoría: primero los fijados y luego los de actividad más reciente. Requiere poder ver el curso de la categoría.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Obtener los hilos de una categoría",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la categoría",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número de página (1 por defecto)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hilos por página (10 por defecto, máximo 50)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.HilosForoPaginadosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Crea un hilo en la categoría con su primera publicación. Pueden hacerlo los inscritos en el curso, su instructor y los administradores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Iniciar un hilo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la categoría",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario, título y contenido",
+                        "name": "hilo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateHiloForoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.HiloForo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/foro/hilos/{id}": {
+            "get": {
+                "description": "Devuelve el hilo y sus publicaciones, de la más antigua a la más reciente. Requiere poder ver el curso del hilo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Obtener un hilo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del hilo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número de página (1 por defecto)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Publicaciones por página (10 por defecto, máximo 50)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.HiloForoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina el hilo y todas sus publicaciones. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Eliminar un hilo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del hilo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Fija o bloquea un hilo; los campos omitidos no se modifican. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Fijar o bloquear un hilo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del hilo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y nuevo estado",
+                        "name": "estado",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.EstadoHiloForoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/foro/hilos/{id}/publicaciones": {
+            "post": {
+                "description": "Agrega una publicación al hilo. Pueden hacerlo los inscritos en el curso; en los hilos bloqueados, solo el instructor del curso o un administrador.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Publicar en un hilo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del hilo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario y contenido",
+                        "name": "publicacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePublicacionForoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PublicacionForo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/foro/publicaciones/{id}": {
+            "delete": {
+                "description": "Elimina una publicación a pedido de su autor, del instructor del curso o de un administrador. La publicación se conserva sin contenido.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Foros"
+                ],
+                "summary": "Eliminar una publicación",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la publicación",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del autor o del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/instructores/preguntas": {
+            "get": {
+                "description": "Devuelve las preguntas sin responder de todos los cursos que dicta el usuario, agrupadas por curso, y el total pendiente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Preguntas"
+                ],
+                "summary": "Panel de preguntas del instructor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del instructor",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del instructor",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PanelInstructorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderacion/cola": {
+            "get": {
+                "description": "Devuelve los comentarios de clase y de curso retenidos por el filtro de palabras o con reportes abiertos, los más reportados primero. Solo para moderadores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderacion"
+                ],
+                "summary": "Cola de moderación",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del moderador",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del moderador",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ElementoModeracion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderacion/comentarios/{id}": {
+            "post": {
+                "description": "Aprueba, oculta o elimina un comentario de clase y resuelve sus reportes abiertos. Solo para moderadores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderacion"
+                ],
+                "summary": "Moderar un comentario de clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del moderador y acción (aprobar, ocultar o eliminar)",
+                        "name": "moderacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ModeracionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderacion/comentarios_curso/{id}": {
+            "post": {
+                "description": "Aprueba, oculta o elimina un comentario de curso y resuelve sus reportes abiertos. Solo para moderadores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderacion"
+                ],
+                "summary": "Moderar un comentario de curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del comentario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del moderador y acción (aprobar, ocultar o eliminar)",
+                        "name": "moderacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ModeracionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notas/{id}": {
+            "put": {
+                "description": "Cambia el momento del video y el texto de una nota. Solo su autor puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notas"
+                ],
+                "summary": "Editar una nota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la nota",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario, momento del video y texto",
+                        "name": "nota",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.NotaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Nota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina una nota. Solo su autor puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notas"
+                ],
+                "summary": "Eliminar una nota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la nota",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del usuario",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notificaciones": {
+            "get": {
+                "description": "Devuelve las notificaciones del usuario, de la más reciente a la más antigua.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notificaciones"
+                ],
+                "summary": "Obtener notificaciones",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Solo las notificaciones sin leer",
+                        "name": "solo_no_leidas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cantidad máxima de notificaciones (10 por defecto, máximo 50)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notificacion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notificaciones/leidas": {
+            "post": {
+                "description": "Marca como leídas las notificaciones indicadas, o todas si no se indica ninguna.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notificaciones"
+                ],
+                "summary": "Marcar notificaciones como leídas",
+                "parameters": [
+                    {
+                        "description": "Credenciales del usuario e IDs de las notificaciones",
+                        "name": "notificaciones",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MarcarNotificacionesLeidasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notificaciones/no_leidas": {
+            "get": {
+                "description": "Devuelve la cantidad de notificaciones que el usuario todavía no leyó.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notificaciones"
+                ],
+                "summary": "Contar notificaciones sin leer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NotificacionesNoLeidasResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notificaciones/preferencias": {
+            "get": {
+                "description": "Devuelve qué tipos de notificación (respuesta, mencion, inscripcion, certificado, anuncio) tiene activados el usuario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notificaciones"
+                ],
+                "summary": "Obtener preferencias de notificación",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Activa o desactiva tipos de notificación. Los tipos no incluidos conservan su preferencia actual.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notificaciones"
+                ],
+                "summary": "Actualizar preferencias de notificación",
+                "parameters": [
+                    {
+                        "description": "Credenciales del usuario y preferencias por tipo",
+                        "name": "preferencias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PreferenciasNotificacionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/puntuaciones/cursos/{id}": {
+            "post": {
+                "description": "Agrega una puntuación a un curso por su ID. El usuario se identifica por email y password, se verifica que esté inscrito en el curso. Solo se guarda el nombre del usuario en la relación.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Puntuaciones"
+                ],
+                "summary": "Crear una puntuación para un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Puntuación a crear (usuario (email), password, valor)",
+                        "name": "puntuacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Puntuacion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Puntuación creada exitosamente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/puntuaciones/cursos/{id}/estadisticas": {
+            "get": {
+                "description": "Devuelve el histograma de puntuaciones (0 a 5 estrellas), la cantidad, el promedio, la mediana, la desviación estándar y el promedio bayesiano de un curso. Requiere poder ver el curso",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Puntuaciones"
+                ],
+                "summary": "Obtener las estadísticas de puntuación de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EstadisticasPuntuacion"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/puntuaciones/cursos/{id}/promedio": {
+            "get": {
+                "description": "Devuelve el promedio de puntuaciones de un curso por su ID, si el usuario puede ver el curso",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Puntuaciones"
+                ],
+                "summary": "Obtener el promedio de puntuaciones de un curso",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "promedio: 0.0",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/puntuaciones/reconciliar": {
+            "post": {
+                "description": "Recalcula la valoración y la valoración ponderada de todos los cursos a partir de sus puntuaciones en Neo4j y devuelve los cursos cuya valoración almacenada no coincidía. Solo disponible para administradores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Puntuaciones"
+                ],
+                "summary": "Reconciliar las valoraciones de los cursos",
+                "parameters": [
+                    {
+                        "description": "Credenciales del administrador",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DiferenciaValoracion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "error: Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/puntuaciones/usuarios/{email}": {
+            "get": {
+                "description": "Devuelve todas las puntuaciones hechas por un usuario por su email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Puntuaciones"
+                ],
+                "summary": "Obtener todas las puntuaciones hechas por un usuario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email del usuario",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "curso: nombre del curso, valoracion: valor de la puntuación",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/resenas/{id}/votos": {
+            "post": {
+                "description": "Registra un voto \"útil\" o \"no útil\" sobre una reseña. Cada usuario tiene un único voto por reseña y no puede votar la suya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reseñas"
+                ],
+                "summary": "Votar la utilidad de una reseña",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la reseña",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Voto",
+                        "name": "voto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VotoResenaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Resena"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error: Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tareas/{id}/entregas": {
+            "get": {
+                "description": "Devuelve las entregas de la tarea con su calificación. Solo el instructor del curso o un administrador puede verlas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tareas"
+                ],
+                "summary": "Obtener las entregas de una tarea",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la tarea",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del instructor",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del instructor",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Entrega"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Sube el archivo de la entrega de un usuario inscrito en el curso, si la unidad de la tarea no está bloqueada para él. Si ya había entregado y la entrega no fue calificada, la reemplaza. Las entregas posteriores a la fecha límite se marcan como atrasadas.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tareas"
+                ],
+                "summary": "Entregar una tarea",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la tarea",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Archivo de la entrega (PDF, texto, imágenes, documentos de Office o ZIP, 25 MB como máximo)",
+                        "name": "archivo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Entrega"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unidades/{id}/clases": {
+            "get": {
+                "description": "Devuelve todas las clases asociadas a una unidad. Si la unidad está bloqueada para el usuario (o para un usuario no inscrito, sin credenciales) las clases se devuelven sin video, adjuntos ni subtítulos y con la fecha en que se desbloquean, si se conoce",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clases"
+                ],
+                "summary": "Devuelve las clases de una unidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClaseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Agrega una clase a la base de datos asociada a una unidad",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clases"
+                ],
+                "summary": "Crear una clase para una unidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clase a crear",
+                        "name": "clase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateClaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CrearClase"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unidades/{id}/cuestionarios": {
+            "get": {
+                "description": "Devuelve los cuestionarios de la unidad sin sus respuestas correctas. Requiere poder ver el curso de la unidad y que la unidad no esté bloqueada para el usuario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cuestionarios"
+                ],
+                "summary": "Obtener los cuestionarios de una unidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Cuestionario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Crea un cuestionario de opción múltiple, verdadero o falso y respuesta corta en la unidad. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cuestionarios"
+                ],
+                "summary": "Crear el cuestionario de una unidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y cuestionario",
+                        "name": "cuestionario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCuestionarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cuestionario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unidades/{id}/desbloqueo": {
+            "put": {
+                "description": "Define cuándo se desbloquea la unidad para los inscritos: en una fecha fija (fecha), una cantidad de días después de su inscripción (dias_inscripcion) o al completar la unidad anterior del curso (unidad_anterior). Reemplaza la regla anterior. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unidades"
+                ],
+                "summary": "Definir la regla de desbloqueo de una unidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y regla de desbloqueo",
+                        "name": "regla",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DesbloqueoUnidadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Unidad"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Quita la regla de desbloqueo de la unidad, que queda disponible para todos los inscritos. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unidades"
+                ],
+                "summary": "Quitar la regla de desbloqueo de una unidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor",
+                        "name": "credenciales",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CredencialesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Unidad"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unidades/{id}/tareas": {
+            "get": {
+                "description": "Devuelve las tareas de la unidad ordenadas por fecha de entrega. Requiere poder ver el curso de la unidad y que la unidad no esté bloqueada para el usuario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tareas"
+                ],
+                "summary": "Obtener las tareas de una unidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tarea"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Crea una tarea con fecha de entrega y puntaje máximo en la unidad. Solo el instructor del curso o un administrador puede hacerlo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tareas"
+                ],
+                "summary": "Crear una tarea",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la unidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credenciales del instructor y datos de la tarea",
+                        "name": "tarea",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateTareaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tarea"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios": {
+            "get": {
+                "description": "Devuelve la lista completa de usuarios registrados",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Devuelve todos los usuarios",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Agrega un usuario a la base de datos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Crear un nuevo usuario",
+                "parameters": [
+                    {
+                        "description": "Usuario a crear",
+                        "name": "usuario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateUsuarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Usuario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/anuncios": {
+            "get": {
+                "description": "Devuelve los anuncios más recientes de los cursos en los que el usuario está inscrito, indicando cuáles ya leyó.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anuncios"
+                ],
+                "summary": "Feed de anuncios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cantidad máxima de anuncios (10 por defecto, máximo 50)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AnuncioFeed"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/anuncios/leidos": {
+            "post": {
+                "description": "Marca como leídos los anuncios indicados, o todos los de los cursos inscritos si no se indica ninguno.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anuncios"
+                ],
+                "summary": "Marcar anuncios como leídos",
+                "parameters": [
+                    {
+                        "description": "Credenciales del usuario e IDs de los anuncios",
+                        "name": "anuncios",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MarcarAnunciosLeidosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/cursos": {
+            "get": {
+                "description": "Devuelve la lista de cursos en los que un usuario está inscrito, con la cantidad de anuncios sin leer de cada uno",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Obtener cursos inscritos de un usuario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CursoInscrito"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/entregas": {
+            "get": {
+                "description": "Devuelve las entregas del usuario, de la más reciente a la más antigua, con su calificación y el comentario del instructor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tareas"
+                ],
+                "summary": "Obtener mis entregas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Entrega"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/inscripcion": {
+            "post": {
+                "description": "Inscribe a un usuario en un curso específico",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Inscribir un usuario en un curso",
+                "parameters": [
+                    {
+                        "description": "Datos de inscripción",
+                        "name": "inscripcion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.InscripcionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.InscripcionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/password/restablecer": {
+            "post": {
+                "description": "Cambia la contraseña del usuario usando el código enviado a su correo. El código solo puede usarse una vez.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Restablecer la contraseña",
+                "parameters": [
+                    {
+                        "description": "Código recibido y nueva contraseña",
+                        "name": "restablecimiento",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RestablecerPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/password/solicitud": {
+            "post": {
+                "description": "Envía un código de un solo uso, válido por una hora, al correo del usuario. La respuesta es la misma exista o no el usuario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Solicitar el restablecimiento de la contraseña",
+                "parameters": [
+                    {
+                        "description": "Correo del usuario",
+                        "name": "solicitud",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SolicitudRestablecimientoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/progreso": {
+            "get": {
+                "description": "Devuelve el progreso de los cursos en los que un usuario está inscrito, con la duración total de cada curso y el tiempo restante de las clases que aún no vio, en segundos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Devuelve el progreso de los cursos de un usuario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProgresoCursoDetallado"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/usuario": {
+            "get": {
+                "description": "Devuelve un usuario en específico por su correo y contraseña",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Obtener un usuario por correo y contraseña",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Usuario"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usuarios/{email}/{password}/clases/{clase_id}": {
+            "post": {
+                "description": "Permite que un usuario vea una clase y actualiza su progreso en el curso. Las clases de una unidad bloqueada se rechazan indicando hasta cuándo están bloqueadas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Ver una clase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña del usuario",
+                        "name": "password",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de la clase",
+                        "name": "clase_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.VerClaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Adjunto": {
+            "type": "object",
+            "properties": {
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "description": "Suma de verificación del contenido en hexadecimal",
+                    "type": "string"
+                },
+                "subido_por": {
+                    "type": "string"
+                },
+                "tamano": {
+                    "type": "integer"
+                },
+                "tipo_contenido": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Anuncio": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "description": "email de quien lo publicó",
+                    "type": "string"
+                },
+                "contenido": {
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "editado_en": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AnuncioFeed": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "description": "email de quien lo publicó",
+                    "type": "string"
+                },
+                "contenido": {
+                    "type": "string"
+                },
+                "curso": {
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "editado_en": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "leido": {
+                    "type": "boolean"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CambioVersion": {
+            "type": "object",
+            "properties": {
+                "campos": {
+                    "description": "Solo para los elementos modificados",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CampoModificado"
+                    }
+                },
+                "elemento": {
+                    "description": "curso, unidad o clase",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Vacío para los datos del curso",
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "description": "agregado, eliminado o modificado",
+                    "type": "string"
+                },
+                "unidad": {
+                    "description": "Nombre de la unidad, para las clases",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CampoModificado": {
+            "type": "object",
+            "properties": {
+                "antes": {},
+                "campo": {
+                    "type": "string"
+                },
+                "despues": {}
+            }
+        },
+        "models.CategoriaForo": {
+            "type": "object",
+            "properties": {
+                "cant_hilos": {
+                    "type": "integer"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "descripcion": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Clase": {
+            "type": "object",
+            "properties": {
+                "adjuntos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Adjunto"
+                    }
+                },
+                "adjuntos_url": {
+                    "description": "URLs de descarga de los adjuntos",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bloqueada": {
+                    "description": "Si su unidad está bloqueada se omiten el video, los adjuntos y los subtítulos",
+                    "type": "boolean"
+                },
+                "comentarios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "desbloquea_en": {
+                    "type": "string"
+                },
+                "descripcion": {
+                    "type": "string"
+                },
+                "duracion": {
+                    "description": "Duración del video en segundos",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "me_gusta": {
+                    "type": "integer"
+                },
+                "no_me_gusta": {
+                    "type": "integer"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "subtitulos": {
+                    "description": "Una pista por idioma",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PistaSubtitulos"
+                    }
+                },
+                "unidad_id": {
+                    "type": "string"
+                },
+                "video_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Comentario": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "description": "email del usuario",
+                    "type": "string"
+                },
+                "cant_respuestas": {
+                    "type": "integer"
+                },
+                "clase_id": {
+                    "type": "string"
+                },
+                "detalle": {
+                    "type": "string"
+                },
+                "editado": {
+                    "type": "boolean"
+                },
+                "editado_en": {
+                    "type": "string"
+                },
+                "eliminado": {
+                    "type": "boolean"
+                },
+                "es_pregunta": {
+                    "type": "boolean"
+                },
+                "estado_moderacion": {
+                    "description": "\"pendiente\" u \"oculto\" si no es visible",
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "me_gusta": {
+                    "type": "integer"
+                },
+                "no_me_gusta": {
+                    "type": "integer"
+                },
+                "padre_id": {
+                    "description": "ID del comentario al que responde",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "password del usuario",
+                    "type": "string"
+                },
+                "respuesta_aceptada_id": {
+                    "description": "Solo para preguntas",
+                    "type": "string"
+                },
+                "respuestas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Comentario"
+                    }
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ComentarioCurso": {
+            "type": "object",
+            "properties": {
+                "curso": {
+                    "description": "nombre del curso",
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "editado": {
+                    "type": "boolean"
+                },
+                "editado_en": {
+                    "type": "string"
+                },
+                "email": {
+                    "description": "email del autor",
+                    "type": "string"
+                },
+                "estado_moderacion": {
+                    "description": "\"pendiente\" u \"oculto\" si no es visible",
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre_autor": {
+                    "description": "nombre del autor",
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Cuestionario": {
+            "type": "object",
+            "properties": {
+                "clase_id": {
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "intentos_maximos": {
+                    "description": "0 significa intentos ilimitados",
+                    "type": "integer"
+                },
+                "preguntas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PreguntaCuestionario"
+                    }
+                },
+                "puntaje_aprobacion": {
+                    "description": "Porcentaje mínimo para aprobar",
+                    "type": "number"
+                },
+                "requerido": {
+                    "description": "Si es de una clase, aprobarlo es necesario para marcarla como vista",
+                    "type": "boolean"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "unidad_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CursoInscrito": {
+            "type": "object",
+            "properties": {
+                "anuncios_no_leidos": {
+                    "type": "integer"
+                },
+                "cant_clases": {
+                    "type": "integer"
+                },
+                "cant_usuarios": {
+                    "type": "integer"
+                },
+                "comentarios": {
+                    "description": "Lista de IDs de comentarios",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "descripcion": {
+                    "type": "string"
+                },
+                "duracion": {
+                    "description": "Suma de la duración de sus clases en segundos",
+                    "type": "integer"
+                },
+                "estado": {
+                    "description": "borrador, publicado o archivado; sin estado se considera publicado",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imagen_url": {
+                    "type": "string"
+                },
+                "instructor": {
+                    "description": "email del instructor del curso",
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "portada": {
+                    "description": "Imagen de portada subida y sus miniaturas",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PortadaCurso"
+                        }
+                    ]
+                },
+                "publicacion_programada": {
+                    "type": "string"
+                },
+                "publicado_en": {
+                    "type": "string"
+                },
+                "unidades": {
+                    "description": "Lista de IDs de unidades",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "valoracion": {
+                    "type": "number"
+                },
+                "valoracion_ponderada": {
+                    "description": "Promedio bayesiano usado para ordenar el catálogo",
+                    "type": "number"
+                },
+                "version": {
+                    "description": "Número de la última versión guardada de su estructura",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DiferenciaValoracion": {
+            "type": "object",
+            "properties": {
+                "curso_id": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "puntuaciones": {
+                    "type": "integer"
+                },
+                "valoracion_almacenada": {
+                    "type": "number"
+                },
+                "valoracion_calculada": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.DiferenciaVersiones": {
+            "type": "object",
+            "properties": {
+                "cambios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CambioVersion"
+                    }
+                },
+                "desde": {
+                    "type": "integer"
+                },
+                "hasta": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ElementoModeracion": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "type": "string"
+                },
+                "comentario_id": {
+                    "type": "string"
+                },
+                "estado": {
+                    "description": "\"pendiente\", \"aprobado\" u \"oculto\"",
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "motivos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reportes": {
+                    "type": "integer"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "description": "\"clase\" o \"curso\"",
+                    "type": "string"
+                },
+                "titulo": {
+                    "description": "Solo para comentarios de clase",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Entrega": {
+            "type": "object",
+            "properties": {
+                "atrasada": {
+                    "type": "boolean"
+                },
+                "calificacion": {
+                    "type": "number"
+                },
+                "calificada_en": {
+                    "type": "string"
+                },
+                "calificada_por": {
+                    "type": "string"
+                },
+                "comentario": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre_archivo": {
+                    "type": "string"
+                },
+                "tamano": {
+                    "type": "integer"
+                },
+                "tarea_id": {
+                    "type": "string"
+                },
+                "tipo_contenido": {
+                    "type": "string"
+                },
+                "titulo_tarea": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EstadisticasPuntuacion": {
+            "type": "object",
+            "properties": {
+                "curso_id": {
+                    "type": "string"
+                },
+                "desviacion_estandar": {
+                    "type": "number"
+                },
+                "histograma": {
+                    "description": "Cantidad de puntuaciones por estrellas (0 a 5)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "mediana": {
+                    "type": "number"
+                },
+                "promedio": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "valoracion_ponderada": {
+                    "description": "Promedio bayesiano",
+                    "type": "number"
+                }
+            }
+        },
+        "models.EstructuraClase": {
+            "type": "object",
+            "properties": {
+                "descripcion": {
+                    "type": "string"
+                },
+                "duracion": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "video_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EstructuraCurso": {
+            "type": "object",
+            "properties": {
+                "descripcion": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "unidades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EstructuraUnidad"
+                    }
+                }
+            }
+        },
+        "models.EstructuraUnidad": {
+            "type": "object",
+            "properties": {
+                "clases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EstructuraClase"
+                    }
+                },
+                "desbloqueo": {
+                    "$ref": "#/definitions/models.ReglaDesbloqueo"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HiloForo": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "description": "email de quien inició el hilo",
+                    "type": "string"
+                },
+                "bloqueado": {
+                    "type": "boolean"
+                },
+                "cant_publicaciones": {
+                    "type": "integer"
+                },
+                "categoria_id": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "fijado": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre_autor": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "ultima_actividad": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IntentoCuestionario": {
+            "type": "object",
+            "properties": {
+                "aprobado": {
+                    "type": "boolean"
+                },
+                "cuestionario_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "numero": {
+                    "type": "integer"
+                },
+                "porcentaje": {
+                    "type": "number"
+                },
+                "puntaje": {
+                    "type": "integer"
+                },
+                "puntaje_maximo": {
+                    "type": "integer"
+                },
+                "respuestas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RespuestaIntento"
+                    }
+                }
+            }
+        },
+        "models.Marcador": {
+            "type": "object",
+            "properties": {
+                "clase": {
+                    "type": "string"
+                },
+                "clase_id": {
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "usuario": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Nota": {
+            "type": "object",
+            "properties": {
+                "clase": {
+                    "description": "Nombre de la clase, al listar las notas de un curso",
+                    "type": "string"
+                },
+                "clase_id": {
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "editada_en": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "segundo": {
+                    "description": "Momento del video en segundos",
+                    "type": "integer"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "usuario": {
+                    "description": "email del autor",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Notificacion": {
+            "type": "object",
+            "properties": {
+                "datos": {
+                    "description": "IDs y nombres relacionados (curso_id, curso, comentario_id, autor...)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "leida": {
+                    "type": "boolean"
+                },
+                "mensaje": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "description": "respuesta, mencion, inscripcion, certificado o anuncio",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PistaSubtitulos": {
+            "type": "object",
+            "properties": {
+                "fecha": {
+                    "type": "string"
+                },
+                "formato_original": {
+                    "description": "vtt o srt",
+                    "type": "string"
+                },
+                "idioma": {
+                    "description": "Código de idioma, por ejemplo \"es\" o \"en-US\"",
+                    "type": "string"
+                },
+                "segmentos": {
+                    "type": "integer"
+                },
+                "subido_por": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PortadaCurso": {
+            "type": "object",
+            "properties": {
+                "alto": {
+                    "type": "integer"
+                },
+                "ancho": {
+                    "type": "integer"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "tipo_contenido": {
+                    "description": "Tipo de la imagen original",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "url_mediana": {
+                    "type": "string"
+                },
+                "url_pequena": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Pregunta": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "description": "email del usuario",
+                    "type": "string"
+                },
+                "cant_respuestas": {
+                    "type": "integer"
+                },
+                "clase": {
+                    "description": "nombre de la clase",
+                    "type": "string"
+                },
+                "clase_id": {
+                    "type": "string"
+                },
+                "curso": {
+                    "description": "nombre del curso",
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "detalle": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "respondida": {
+                    "description": "Tiene una respuesta aceptada o una respuesta del instructor",
+                    "type": "boolean"
+                },
+                "respuesta_aceptada_id": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PreguntaCuestionario": {
+            "type": "object",
+            "properties": {
+                "enunciado": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "opcion_correcta": {
+                    "description": "Índice de la opción correcta",
+                    "type": "integer"
+                },
+                "opciones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "puntos": {
+                    "type": "integer"
+                },
+                "respuesta_vf": {
+                    "type": "boolean"
+                },
+                "respuestas_aceptadas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tipo": {
+                    "description": "opcion_multiple, verdadero_falso o respuesta_corta",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PreguntasCurso": {
+            "type": "object",
+            "properties": {
+                "curso": {
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "preguntas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Pregunta"
+                    }
+                },
+                "sin_responder": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProgresoCurso": {
+            "type": "object",
+            "properties": {
+                "clases_vistas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "estado": {
+                    "description": "INICIADO, EN CURSO, COMPLETADO",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProgresoCursoDetallado": {
+            "type": "object",
+            "properties": {
+                "clases_vistas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "duracion_total": {
+                    "type": "integer"
+                },
+                "estado": {
+                    "description": "INICIADO, EN CURSO, COMPLETADO",
+                    "type": "string"
+                },
+                "tiempo_restante": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PublicacionForo": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "type": "string"
+                },
+                "contenido": {
+                    "type": "string"
+                },
+                "eliminada": {
+                    "type": "boolean"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "hilo_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nombre_autor": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Puntuacion": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "email del usuario",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "contraseña del usuario",
+                    "type": "string"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ReglaDesbloqueo": {
+            "type": "object",
+            "properties": {
+                "dias": {
+                    "type": "integer"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Resena": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "description": "email del usuario",
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "no_util": {
+                    "description": "Votos \"no útil\" de otros usuarios",
+                    "type": "integer"
+                },
+                "nombre_autor": {
+                    "description": "nombre del usuario",
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "util": {
+                    "description": "Votos \"útil\" de otros usuarios",
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.RespuestaIntento": {
+            "type": "object",
+            "properties": {
+                "correcta": {
+                    "type": "boolean"
+                },
+                "opcion": {
+                    "type": "integer"
+                },
+                "pregunta_id": {
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "valor": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ResultadoTranscripcion": {
+            "type": "object",
+            "properties": {
+                "clase": {
+                    "type": "string"
+                },
+                "clase_id": {
+                    "type": "string"
+                },
+                "fin": {
+                    "type": "number"
+                },
+                "idioma": {
+                    "type": "string"
+                },
+                "inicio": {
+                    "description": "Segundos desde el comienzo del video",
+                    "type": "number"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "unidad_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResultadosCuestionario": {
+            "type": "object",
+            "properties": {
+                "aprobado": {
+                    "type": "boolean"
+                },
+                "cuestionario_id": {
+                    "type": "string"
+                },
+                "intentos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IntentoCuestionario"
+                    }
+                },
+                "intentos_restantes": {
+                    "description": "Se omite si los intentos son ilimitados",
+                    "type": "integer"
+                },
+                "mejor_porcentaje": {
+                    "type": "number"
+                },
+                "mejor_puntaje": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RevisionComentario": {
+            "type": "object",
+            "properties": {
+                "fecha": {
+                    "description": "Fecha en que se escribió esta versión",
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "description": "Solo para comentarios de clase",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Tarea": {
+            "type": "object",
+            "properties": {
+                "curso_id": {
+                    "type": "string"
+                },
+                "descripcion": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "fecha_entrega": {
+                    "description": "Las entregas posteriores se marcan como atrasadas",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "puntaje_maximo": {
+                    "type": "number"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "unidad_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Unidad": {
+            "type": "object",
+            "properties": {
+                "bloqueada": {
+                    "description": "Se calcula para el usuario que consulta",
+                    "type": "boolean"
+                },
+                "clases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "desbloquea_en": {
+                    "description": "Vacío si depende de completar la unidad anterior",
+                    "type": "string"
+                },
+                "desbloqueo": {
+                    "description": "Sin regla la unidad está siempre disponible",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReglaDesbloqueo"
+                        }
+                    ]
+                },
+                "duracion": {
+                    "description": "Suma de la duración de sus clases en segundos",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idcurso": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Usuario": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "fecha_inscripcion": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "idioma": {
+                    "description": "Idioma de los correos (es o en)",
+                    "type": "string"
+                },
+                "inscritos": {
+                    "description": "IDs de cursos inscritos",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "progresos": {
+                    "description": "Progreso de los cursos",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProgresoCurso"
+                    }
+                }
+            }
+        },
+        "models.VersionCurso": {
+            "type": "object",
+            "properties": {
+                "autor": {
+                    "description": "email de quien hizo el cambio, si se conoce",
+                    "type": "string"
+                },
+                "curso_id": {
+                    "type": "string"
+                },
+                "estructura": {
+                    "description": "Se omite al listar las versiones",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EstructuraCurso"
+                        }
+                    ]
+                },
+                "fecha": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "motivo": {
+                    "description": "Cambio que generó la versión",
+                    "type": "string"
+                },
+                "numero": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AceptarRespuestaRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "respuesta_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "respuesta_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.AnuncioRequest": {
+            "type": "object",
+            "required": [
+                "contenido",
+                "email",
+                "password",
+                "titulo"
+            ],
+            "properties": {
+                "contenido": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.AsignarInstructorRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "instructor",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "instructor": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CalificarEntregaRequest": {
+            "type": "object",
+            "required": [
+                "calificacion",
+                "email",
+                "password"
+            ],
+            "properties": {
+                "calificacion": {
+                    "type": "number"
+                },
+                "comentario": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateCategoriaForoRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "nombre",
+                "password"
+            ],
+            "properties": {
+                "descripcion": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateClaseRequest": {
+            "type": "object",
+            "required": [
+                "descripcion",
+                "nombre",
+                "video_url"
+            ],
+            "properties": {
+                "descripcion": {
+                    "type": "string"
+                },
+                "duracion": {
+                    "description": "Duración del video en segundos",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "video_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateComentarioCursoRequest": {
+            "type": "object",
+            "required": [
+                "curso_id",
+                "email",
+                "password",
+                "texto"
+            ],
+            "properties": {
+                "curso_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateComentarioRequest": {
+            "type": "object",
+            "required": [
+                "autor",
+                "detalle",
+                "password",
+                "titulo"
+            ],
+            "properties": {
+                "autor": {
+                    "type": "string"
+                },
+                "detalle": {
+                    "type": "string"
+                },
+                "es_pregunta": {
+                    "description": "Marca el comentario como pregunta para el instructor (opcional)",
+                    "type": "boolean"
+                },
+                "padre_id": {
+                    "description": "ID del comentario al que responde (opcional)",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateCuestionarioRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "preguntas",
+                "titulo"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "intentos_maximos": {
+                    "description": "0 para intentos ilimitados",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "password": {
+                    "type": "string"
+                },
+                "preguntas": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.PreguntaCuestionarioRequest"
+                    }
+                },
+                "puntaje_aprobacion": {
+                    "description": "60 por defecto",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "requerido": {
+                    "description": "Solo para cuestionarios de clase",
+                    "type": "boolean"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateCursoRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "nombre",
+                "password"
+            ],
+            "properties": {
+                "descripcion": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "imagen_url": {
+                    "type": "string"
+                },
+                "instructor": {
+                    "description": "email del instructor (opcional, solo administradores)",
+                    "type": "string"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateHiloForoRequest": {
+            "type": "object",
+            "required": [
+                "contenido",
+                "email",
+                "password",
+                "titulo"
+            ],
+            "properties": {
+                "contenido": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreatePublicacionForoRequest": {
+            "type": "object",
+            "required": [
+                "contenido",
+                "email",
+                "password"
+            ],
+            "properties": {
+                "contenido": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateResenaRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "texto"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.CreateTareaRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "fecha_entrega",
+                "password",
+                "puntaje_maximo",
+                "titulo"
+            ],
+            "properties": {
+                "descripcion": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fecha_entrega": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "puntaje_maximo": {
+                    "type": "number"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateUnidadRequest": {
+            "type": "object",
+            "required": [
+                "nombre"
+            ],
+            "properties": {
+                "nombre": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Comentario": {
+        },
+        "request.CreateUsuarioRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "nombre",
+                "password"
+            ],
             "properties": {
-                "autor": {
-                    "description": "email del usuario",
+                "email": {
                     "type": "string"
                 },
-                "clase_id": {
-                    "type": "string"
+                "idioma": {
+                    "description": "Idioma de los correos (es por defecto)",
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en"
+                    ]
                 },
-                "detalle": {
+                "nombre": {
                     "type": "string"
                 },
-                "fecha": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CredencialesRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "id": {
+                "password": {
                     "type": "string"
+                }
+            }
+        },
+        "request.DesbloqueoUnidadRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "tipo"
+            ],
+            "properties": {
+                "dias": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "me_gusta": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
                 },
-                "no_me_gusta": {
-                    "type": "integer"
+                "fecha": {
+                    "type": "string"
                 },
                 "password": {
-                    "description": "password del usuario",
                     "type": "string"
                 },
-                "titulo": {
+                "tipo": {
+                    "type": "string",
+                    "enum": [
+                        "fecha",
+                        "dias_inscripcion",
+                        "unidad_anterior"
+                    ]
+                }
+            }
+        },
+        "request.EstadoHiloForoRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "bloqueado": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fijado": {
+                    "type": "boolean"
+                },
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "models.ComentarioCurso": {
+        "request.InscripcionRequest": {
             "type": "object",
+            "required": [
+                "curso_id",
+                "email",
+                "password"
+            ],
             "properties": {
                 "curso_id": {
                     "type": "string"
@@ -1227,174 +9208,310 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
-                "texto": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "models.Curso": {
+        "request.IntentoCuestionarioRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
             "properties": {
-                "cant_clases": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
                 },
-                "cant_usuarios": {
-                    "type": "integer"
+                "password": {
+                    "type": "string"
                 },
-                "comentarios": {
-                    "description": "Lista de IDs de comentarios",
+                "respuestas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.RespuestaCuestionarioRequest"
+                    }
+                }
+            }
+        },
+        "request.MarcarAnunciosLeidosRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "descripcion": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.MarcarNotificacionesLeidasRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "id": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ModeracionRequest": {
+            "type": "object",
+            "required": [
+                "accion",
+                "email",
+                "password"
+            ],
+            "properties": {
+                "accion": {
+                    "type": "string",
+                    "enum": [
+                        "aprobar",
+                        "ocultar",
+                        "eliminar"
+                    ]
+                },
+                "email": {
                     "type": "string"
                 },
-                "imagen_url": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.NotaRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "texto"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "nombre": {
+                "password": {
                     "type": "string"
                 },
-                "unidades": {
-                    "description": "Lista de IDs de unidades",
+                "segundo": {
+                    "description": "Momento del video en segundos",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "texto": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PreferenciasNotificacionRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "preferencias"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "preferencias": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                }
+            }
+        },
+        "request.PreguntaCuestionarioRequest": {
+            "type": "object",
+            "required": [
+                "enunciado",
+                "tipo"
+            ],
+            "properties": {
+                "enunciado": {
+                    "type": "string"
+                },
+                "opcion_correcta": {
+                    "type": "integer"
+                },
+                "opciones": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "valoracion": {
-                    "type": "number"
+                "puntos": {
+                    "description": "1 por defecto",
+                    "type": "integer"
+                },
+                "respuesta_vf": {
+                    "type": "boolean"
+                },
+                "respuestas_aceptadas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tipo": {
+                    "type": "string",
+                    "enum": [
+                        "opcion_multiple",
+                        "verdadero_falso",
+                        "respuesta_corta"
+                    ]
                 }
             }
         },
-        "models.ProgresoCurso": {
+        "request.PublicarCursoRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
             "properties": {
-                "clases_vistas": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "email": {
+                    "type": "string"
                 },
-                "curso_id": {
+                "fecha": {
                     "type": "string"
                 },
-                "estado": {
-                    "description": "INICIADO, EN CURSO, COMPLETADO",
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "models.Puntuacion": {
+        "request.ReaccionRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "password",
+                "tipo"
+            ],
             "properties": {
                 "email": {
-                    "description": "email del usuario",
                     "type": "string"
                 },
                 "password": {
-                    "description": "contraseña del usuario",
                     "type": "string"
                 },
-                "valor": {
-                    "type": "number"
+                "tipo": {
+                    "type": "string",
+                    "enum": [
+                        "me_gusta",
+                        "no_me_gusta"
+                    ]
                 }
             }
         },
-        "models.Usuario": {
+        "request.ReporteComentarioRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "motivo",
+                "password"
+            ],
             "properties": {
                 "email": {
                     "type": "string"
                 },
-                "fecha_inscripcion": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "inscritos": {
-                    "description": "IDs de cursos inscritos",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "nombre": {
+                "motivo": {
                     "type": "string"
                 },
                 "password": {
                     "type": "string"
-                },
-                "progresos": {
-                    "description": "Progreso de los cursos",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ProgresoCurso"
-                    }
                 }
             }
         },
-        "request.CreateClaseRequest": {
+        "request.RespuestaCuestionarioRequest": {
             "type": "object",
             "required": [
-                "descripcion",
-                "nombre",
-                "video_url"
+                "pregunta_id"
             ],
             "properties": {
-                "descripcion": {
-                    "type": "string"
+                "opcion": {
+                    "type": "integer"
                 },
-                "nombre": {
+                "pregunta_id": {
                     "type": "string"
                 },
-                "video_url": {
+                "texto": {
                     "type": "string"
+                },
+                "valor": {
+                    "type": "boolean"
                 }
             }
         },
-        "request.CreateCursoRequest": {
+        "request.RestablecerPasswordRequest": {
             "type": "object",
             "required": [
-                "nombre"
+                "password",
+                "token"
             ],
             "properties": {
-                "descripcion": {
-                    "type": "string"
-                },
-                "imagen_url": {
+                "password": {
                     "type": "string"
                 },
-                "nombre": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "request.CreateUnidadRequest": {
+        "request.SolicitudRestablecimientoRequest": {
             "type": "object",
             "required": [
-                "nombre"
+                "email"
             ],
             "properties": {
-                "nombre": {
+                "email": {
                     "type": "string"
                 }
             }
         },
-        "request.CreateUsuarioRequest": {
+        "request.UpdateClaseRequest": {
             "type": "object",
             "required": [
+                "descripcion",
                 "email",
                 "nombre",
-                "password"
+                "password",
+                "video_url"
             ],
             "properties": {
+                "descripcion": {
+                    "type": "string"
+                },
+                "duracion": {
+                    "description": "Duración del video en segundos",
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1403,18 +9520,41 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string"
+                },
+                "video_url": {
+                    "type": "string"
                 }
             }
         },
-        "request.InscripcionRequest": {
+        "request.UpdateComentarioCursoRequest": {
             "type": "object",
             "required": [
-                "curso_id",
                 "email",
-                "password"
+                "password",
+                "texto"
             ],
             "properties": {
-                "curso_id": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateComentarioRequest": {
+            "type": "object",
+            "required": [
+                "detalle",
+                "email",
+                "password",
+                "titulo"
+            ],
+            "properties": {
+                "detalle": {
                     "type": "string"
                 },
                 "email": {
@@ -1422,38 +9562,89 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
                 }
             }
         },
         "request.UpdateValoracionRequest": {
             "type": "object",
             "required": [
+                "email",
+                "motivo",
+                "password",
                 "valoracion"
             ],
             "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "motivo": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
                 "valoracion": {
                     "type": "number"
                 }
             }
         },
+        "request.VotoResenaRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "util"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "util": {
+                    "type": "boolean"
+                }
+            }
+        },
         "response.ClaseResponse": {
             "type": "object",
             "properties": {
+                "adjuntos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Adjunto"
+                    }
+                },
                 "adjuntos_url": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "bloqueada": {
+                    "description": "Sin video, adjuntos ni subtítulos hasta que se desbloquee",
+                    "type": "boolean"
+                },
                 "comentarios": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "desbloquea_en": {
+                    "type": "string"
+                },
                 "descripcion": {
                     "type": "string"
                 },
+                "duracion": {
+                    "description": "Duración del video en segundos",
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -1466,6 +9657,12 @@ const docTemplate = `{
                 "nombre": {
                     "type": "string"
                 },
+                "subtitulos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PistaSubtitulos"
+                    }
+                },
                 "unidad_id": {
                     "type": "string"
                 },
@@ -1474,6 +9671,24 @@ const docTemplate = `{
                 }
             }
         },
+        "response.ComentariosCursoPaginadosResponse": {
+            "type": "object",
+            "properties": {
+                "comentarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComentarioCurso"
+                    }
+                },
+                "limite": {
+                    "type": "integer"
+                },
+                "siguiente_cursor": {
+                    "description": "Vacío si no hay más comentarios",
+                    "type": "string"
+                }
+            }
+        },
         "response.CrearClase": {
             "type": "object",
             "properties": {
@@ -1501,6 +9716,9 @@ const docTemplate = `{
         "response.CursoResponse": {
             "type": "object",
             "properties": {
+                "cant_clases": {
+                    "type": "integer"
+                },
                 "cant_usuarios": {
                     "type": "integer"
                 },
@@ -1514,15 +9732,37 @@ const docTemplate = `{
                 "descripcion": {
                     "type": "string"
                 },
+                "duracion": {
+                    "description": "Suma de la duración de las clases en segundos",
+                    "type": "integer"
+                },
+                "estado": {
+                    "description": "borrador, publicado o archivado",
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "imagen_url": {
                     "type": "string"
                 },
+                "instructor": {
+                    "description": "email del instructor",
+                    "type": "string"
+                },
+                "miniatura_mediana_url": {
+                    "type": "string"
+                },
+                "miniatura_pequena_url": {
+                    "description": "Solo si el curso tiene portada subida",
+                    "type": "string"
+                },
                 "nombre": {
                     "type": "string"
                 },
+                "publicacion_programada": {
+                    "type": "string"
+                },
                 "unidades": {
                     "description": "IDs de las unidades",
                     "type": "array",
@@ -1532,6 +9772,9 @@ const docTemplate = `{
                 },
                 "valoracion": {
                     "type": "number"
+                },
+                "valoracion_ponderada": {
+                    "type": "number"
                 }
             }
         },
@@ -1543,6 +9786,49 @@ const docTemplate = `{
                 }
             }
         },
+        "response.HiloForoResponse": {
+            "type": "object",
+            "properties": {
+                "hilo": {
+                    "$ref": "#/definitions/models.HiloForo"
+                },
+                "limite": {
+                    "type": "integer"
+                },
+                "pagina": {
+                    "type": "integer"
+                },
+                "publicaciones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PublicacionForo"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.HilosForoPaginadosResponse": {
+            "type": "object",
+            "properties": {
+                "hilos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HiloForo"
+                    }
+                },
+                "limite": {
+                    "type": "integer"
+                },
+                "pagina": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "response.InscripcionResponse": {
             "type": "object",
             "properties": {
@@ -1551,6 +9837,71 @@ const docTemplate = `{
                 }
             }
         },
+        "response.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.NotificacionesNoLeidasResponse": {
+            "type": "object",
+            "properties": {
+                "no_leidas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PanelInstructorResponse": {
+            "type": "object",
+            "properties": {
+                "cursos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PreguntasCurso"
+                    }
+                },
+                "total_sin_responder": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ReaccionResponse": {
+            "type": "object",
+            "properties": {
+                "me_gusta": {
+                    "type": "integer"
+                },
+                "no_me_gusta": {
+                    "type": "integer"
+                },
+                "reaccion": {
+                    "description": "Reacción vigente del usuario (\"\" si la quitó)",
+                    "type": "string"
+                }
+            }
+        },
+        "response.ResenasPaginadasResponse": {
+            "type": "object",
+            "properties": {
+                "limite": {
+                    "type": "integer"
+                },
+                "pagina": {
+                    "type": "integer"
+                },
+                "resenas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Resena"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "response.UpdateValoracionResponse": {
             "type": "object",
             "properties": {
@@ -7,8 +7,11 @@ import (
     "os"
     "os/signal"
     "syscall"
+    "time"
 
+    "go-API/almacenamiento"
     "go-API/controllers"
+    "go-API/correo"
     _ "go-API/docs" // Importar los documentos de Swagger
     "go-API/services"
     "go-API/neo4j"
@@ -31,6 +34,9 @@ import (
 
 var mongoClient *mongo.Client
 var redisClient *redis.Client
+var colaCorreo *correo.Cola
+var almacen almacenamiento.Almacenamiento
+var programadorPublicaciones *services.ProgramadorPublicaciones
 
 func init() {
     if err := loadEnv(); err != nil {
@@ -41,10 +47,26 @@ func init() {
     }
     // Inicializar Neo4j
     neo4j.InitNeo4j()
+
+    // Inicializar la cola de envío de correos
+    var err error
+    colaCorreo, err = correo.NewColaDesdeEntorno()
+    if err != nil {
+        log.Fatal("Error al configurar el envío de correos:", err)
+    }
+    colaCorreo.Iniciar(2)
+
+    // Inicializar el almacenamiento de archivos
+    almacen, err = almacenamiento.NewDesdeEntorno()
+    if err != nil {
+        log.Fatal("Error al configurar el almacenamiento de archivos:", err)
+    }
 }
 
 func main() {
     router := gin.Default()
+    // Los formularios de subida se guardan en memoria hasta este tamaño y el resto en archivos temporales
+    router.MaxMultipartMemory = 8 << 20
 
     redisClient := redis.NewClient(&redis.Options{
         Addr: "localhost:6379",
@@ -58,27 +80,87 @@ func main() {
     db := mongoClient.Database("miBaseDeDatos")
     migrationService := services.NewMigrationService(redisClient, db, neo4j.Driver)
     
-    cursoService := services.NewCursoService(db, neo4j.Driver)
+    cursoService := services.NewCursoService(db, neo4j.Driver, redisClient)
     cursoControlador := controllers.NewCursoControlador(cursoService)
 
-    unidadService := services.NewUnidadService(db)
+    // Publicar en segundo plano los cursos con publicación programada
+    programadorPublicaciones = services.NewProgramadorPublicaciones(cursoService, time.Minute)
+    programadorPublicaciones.Iniciar()
+
+    portadaService := services.NewPortadaService(db, redisClient, almacen)
+    portadaControlador := controllers.NewPortadaControlador(portadaService)
+
+    unidadService := services.NewUnidadService(db, neo4j.Driver, redisClient)
     unidadControlador := controllers.NewUnidadControlador(unidadService)
 
-    claseService := services.NewClaseService(db)
+    claseService := services.NewClaseService(db, neo4j.Driver, redisClient, almacen)
     claseControlador := controllers.NewClaseControlador(claseService)
 
-    usuarioService := services.NewUsuarioService(redisClient,db.Collection("cursos"),db.Collection("unidades"),db.Collection("clases"),neo4j.Driver)
+    adjuntoService := services.NewAdjuntoService(db, redisClient, almacen)
+    adjuntoControlador := controllers.NewAdjuntoControlador(adjuntoService)
+
+    subtituloService := services.NewSubtituloService(db, redisClient, almacen)
+    subtituloControlador := controllers.NewSubtituloControlador(subtituloService)
+    if err := subtituloService.CrearIndices(context.Background()); err != nil {
+        log.Fatal("Error al crear los índices de las transcripciones:", err)
+    }
+
+    correoService := services.NewCorreoService(colaCorreo, redisClient)
+
+    notificacionService := services.NewNotificacionService(redisClient, neo4j.Driver, correoService)
+    notificacionControlador := controllers.NewNotificacionControlador(notificacionService)
+
+    cuestionarioService := services.NewCuestionarioService(db, redisClient)
+    cuestionarioControlador := controllers.NewCuestionarioControlador(cuestionarioService)
+    if err := cuestionarioService.CrearIndices(context.Background()); err != nil {
+        log.Fatal("Error al crear los índices de los cuestionarios:", err)
+    }
+
+    tareaService := services.NewTareaService(db, redisClient, almacen)
+    tareaControlador := controllers.NewTareaControlador(tareaService)
+    if err := tareaService.CrearIndices(context.Background()); err != nil {
+        log.Fatal("Error al crear los índices de las tareas:", err)
+    }
+
+    notaService := services.NewNotaService(db, redisClient)
+    notaControlador := controllers.NewNotaControlador(notaService)
+
+    versionService := services.NewVersionService(db, neo4j.Driver, redisClient)
+    versionControlador := controllers.NewVersionControlador(versionService)
+    if err := versionService.CrearIndices(context.Background()); err != nil {
+        log.Fatal("Error al crear los índices de las versiones:", err)
+    }
+
+    usuarioService := services.NewUsuarioService(redisClient,db.Collection("cursos"),db.Collection("unidades"),db.Collection("clases"),db.Collection("anuncios"),neo4j.Driver, notificacionService, correoService, cuestionarioService)
     usuarioControlador := controllers.NewUsuarioControlador(usuarioService)
 
-    comentarioService := services.NewComentarioService(neo4j.Driver, redisClient)
+    comentarioService := services.NewComentarioService(neo4j.Driver, db.Collection("cursos"), redisClient, notificacionService)
     comentarioControlador := controllers.NewComentarioControlador(comentarioService)
 
-    comentarioCursoService := services.NewComentarioCursoService(neo4j.Driver)
+    reaccionService := services.NewReaccionService(neo4j.Driver, redisClient, db.Collection("clases"))
+    reaccionControlador := controllers.NewReaccionControlador(reaccionService)
+
+    comentarioCursoService := services.NewComentarioCursoService(neo4j.Driver, db.Collection("cursos"), redisClient, notificacionService)
     comentarioCursoControlador := controllers.NewComentarioCursoControlador(comentarioCursoService)
 
     puntuacionService := services.NewPuntuacionService(neo4j.Driver, db.Collection("cursos"),redisClient)
     puntuacionesControlador := controllers.NewPuntuacionesControlador(puntuacionService)
 
+    resenaService := services.NewResenaService(neo4j.Driver, db.Collection("cursos"), redisClient, puntuacionService)
+    resenaControlador := controllers.NewResenaControlador(resenaService)
+
+    anuncioService := services.NewAnuncioService(db.Collection("anuncios"), db.Collection("cursos"), redisClient, notificacionService)
+    anuncioControlador := controllers.NewAnuncioControlador(anuncioService)
+
+    foroService := services.NewForoService(neo4j.Driver, db.Collection("cursos"), redisClient)
+    foroControlador := controllers.NewForoControlador(foroService)
+
+    moderacionService := services.NewModeracionService(neo4j.Driver, redisClient, notificacionService)
+    moderacionControlador := controllers.NewModeracionControlador(moderacionService)
+
+    preguntaService := services.NewPreguntaService(neo4j.Driver, db.Collection("cursos"), redisClient)
+    preguntaControlador := controllers.NewPreguntaControlador(preguntaService)
+
     // Rutas de la API
     router.GET("/", func(c *gin.Context) {
         c.JSON(200, gin.H{"message": "Conexión exitosa"})
@@ -88,20 +170,87 @@ func main() {
     router.GET("/api/cursos", cursoControlador.ObtenerCursos)
     router.GET("/api/cursos/:id", cursoControlador.ObtenerCursoPorID)
     router.PATCH("/api/cursos/:id/valoracion", cursoControlador.ActualizarValoracion)
+    router.PUT("/api/cursos/:id/instructor", cursoControlador.AsignarInstructor)
     router.POST("/api/cursos", cursoControlador.CrearCurso)
     router.GET("/api/cursos/:id/clases", cursoControlador.ObtenerClasesPorCurso)
+    router.POST("/api/cursos/:id/publicar", cursoControlador.PublicarCurso)
+    router.POST("/api/cursos/:id/despublicar", cursoControlador.DespublicarCurso)
+    router.POST("/api/cursos/:id/archivar", cursoControlador.ArchivarCurso)
+    router.PUT("/api/cursos/:id/portada", portadaControlador.SubirPortada)
+    router.GET("/api/cursos/:id/portada/:tamano", portadaControlador.ObtenerPortada)
 
     // Unidades
     router.GET("/api/cursos/:id/unidades", unidadControlador.ObtenerUnidadesPorCurso)
     router.POST("/api/cursos/:id/unidades", unidadControlador.CrearUnidad)
+    router.PUT("/api/unidades/:id/desbloqueo", unidadControlador.ActualizarDesbloqueo)
+    router.DELETE("/api/unidades/:id/desbloqueo", unidadControlador.EliminarDesbloqueo)
 
     // Clases
     router.GET("/api/unidades/:id/clases", claseControlador.ObtenerClasesPorUnidad)
     router.POST("/api/unidades/:id/clases", claseControlador.CrearClaseParaUnidad)
+    router.PUT("/api/clases/:id", claseControlador.ActualizarClase)
+    router.DELETE("/api/clases/:id", claseControlador.EliminarClase)
+    router.POST("/api/clases/:id/adjuntos", adjuntoControlador.SubirAdjunto)
+    router.GET("/api/clases/:id/adjuntos/:adjunto_id", adjuntoControlador.DescargarAdjunto)
+    router.DELETE("/api/clases/:id/adjuntos/:adjunto_id", adjuntoControlador.EliminarAdjunto)
+
+    // Subtítulos y transcripciones
+    router.GET("/api/clases/:id/subtitulos", subtituloControlador.ObtenerSubtitulos)
+    router.POST("/api/clases/:id/subtitulos", subtituloControlador.SubirSubtitulos)
+    router.GET("/api/clases/:id/subtitulos/:idioma", subtituloControlador.DescargarSubtitulos)
+    router.DELETE("/api/clases/:id/subtitulos/:idioma", subtituloControlador.EliminarSubtitulos)
+    router.GET("/api/cursos/:id/transcripciones", subtituloControlador.BuscarEnTranscripciones)
+
+    // Cuestionarios
+    router.GET("/api/clases/:id/cuestionarios", cuestionarioControlador.ObtenerCuestionariosPorClase)
+    router.POST("/api/clases/:id/cuestionarios", cuestionarioControlador.CrearCuestionarioParaClase)
+    router.GET("/api/unidades/:id/cuestionarios", cuestionarioControlador.ObtenerCuestionariosPorUnidad)
+    router.POST("/api/unidades/:id/cuestionarios", cuestionarioControlador.CrearCuestionarioParaUnidad)
+    router.GET("/api/cuestionarios/:id", cuestionarioControlador.ObtenerCuestionario)
+    router.POST("/api/cuestionarios/:id/intentos", cuestionarioControlador.EnviarIntento)
+    router.GET("/api/cuestionarios/:id/intentos", cuestionarioControlador.ObtenerResultados)
+
+    // Tareas
+    router.GET("/api/unidades/:id/tareas", tareaControlador.ObtenerTareasPorUnidad)
+    router.POST("/api/unidades/:id/tareas", tareaControlador.CrearTarea)
+    router.POST("/api/tareas/:id/entregas", tareaControlador.EntregarTarea)
+    router.GET("/api/tareas/:id/entregas", tareaControlador.ObtenerEntregas)
+    router.GET("/api/entregas/:id/archivo", tareaControlador.DescargarEntrega)
+    router.PUT("/api/entregas/:id/calificacion", tareaControlador.CalificarEntrega)
+    router.GET("/api/usuarios/entregas", tareaControlador.ObtenerEntregasUsuario)
+
+    // Notas y marcadores
+    router.POST("/api/clases/:id/notas", notaControlador.CrearNota)
+    router.PUT("/api/notas/:id", notaControlador.EditarNota)
+    router.DELETE("/api/notas/:id", notaControlador.EliminarNota)
+    router.GET("/api/cursos/:id/notas", notaControlador.ObtenerNotasPorCurso)
+    router.GET("/api/cursos/:id/notas/exportar", notaControlador.ExportarNotas)
+    router.PUT("/api/clases/:id/marcador", notaControlador.GuardarMarcador)
+    router.DELETE("/api/clases/:id/marcador", notaControlador.EliminarMarcador)
+    router.GET("/api/cursos/:id/marcadores", notaControlador.ObtenerMarcadoresPorCurso)
+
+    // Versiones de los cursos
+    router.GET("/api/cursos/:id/versiones", versionControlador.ObtenerVersiones)
+    router.GET("/api/cursos/:id/versiones/diferencias", versionControlador.CompararVersiones)
+    router.GET("/api/cursos/:id/versiones/:numero", versionControlador.ObtenerVersion)
+    router.POST("/api/cursos/:id/versiones/:numero/restaurar", versionControlador.RestaurarVersion)
 
     // Comentarios
     router.GET("/api/clases/:id/comentarios", comentarioControlador.ObtenerComentariosPorClase)
     router.POST("/api/clases/:id/comentarios", comentarioControlador.CrearComentarioParaClase)
+    router.GET("/api/comentarios/:id/hilo", comentarioControlador.ObtenerHilo)
+    router.PUT("/api/comentarios/:id", comentarioControlador.EditarComentario)
+    router.DELETE("/api/comentarios/:id", comentarioControlador.EliminarComentario)
+    router.GET("/api/comentarios/:id/revisiones", comentarioControlador.ObtenerRevisionesComentario)
+
+    // Preguntas y respuestas
+    router.GET("/api/cursos/:id/preguntas", preguntaControlador.ObtenerPreguntasPorCurso)
+    router.GET("/api/instructores/preguntas", preguntaControlador.ObtenerPanelInstructor)
+    router.POST("/api/comentarios/:id/respuesta_aceptada", preguntaControlador.AceptarRespuesta)
+
+    // Reacciones
+    router.POST("/api/clases/:id/reaccion", reaccionControlador.ReaccionarClase)
+    router.POST("/api/comentarios/:id/reaccion", reaccionControlador.ReaccionarComentario)
 
     // Usuarios
     router.GET("/api/usuarios", usuarioControlador.ObtenerUsuarios)
@@ -111,15 +260,61 @@ func main() {
     router.POST("/api/usuarios/inscripcion", usuarioControlador.InscribirseACurso)
     router.POST("/api/usuarios/:email/:password/clases/:clase_id", usuarioControlador.VerClase)
     router.GET("/api/usuarios/progreso", usuarioControlador.ObtenerProgresoCursos)
+    router.POST("/api/usuarios/password/solicitud", usuarioControlador.SolicitarRestablecimientoPassword)
+    router.POST("/api/usuarios/password/restablecer", usuarioControlador.RestablecerPassword)
 
     // Puntuaciones
     router.POST("/api/puntuaciones/cursos/:id", puntuacionesControlador.CrearPuntuacionParaCurso)
     router.GET("/api/puntuaciones/cursos/:id/promedio", puntuacionesControlador.ObtenerPromedioPuntuacion)
-    router.GET("/api/puntuaciones/usuarios/:email", puntuacionesControlador.ObtenerPuntuacionesPorUsuario)	
+    router.GET("/api/puntuaciones/cursos/:id/estadisticas", puntuacionesControlador.ObtenerEstadisticasPuntuacion)
+    router.GET("/api/puntuaciones/usuarios/:email", puntuacionesControlador.ObtenerPuntuacionesPorUsuario)
+    router.POST("/api/puntuaciones/reconciliar", puntuacionesControlador.ReconciliarValoraciones)	
+
+    // Reseñas
+    router.GET("/api/cursos/:id/resenas", resenaControlador.ObtenerResenasPorCurso)
+    router.POST("/api/cursos/:id/resenas", resenaControlador.CrearResena)
+    router.POST("/api/resenas/:id/votos", resenaControlador.VotarResena)
 
     // Comentarios de Curso
     router.POST("/api/comentarios_curso", comentarioCursoControlador.CrearComentarioCurso)
     router.GET("/api/comentarios_curso/usuarios/:email", comentarioCursoControlador.ObtenerComentariosCursoPorUsuario)
+    router.GET("/api/cursos/:id/comentarios", comentarioCursoControlador.ObtenerComentariosPorCurso)
+    router.PUT("/api/comentarios_curso/:id", comentarioCursoControlador.EditarComentarioCurso)
+    router.DELETE("/api/comentarios_curso/:id", comentarioCursoControlador.EliminarComentarioCurso)
+    router.GET("/api/comentarios_curso/:id/revisiones", comentarioCursoControlador.ObtenerRevisionesComentarioCurso)
+
+    // Moderación
+    router.POST("/api/comentarios/:id/reportes", moderacionControlador.ReportarComentario)
+    router.POST("/api/comentarios_curso/:id/reportes", moderacionControlador.ReportarComentarioCurso)
+    router.GET("/api/moderacion/cola", moderacionControlador.ObtenerColaModeracion)
+    router.POST("/api/moderacion/comentarios/:id", moderacionControlador.ModerarComentario)
+    router.POST("/api/moderacion/comentarios_curso/:id", moderacionControlador.ModerarComentarioCurso)
+
+    // Foros
+    router.GET("/api/cursos/:id/foro", foroControlador.ObtenerCategorias)
+    router.POST("/api/cursos/:id/foro/categorias", foroControlador.CrearCategoria)
+    router.GET("/api/foro/categorias/:id/hilos", foroControlador.ObtenerHilos)
+    router.POST("/api/foro/categorias/:id/hilos", foroControlador.CrearHilo)
+    router.GET("/api/foro/hilos/:id", foroControlador.ObtenerHilo)
+    router.PATCH("/api/foro/hilos/:id", foroControlador.ActualizarEstadoHilo)
+    router.DELETE("/api/foro/hilos/:id", foroControlador.EliminarHilo)
+    router.POST("/api/foro/hilos/:id/publicaciones", foroControlador.CrearPublicacion)
+    router.DELETE("/api/foro/publicaciones/:id", foroControlador.EliminarPublicacion)
+
+    // Anuncios
+    router.GET("/api/cursos/:id/anuncios", anuncioControlador.ObtenerAnunciosPorCurso)
+    router.POST("/api/cursos/:id/anuncios", anuncioControlador.CrearAnuncio)
+    router.PUT("/api/anuncios/:id", anuncioControlador.EditarAnuncio)
+    router.DELETE("/api/anuncios/:id", anuncioControlador.EliminarAnuncio)
+    router.GET("/api/usuarios/anuncios", anuncioControlador.ObtenerFeed)
+    router.POST("/api/usuarios/anuncios/leidos", anuncioControlador.MarcarAnunciosLeidos)
+
+    // Notificaciones
+    router.GET("/api/notificaciones", notificacionControlador.ObtenerNotificaciones)
+    router.GET("/api/notificaciones/no_leidas", notificacionControlador.ContarNoLeidas)
+    router.POST("/api/notificaciones/leidas", notificacionControlador.MarcarLeidas)
+    router.GET("/api/notificaciones/preferencias", notificacionControlador.ObtenerPreferencias)
+    router.PUT("/api/notificaciones/preferencias", notificacionControlador.ActualizarPreferencias)
 
     // Migraciones de usuarios y cursos a nodos en el grafo de Neo4j [hacer en postman]
     router.POST("/api/migrate", func(c *gin.Context) {
@@ -130,6 +325,45 @@ func main() {
         c.JSON(200, gin.H{"message": "Migración completada exitosamente"})
     })
 
+    // Migración de unidades y clases de MongoDB a nodos en Neo4j y de comentarios de clase legados
+    router.POST("/api/migrate/contenido", func(c *gin.Context) {
+        if err := migrationService.MigrateContenido(context.Background()); err != nil {
+            c.JSON(500, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(200, gin.H{"message": "Migración de contenido completada exitosamente"})
+    })
+
+    // Migración de pares puntuación + comentario de un mismo usuario a reseñas
+    router.POST("/api/migrate/resenas", func(c *gin.Context) {
+        migradas, err := migrationService.MigrateResenas(context.Background())
+        if err != nil {
+            c.JSON(500, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(200, gin.H{"message": "Migración de reseñas completada exitosamente", "migradas": migradas})
+    })
+
+    // Asignación de ID y fecha a los comentarios de curso creados antes de que se guardaran
+    router.POST("/api/migrate/comentarios_curso", func(c *gin.Context) {
+        migrados, err := migrationService.MigrateComentariosCurso(context.Background())
+        if err != nil {
+            c.JSON(500, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(200, gin.H{"message": "Migración de comentarios de curso completada exitosamente", "migrados": migrados})
+    })
+
+    // Índice de inscritos por curso en Redis para las inscripciones anteriores a su creación
+    router.POST("/api/migrate/inscritos", func(c *gin.Context) {
+        inscripciones, err := migrationService.MigrateInscritos(context.Background())
+        if err != nil {
+            c.JSON(500, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(200, gin.H{"message": "Migración de inscritos completada exitosamente", "inscripciones": inscripciones})
+    })
+
     // Iniciar el servidor
     go func() {
         if err := router.Run(); err != nil {
@@ -172,6 +406,10 @@ func gracefulShutdown() {
     signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
     <-sigChan
+    log.Println("Deteniendo la publicación programada de cursos...")
+    programadorPublicaciones.Detener()
+    log.Println("Deteniendo la cola de correos...")
+    colaCorreo.Detener()
     log.Println("Cerrando la conexión con MongoDB...")
     if err := mongoClient.Disconnect(context.TODO()); err != nil {
         log.Fatal("Error al desconectar MongoDB:", err)
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Anuncio representa una noticia que el instructor de un curso publica para sus inscritos.
+type Anuncio struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CursoID   primitive.ObjectID `bson:"curso_id" json:"curso_id"`
+	Autor     string             `bson:"autor" json:"autor"` // email de quien lo publicó
+	Titulo    string             `bson:"titulo" json:"titulo"`
+	Contenido string             `bson:"contenido" json:"contenido"`
+	Fecha     time.Time          `bson:"fecha" json:"fecha"`
+	EditadoEn *time.Time         `bson:"editado_en,omitempty" json:"editado_en,omitempty"`
+}
+
+// AnuncioFeed es un anuncio dentro del feed de un usuario, con el nombre de su curso y si ya lo leyó.
+type AnuncioFeed struct {
+	Anuncio `bson:",inline"`
+	Curso   string `json:"curso"`
+	Leido   bool   `json:"leido"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -11,8 +13,39 @@ type Clase struct {
 	Nombre       string               `bson:"nombre" json:"nombre"`
 	Descripcion  string               `bson:"descripcion" json:"descripcion"`
 	VideoURL     string               `bson:"video_url" json:"video_url"`
-	Adjuntos_url []string             `bson:"adjuntos_url" json:"adjuntos_url"`
+	Duracion     int                  `bson:"duracion" json:"duracion"`         // Duración del video en segundos
+	Adjuntos_url []string             `bson:"adjuntos_url" json:"adjuntos_url"` // URLs de descarga de los adjuntos
+	Adjuntos     []Adjunto            `bson:"adjuntos" json:"adjuntos"`
+	Subtitulos   []PistaSubtitulos    `bson:"subtitulos" json:"subtitulos"` // Una pista por idioma
 	Comentarios  []primitive.ObjectID `bson:"comentarios" json:"comentarios"`
 	MeGusta      int                  `bson:"me_gusta" json:"me_gusta"`
 	NoMeGusta    int                  `bson:"no_me_gusta" json:"no_me_gusta"`
+	Bloqueada    bool                 `bson:"-" json:"bloqueada"` // Si su unidad está bloqueada se omiten el video, los adjuntos y los subtítulos
+	DesbloqueaEn *time.Time           `bson:"-" json:"desbloquea_en,omitempty"`
+}
+
+// Adjunto representa un archivo adjunto a una clase, guardado en el almacenamiento de archivos.
+type Adjunto struct {
+	ID            primitive.ObjectID `bson:"_id" json:"id"`
+	Nombre        string             `bson:"nombre" json:"nombre"`
+	TipoContenido string             `bson:"tipo_contenido" json:"tipo_contenido"`
+	Tamano        int64              `bson:"tamano" json:"tamano"`
+	SHA256        string             `bson:"sha256" json:"sha256"` // Suma de verificación del contenido en hexadecimal
+	Clave         string             `bson:"clave" json:"-"`       // Clave del archivo en el almacenamiento
+	URL           string             `bson:"url" json:"url"`
+	SubidoPor     string             `bson:"subido_por" json:"subido_por"`
+	Fecha         time.Time          `bson:"fecha" json:"fecha"`
+}
+
+// PistaSubtitulos describe los subtítulos de una clase en un idioma. Se sirven siempre en formato
+// WebVTT, aunque se hayan subido en SRT.
+type PistaSubtitulos struct {
+	Idioma          string    `bson:"idioma" json:"idioma"`                     // Código de idioma, por ejemplo "es" o "en-US"
+	FormatoOriginal string    `bson:"formato_original" json:"formato_original"` // vtt o srt
+	Segmentos       int       `bson:"segmentos" json:"segmentos"`
+	Version         string    `bson:"version" json:"version"`
+	Clave           string    `bson:"clave" json:"-"` // Clave del archivo WebVTT en el almacenamiento
+	URL             string    `bson:"url" json:"url"`
+	SubidoPor       string    `bson:"subido_por" json:"subido_por"`
+	Fecha           time.Time `bson:"fecha" json:"fecha"`
 }
@@ -8,10 +8,28 @@ type Comentario struct {
     ID        string    `json:"id"`
     ClaseID   string    `json:"clase_id"`
     Autor     string    `json:"autor"`    // email del usuario
-    Password  string    `json:"password"` // password del usuario
+    Password  string    `json:"password,omitempty"` // password del usuario
     Fecha     time.Time `json:"fecha"`
     Titulo    string    `json:"titulo"`
     Detalle   string    `json:"detalle"`
     MeGusta   int       `json:"me_gusta"`
     NoMeGusta int       `json:"no_me_gusta"`
+    PadreID   string    `json:"padre_id,omitempty"` // ID del comentario al que responde
+
+    EsPregunta          bool   `json:"es_pregunta"`
+    RespuestaAceptadaID string `json:"respuesta_aceptada_id,omitempty"` // Solo para preguntas
+
+    Editado          bool         `json:"editado"`
+    EditadoEn        *time.Time   `json:"editado_en"`
+    Eliminado        bool         `json:"eliminado"`
+    EstadoModeracion string       `json:"estado_moderacion,omitempty"` // "pendiente" u "oculto" si no es visible
+    CantRespuestas   int          `json:"cant_respuestas"`
+    Respuestas       []Comentario `json:"respuestas,omitempty"`
+}
+
+// RevisionComentario representa una versión anterior del texto de un comentario (de clase o de curso).
+type RevisionComentario struct {
+    Titulo string    `json:"titulo,omitempty"` // Solo para comentarios de clase
+    Texto  string    `json:"texto"`
+    Fecha  time.Time `json:"fecha"` // Fecha en que se escribió esta versión
 }
@@ -1,10 +1,18 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import "time"
 
-// ComentarioCurso representa un comentario que un usuario hace a un curso.
+// ComentarioCurso representa un comentario que un usuario hace a un curso. Se guarda en Neo4j como
+// relación REALIZO_COMENTARIO entre el Usuario y el Curso.
 type ComentarioCurso struct {
-    Email   string             `bson:"email" json:"email"`
-    CursoID primitive.ObjectID `bson:"curso_id" json:"curso_id"`
-    Texto   string             `bson:"texto" json:"texto"`
+    ID               string     `json:"id"`
+    CursoID          string     `json:"curso_id"`
+    Curso            string     `json:"curso"`        // nombre del curso
+    Email            string     `json:"email"`        // email del autor
+    Nombre           string     `json:"nombre_autor"` // nombre del autor
+    Texto            string     `json:"texto"`
+    Fecha            time.Time  `json:"fecha"`
+    Editado          bool       `json:"editado"`
+    EditadoEn        *time.Time `json:"editado_en"`
+    EstadoModeracion string     `json:"estado_moderacion,omitempty"` // "pendiente" u "oculto" si no es visible
 }
\ No newline at end of file
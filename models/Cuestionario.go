@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PreguntaCuestionario es una pregunta de un cuestionario. Según su tipo, la respuesta correcta está
+// en OpcionCorrecta (opcion_multiple), RespuestaVF (verdadero_falso) o RespuestasAceptadas
+// (respuesta_corta); estos campos no se muestran a quienes responden el cuestionario.
+type PreguntaCuestionario struct {
+	ID                  string   `bson:"id" json:"id"`
+	Tipo                string   `bson:"tipo" json:"tipo"` // opcion_multiple, verdadero_falso o respuesta_corta
+	Enunciado           string   `bson:"enunciado" json:"enunciado"`
+	Opciones            []string `bson:"opciones,omitempty" json:"opciones,omitempty"`
+	Puntos              int      `bson:"puntos" json:"puntos"`
+	OpcionCorrecta      *int     `bson:"opcion_correcta,omitempty" json:"opcion_correcta,omitempty"` // Índice de la opción correcta
+	RespuestaVF         *bool    `bson:"respuesta_vf,omitempty" json:"respuesta_vf,omitempty"`
+	RespuestasAceptadas []string `bson:"respuestas_aceptadas,omitempty" json:"respuestas_aceptadas,omitempty"`
+}
+
+// Cuestionario es una evaluación asociada a una clase o a una unidad.
+type Cuestionario struct {
+	ID                primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	ClaseID           *primitive.ObjectID    `bson:"clase_id,omitempty" json:"clase_id,omitempty"`
+	UnidadID          *primitive.ObjectID    `bson:"unidad_id,omitempty" json:"unidad_id,omitempty"`
+	CursoID           primitive.ObjectID     `bson:"curso_id" json:"curso_id"`
+	Titulo            string                 `bson:"titulo" json:"titulo"`
+	Preguntas         []PreguntaCuestionario `bson:"preguntas" json:"preguntas"`
+	IntentosMaximos   int                    `bson:"intentos_maximos" json:"intentos_maximos"`     // 0 significa intentos ilimitados
+	PuntajeAprobacion float64                `bson:"puntaje_aprobacion" json:"puntaje_aprobacion"` // Porcentaje mínimo para aprobar
+	Requerido         bool                   `bson:"requerido" json:"requerido"`                   // Si es de una clase, aprobarlo es necesario para marcarla como vista
+	Fecha             time.Time              `bson:"fecha" json:"fecha"`
+}
+
+// SinRespuestas devuelve una copia del cuestionario sin las respuestas correctas de sus preguntas.
+func (c Cuestionario) SinRespuestas() Cuestionario {
+	preguntas := make([]PreguntaCuestionario, len(c.Preguntas))
+	for i, pregunta := range c.Preguntas {
+		pregunta.OpcionCorrecta = nil
+		pregunta.RespuestaVF = nil
+		pregunta.RespuestasAceptadas = nil
+		preguntas[i] = pregunta
+	}
+	c.Preguntas = preguntas
+	return c
+}
+
+// RespuestaIntento es la respuesta a una pregunta: Opcion para opcion_multiple, Valor para
+// verdadero_falso y Texto para respuesta_corta.
+type RespuestaIntento struct {
+	PreguntaID string `bson:"pregunta_id" json:"pregunta_id"`
+	Opcion     *int   `bson:"opcion,omitempty" json:"opcion,omitempty"`
+	Valor      *bool  `bson:"valor,omitempty" json:"valor,omitempty"`
+	Texto      string `bson:"texto,omitempty" json:"texto,omitempty"`
+	Correcta   bool   `bson:"correcta" json:"correcta"`
+}
+
+// IntentoCuestionario es un intento corregido de un usuario sobre un cuestionario.
+type IntentoCuestionario struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CuestionarioID primitive.ObjectID `bson:"cuestionario_id" json:"cuestionario_id"`
+	Email          string             `bson:"email" json:"email"`
+	Numero         int                `bson:"numero" json:"numero"`
+	Respuestas     []RespuestaIntento `bson:"respuestas" json:"respuestas"`
+	Puntaje        int                `bson:"puntaje" json:"puntaje"`
+	PuntajeMaximo  int                `bson:"puntaje_maximo" json:"puntaje_maximo"`
+	Porcentaje     float64            `bson:"porcentaje" json:"porcentaje"`
+	Aprobado       bool               `bson:"aprobado" json:"aprobado"`
+	Fecha          time.Time          `bson:"fecha" json:"fecha"`
+}
+
+// ResultadosCuestionario resume los intentos de un usuario sobre un cuestionario.
+type ResultadosCuestionario struct {
+	CuestionarioID    primitive.ObjectID    `json:"cuestionario_id"`
+	Intentos          []IntentoCuestionario `json:"intentos"`
+	MejorPuntaje      int                   `json:"mejor_puntaje"`
+	MejorPorcentaje   float64               `json:"mejor_porcentaje"`
+	Aprobado          bool                  `json:"aprobado"`
+	IntentosRestantes *int                  `json:"intentos_restantes,omitempty"` // Se omite si los intentos son ilimitados
+}
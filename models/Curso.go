@@ -1,6 +1,10 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // Curso representa un curso en la base de datos.
 type Curso struct {
@@ -9,21 +13,76 @@ type Curso struct {
 	Descripcion string               `bson:"descripcion" json:"descripcion"`
 	Imagen      string               `bson:"imagen_url" json:"imagen_url"`
 	Valoracion  float32              `bson:"valoracion" json:"valoracion"`
+	ValoracionPonderada float32      `bson:"valoracion_ponderada" json:"valoracion_ponderada"` // Promedio bayesiano usado para ordenar el catálogo
 	Unidades    []primitive.ObjectID `bson:"unidades" json:"unidades"` // Lista de IDs de unidades
 	Usuarios    int                  `bson:"cant_usuarios" json:"cant_usuarios"`
 	Comentarios []primitive.ObjectID `bson:"comentarios" json:"comentarios"` // Lista de IDs de comentarios
 	Clases int `bson:"cant_clases" json:"cant_clases"`
+	Duracion    int                  `bson:"duracion" json:"duracion"` // Suma de la duración de sus clases en segundos
+	Instructor  string               `bson:"instructor,omitempty" json:"instructor"` // email del instructor del curso
+	Portada     *PortadaCurso        `bson:"portada,omitempty" json:"portada,omitempty"` // Imagen de portada subida y sus miniaturas
+	Estado      string               `bson:"estado,omitempty" json:"estado"` // borrador, publicado o archivado; sin estado se considera publicado
+	PublicacionProgramada *time.Time `bson:"publicacion_programada,omitempty" json:"publicacion_programada,omitempty"`
+	PublicadoEn *time.Time           `bson:"publicado_en,omitempty" json:"publicado_en,omitempty"`
+	Version     int                  `bson:"version,omitempty" json:"version"` // Número de la última versión guardada de su estructura
+}
+
+// Estados del ciclo de vida de un curso. Solo los cursos publicados aparecen en el catálogo.
+const (
+	EstadoCursoBorrador  = "borrador"
+	EstadoCursoPublicado = "publicado"
+	EstadoCursoArchivado = "archivado"
+)
+
+// EstadoActual devuelve el estado del curso. Los cursos creados antes de que existieran los estados
+// no lo tienen guardado y se consideran publicados.
+func (c Curso) EstadoActual() string {
+	if c.Estado == "" {
+		return EstadoCursoPublicado
+	}
+	return c.Estado
+}
+
+// PortadaCurso describe la imagen de portada subida para un curso. Cada subida tiene una versión
+// nueva que forma parte de las URLs, para que los clientes puedan guardarlas en caché sin vencimiento.
+type PortadaCurso struct {
+	Version       string    `bson:"version" json:"version"`
+	TipoContenido string    `bson:"tipo_contenido" json:"tipo_contenido"` // Tipo de la imagen original
+	Ancho         int       `bson:"ancho" json:"ancho"`
+	Alto          int       `bson:"alto" json:"alto"`
+	URL           string    `bson:"url" json:"url"`
+	URLPequena    string    `bson:"url_pequena" json:"url_pequena"`
+	URLMediana    string    `bson:"url_mediana" json:"url_mediana"`
+	Fecha         time.Time `bson:"fecha" json:"fecha"`
 }
 
 // NewCurso crea un nuevo curso con listas vacías por defecto.
-func NewCurso(nombre, descripcion, imagen string, valoracion float32) Curso {
+func NewCurso(nombre, descripcion, imagen string, valoracion float32, instructor string) Curso {
 	return Curso{
 		ID:          primitive.NewObjectID(),
 		Nombre:      nombre,
 		Descripcion: descripcion,
 		Imagen:      imagen,
 		Valoracion:  valoracion,
+		Instructor:  instructor,
 		Unidades:    []primitive.ObjectID{}, // Inicializado como lista vacía
 		Comentarios: []primitive.ObjectID{}, // Inicializado como lista vacía
 	}
 }
+
+// CursoInscrito es un curso en el que el usuario está inscrito, con la cantidad de anuncios que aún no leyó.
+type CursoInscrito struct {
+	Curso            `bson:",inline"`
+	AnunciosNoLeidos int `json:"anuncios_no_leidos"`
+}
+
+// AuditoriaValoracion registra un cambio manual o una reconciliación de la valoración de un curso.
+type AuditoriaValoracion struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CursoID            primitive.ObjectID `bson:"curso_id" json:"curso_id"`
+	Admin              string             `bson:"admin" json:"admin"` // email del administrador
+	ValoracionAnterior float32            `bson:"valoracion_anterior" json:"valoracion_anterior"`
+	ValoracionNueva    float32            `bson:"valoracion_nueva" json:"valoracion_nueva"`
+	Motivo             string             `bson:"motivo" json:"motivo"`
+	Fecha              time.Time          `bson:"fecha" json:"fecha"`
+}
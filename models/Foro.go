@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// CategoriaForo agrupa los hilos del foro de un curso.
+type CategoriaForo struct {
+	ID          string    `json:"id"`
+	CursoID     string    `json:"curso_id"`
+	Nombre      string    `json:"nombre"`
+	Descripcion string    `json:"descripcion"`
+	Fecha       time.Time `json:"fecha"`
+	CantHilos   int       `json:"cant_hilos"`
+}
+
+// HiloForo es un tema de conversación dentro de una categoría del foro. Los hilos fijados se muestran
+// primero y en los bloqueados solo pueden publicar los moderadores del curso.
+type HiloForo struct {
+	ID                string    `json:"id"`
+	CategoriaID       string    `json:"categoria_id"`
+	Titulo            string    `json:"titulo"`
+	Autor             string    `json:"autor"` // email de quien inició el hilo
+	NombreAutor       string    `json:"nombre_autor"`
+	Fecha             time.Time `json:"fecha"`
+	UltimaActividad   time.Time `json:"ultima_actividad"`
+	Fijado            bool      `json:"fijado"`
+	Bloqueado         bool      `json:"bloqueado"`
+	CantPublicaciones int       `json:"cant_publicaciones"`
+}
+
+// PublicacionForo es un mensaje dentro de un hilo del foro. Las publicaciones eliminadas se conservan
+// sin contenido para no alterar la conversación.
+type PublicacionForo struct {
+	ID          string    `json:"id"`
+	HiloID      string    `json:"hilo_id"`
+	Autor       string    `json:"autor"`
+	NombreAutor string    `json:"nombre_autor"`
+	Contenido   string    `json:"contenido"`
+	Fecha       time.Time `json:"fecha"`
+	Eliminada   bool      `json:"eliminada"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ElementoModeracion representa un comentario (de clase o de curso) que espera la revisión de un
+// moderador, ya sea porque lo retuvo el filtro de palabras o porque otros usuarios lo reportaron.
+type ElementoModeracion struct {
+	Tipo         string    `json:"tipo"` // "clase" o "curso"
+	ComentarioID string    `json:"comentario_id"`
+	Autor        string    `json:"autor"`
+	Titulo       string    `json:"titulo,omitempty"` // Solo para comentarios de clase
+	Texto        string    `json:"texto"`
+	Fecha        time.Time `json:"fecha"`
+	Estado       string    `json:"estado"` // "pendiente", "aprobado" u "oculto"
+	Reportes     int       `json:"reportes"`
+	Motivos      []string  `json:"motivos"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Nota es una nota privada que un usuario toma sobre un momento del video de una clase.
+type Nota struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Usuario   string             `bson:"usuario" json:"usuario"` // email del autor
+	CursoID   primitive.ObjectID `bson:"curso_id" json:"curso_id"`
+	ClaseID   primitive.ObjectID `bson:"clase_id" json:"clase_id"`
+	Clase     string             `bson:"-" json:"clase,omitempty"` // Nombre de la clase, al listar las notas de un curso
+	Segundo   int                `bson:"segundo" json:"segundo"`   // Momento del video en segundos
+	Texto     string             `bson:"texto" json:"texto"`
+	Fecha     time.Time          `bson:"fecha" json:"fecha"`
+	EditadaEn *time.Time         `bson:"editada_en,omitempty" json:"editada_en,omitempty"`
+}
+
+// Marcador indica que un usuario guardó una clase para volver a ella. Hay como máximo un marcador
+// por usuario y clase.
+type Marcador struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Usuario string             `bson:"usuario" json:"usuario"`
+	CursoID primitive.ObjectID `bson:"curso_id" json:"curso_id"`
+	ClaseID primitive.ObjectID `bson:"clase_id" json:"clase_id"`
+	Clase   string             `bson:"-" json:"clase,omitempty"`
+	Fecha   time.Time          `bson:"fecha" json:"fecha"`
+}
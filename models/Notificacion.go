@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Notificacion representa una notificación dentro de la aplicación para un usuario.
+type Notificacion struct {
+	ID      string            `json:"id"`
+	Tipo    string            `json:"tipo"` // respuesta, mencion, inscripcion, certificado o anuncio
+	Mensaje string            `json:"mensaje"`
+	Datos   map[string]string `json:"datos,omitempty"` // IDs y nombres relacionados (curso_id, curso, comentario_id, autor...)
+	Fecha   time.Time         `json:"fecha"`
+	Leida   bool              `json:"leida"`
+}
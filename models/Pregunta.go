@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Pregunta representa un comentario de clase marcado como pregunta, junto con su clase y su curso,
+// para las listas de trabajo de los instructores.
+type Pregunta struct {
+	ID                  string    `json:"id"`
+	CursoID             string    `json:"curso_id"`
+	Curso               string    `json:"curso"` // nombre del curso
+	ClaseID             string    `json:"clase_id"`
+	Clase               string    `json:"clase"` // nombre de la clase
+	Autor               string    `json:"autor"` // email del usuario
+	Titulo              string    `json:"titulo"`
+	Detalle             string    `json:"detalle"`
+	Fecha               time.Time `json:"fecha"`
+	CantRespuestas      int       `json:"cant_respuestas"`
+	Respondida          bool      `json:"respondida"` // Tiene una respuesta aceptada o una respuesta del instructor
+	RespuestaAceptadaID string    `json:"respuesta_aceptada_id,omitempty"`
+}
+
+// PreguntasCurso agrupa las preguntas sin responder de un curso en el panel del instructor.
+type PreguntasCurso struct {
+	CursoID      string     `json:"curso_id"`
+	Curso        string     `json:"curso"`
+	SinResponder int        `json:"sin_responder"`
+	Preguntas    []Pregunta `json:"preguntas"`
+}
@@ -7,3 +7,24 @@ type Puntuacion struct {
 	Password string  `json:"password"` // contraseña del usuario
 	Valor    float32 `json:"valor"`
 }
+
+// EstadisticasPuntuacion resume la distribución de las puntuaciones de un curso.
+type EstadisticasPuntuacion struct {
+	CursoID             string  `json:"curso_id"`
+	Total               int     `json:"total"`
+	Promedio            float64 `json:"promedio"`
+	Mediana             float64 `json:"mediana"`
+	DesviacionEstandar  float64 `json:"desviacion_estandar"`
+	ValoracionPonderada float64 `json:"valoracion_ponderada"` // Promedio bayesiano
+	Histograma          []int   `json:"histograma"`           // Cantidad de puntuaciones por estrellas (0 a 5)
+}
+
+// DiferenciaValoracion describe un curso cuya valoración almacenada en MongoDB no coincidía
+// con el promedio de sus puntuaciones en Neo4j.
+type DiferenciaValoracion struct {
+	CursoID              string  `json:"curso_id"`
+	Nombre               string  `json:"nombre"`
+	ValoracionAlmacenada float32 `json:"valoracion_almacenada"`
+	ValoracionCalculada  float32 `json:"valoracion_calculada"`
+	Puntuaciones         int     `json:"puntuaciones"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Resena representa la reseña de un curso: una puntuación acompañada de un texto.
+type Resena struct {
+	ID      string    `json:"id"`
+	CursoID string    `json:"curso_id"`
+	Autor   string    `json:"autor"`        // email del usuario
+	Nombre  string    `json:"nombre_autor"` // nombre del usuario
+	Valor   float64   `json:"valor"`
+	Texto   string    `json:"texto"`
+	Fecha   time.Time `json:"fecha"`
+	Util    int       `json:"util"`    // Votos "útil" de otros usuarios
+	NoUtil  int       `json:"no_util"` // Votos "no útil" de otros usuarios
+}
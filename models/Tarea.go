@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tarea es una actividad de una unidad que los inscritos entregan como archivo antes de una fecha límite.
+type Tarea struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UnidadID      primitive.ObjectID `bson:"unidad_id" json:"unidad_id"`
+	CursoID       primitive.ObjectID `bson:"curso_id" json:"curso_id"`
+	Titulo        string             `bson:"titulo" json:"titulo"`
+	Descripcion   string             `bson:"descripcion" json:"descripcion"`
+	FechaEntrega  time.Time          `bson:"fecha_entrega" json:"fecha_entrega"` // Las entregas posteriores se marcan como atrasadas
+	PuntajeMaximo float64            `bson:"puntaje_maximo" json:"puntaje_maximo"`
+	Fecha         time.Time          `bson:"fecha" json:"fecha"`
+}
+
+// Entrega es el archivo que un usuario entregó para una tarea, con su calificación si ya fue corregida.
+type Entrega struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TareaID       primitive.ObjectID `bson:"tarea_id" json:"tarea_id"`
+	TituloTarea   string             `bson:"-" json:"titulo_tarea,omitempty"`
+	Email         string             `bson:"email" json:"email"`
+	NombreArchivo string             `bson:"nombre_archivo" json:"nombre_archivo"`
+	TipoContenido string             `bson:"tipo_contenido" json:"tipo_contenido"`
+	Tamano        int64              `bson:"tamano" json:"tamano"`
+	Clave         string             `bson:"clave" json:"-"` // Clave del archivo en el almacenamiento
+	Fecha         time.Time          `bson:"fecha" json:"fecha"`
+	Atrasada      bool               `bson:"atrasada" json:"atrasada"`
+	Calificacion  *float64           `bson:"calificacion,omitempty" json:"calificacion,omitempty"`
+	Comentario    string             `bson:"comentario,omitempty" json:"comentario,omitempty"`
+	CalificadaPor string             `bson:"calificada_por,omitempty" json:"calificada_por,omitempty"`
+	CalificadaEn  *time.Time         `bson:"calificada_en,omitempty" json:"calificada_en,omitempty"`
+}
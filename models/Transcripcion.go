@@ -0,0 +1,26 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// SegmentoTranscripcion es un segmento de los subtítulos de una clase indexado para la búsqueda.
+type SegmentoTranscripcion struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	ClaseID  primitive.ObjectID `bson:"clase_id"`
+	CursoID  primitive.ObjectID `bson:"curso_id"`
+	Idioma   string             `bson:"idioma"`
+	Inicio   float64            `bson:"inicio"` // Segundos desde el comienzo del video
+	Fin      float64            `bson:"fin"`
+	Texto    string             `bson:"texto"`    // Texto sin etiquetas de formato
+	Palabras []string           `bson:"palabras"` // Palabras normalizadas del texto, para filtrar la búsqueda
+}
+
+// ResultadoTranscripcion es un segmento de subtítulos en el que aparece la frase buscada.
+type ResultadoTranscripcion struct {
+	ClaseID  string  `json:"clase_id"`
+	Clase    string  `json:"clase"`
+	UnidadID string  `json:"unidad_id"`
+	Idioma   string  `json:"idioma"`
+	Inicio   float64 `json:"inicio"` // Segundos desde el comienzo del video
+	Fin      float64 `json:"fin"`
+	Texto    string  `json:"texto"`
+}
@@ -1,13 +1,30 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // Unidad representa las unidades dentro de un curso.
 type Unidad struct {
-	ID      primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
-	IDcurso primitive.ObjectID   `bson:"idcurso" json:"idcurso"`
-	Nombre  string               `bson:"nombre" json:"nombre"`
-	Clases  []primitive.ObjectID `bson:"clases" json:"clases"`
+	ID           primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	IDcurso      primitive.ObjectID   `bson:"idcurso" json:"idcurso"`
+	Nombre       string               `bson:"nombre" json:"nombre"`
+	Clases       []primitive.ObjectID `bson:"clases" json:"clases"`
+	Duracion     int                  `bson:"duracion" json:"duracion"`                         // Suma de la duración de sus clases en segundos
+	Desbloqueo   *ReglaDesbloqueo     `bson:"desbloqueo,omitempty" json:"desbloqueo,omitempty"` // Sin regla la unidad está siempre disponible
+	Bloqueada    bool                 `bson:"-" json:"bloqueada"`                               // Se calcula para el usuario que consulta
+	DesbloqueaEn *time.Time           `bson:"-" json:"desbloquea_en,omitempty"`                 // Vacío si depende de completar la unidad anterior
+}
+
+// ReglaDesbloqueo indica cuándo se desbloquea una unidad: en una fecha fija ("fecha"), una cantidad de
+// días después de la inscripción del usuario ("dias_inscripcion") o al completar todas las clases de
+// la unidad anterior del curso ("unidad_anterior").
+type ReglaDesbloqueo struct {
+	Tipo  string     `bson:"tipo" json:"tipo"`
+	Fecha *time.Time `bson:"fecha,omitempty" json:"fecha,omitempty"`
+	Dias  int        `bson:"dias,omitempty" json:"dias,omitempty"`
 }
 
 // NewUnidad crea una nueva unidad con listas inicializadas.
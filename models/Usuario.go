@@ -12,6 +12,14 @@ type ProgresoCurso struct {
     Estado    string             `bson:"estado" json:"estado"` // INICIADO, EN CURSO, COMPLETADO
 }
 
+// ProgresoCursoDetallado es el progreso de un usuario en un curso junto con la duración total de sus
+// clases y la de las clases que aún no vio, en segundos.
+type ProgresoCursoDetallado struct {
+    ProgresoCurso  `bson:",inline"`
+    DuracionTotal  int `json:"duracion_total"`
+    TiempoRestante int `json:"tiempo_restante"`
+}
+
 // Usuario representa un usuario que puede inscribirse en cursos
 type Usuario struct {
     Nombre           string               `bson:"nombre" json:"nombre"`
@@ -20,6 +28,7 @@ type Usuario struct {
     Inscritos        []primitive.ObjectID `bson:"inscritos" json:"inscritos"` // IDs de cursos inscritos
     FechaInscripcion []time.Time          `bson:"fecha_inscripcion" json:"fecha_inscripcion"`
     Progresos        []ProgresoCurso      `bson:"progresos" json:"progresos"` // Progreso de los cursos
+    Idioma           string               `bson:"idioma,omitempty" json:"idioma,omitempty"` // Idioma de los correos (es o en)
 }
 
 // NewUsuario crea una nueva instancia de Usuario con listas vacías
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VersionCurso es una instantánea de la estructura de un curso (sus datos, unidades y clases) guardada
+// después de cada cambio. Los adjuntos, subtítulos y comentarios de las clases no forman parte de la
+// instantánea.
+type VersionCurso struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CursoID    primitive.ObjectID `bson:"curso_id" json:"curso_id"`
+	Numero     int                `bson:"numero" json:"numero"`
+	Motivo     string             `bson:"motivo" json:"motivo"`                   // Cambio que generó la versión
+	Autor      string             `bson:"autor,omitempty" json:"autor,omitempty"` // email de quien hizo el cambio, si se conoce
+	Fecha      time.Time          `bson:"fecha" json:"fecha"`
+	Estructura *EstructuraCurso   `bson:"estructura,omitempty" json:"estructura,omitempty"` // Se omite al listar las versiones
+}
+
+// EstructuraCurso son los datos de un curso con sus unidades en orden. La portada no forma parte de
+// la estructura: sus archivos se reemplazan al subir una nueva y restaurar una versión no la cambia.
+type EstructuraCurso struct {
+	Nombre      string             `bson:"nombre" json:"nombre"`
+	Descripcion string             `bson:"descripcion" json:"descripcion"`
+	Unidades    []EstructuraUnidad `bson:"unidades" json:"unidades"`
+}
+
+// EstructuraUnidad es una unidad de la instantánea con sus clases en orden.
+type EstructuraUnidad struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	Nombre     string             `bson:"nombre" json:"nombre"`
+	Desbloqueo *ReglaDesbloqueo   `bson:"desbloqueo,omitempty" json:"desbloqueo,omitempty"`
+	Clases     []EstructuraClase  `bson:"clases" json:"clases"`
+}
+
+// EstructuraClase es una clase de la instantánea.
+type EstructuraClase struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	Nombre      string             `bson:"nombre" json:"nombre"`
+	Descripcion string             `bson:"descripcion" json:"descripcion"`
+	VideoURL    string             `bson:"video_url" json:"video_url"`
+	Duracion    int                `bson:"duracion" json:"duracion"`
+}
+
+// DiferenciaVersiones son los cambios en la estructura de un curso entre dos versiones.
+type DiferenciaVersiones struct {
+	Desde   int             `json:"desde"`
+	Hasta   int             `json:"hasta"`
+	Cambios []CambioVersion `json:"cambios"`
+}
+
+// CambioVersion describe un elemento del curso que se agregó, se eliminó o se modificó entre dos
+// versiones.
+type CambioVersion struct {
+	Elemento string            `json:"elemento"`     // curso, unidad o clase
+	Tipo     string            `json:"tipo"`         // agregado, eliminado o modificado
+	ID       string            `json:"id,omitempty"` // Vacío para los datos del curso
+	Nombre   string            `json:"nombre"`
+	Unidad   string            `json:"unidad,omitempty"` // Nombre de la unidad, para las clases
+	Campos   []CampoModificado `json:"campos,omitempty"` // Solo para los elementos modificados
+}
+
+// CampoModificado es un campo cuyo valor cambió entre dos versiones.
+type CampoModificado struct {
+	Campo   string      `json:"campo"`
+	Antes   interface{} `json:"antes"`
+	Despues interface{} `json:"despues"`
+}
@@ -1,15 +1,24 @@
 package request
 
+import "time"
+
 // CreateCursoRequest define el cuerpo de la solicitud para crear un curso.
 type CreateCursoRequest struct {
+    Email       string `json:"email" binding:"required"`
+    Password    string `json:"password" binding:"required"`
     Nombre      string `json:"nombre" binding:"required"`
     Descripcion string `json:"descripcion"`
     Imagen      string `json:"imagen_url"`
+    Instructor  string `json:"instructor"` // email del instructor (opcional, solo administradores)
 }
 
 // UpdateValoracionRequest define el cuerpo de la solicitud para actualizar la valoración.
+// Solo los administradores pueden modificarla y deben indicar el motivo del cambio.
 type UpdateValoracionRequest struct {
-    Valoracion float32 `json:"valoracion" binding:"required"`
+    Email      string   `json:"email" binding:"required"`
+    Password   string   `json:"password" binding:"required"`
+    Valoracion *float32 `json:"valoracion" binding:"required"`
+    Motivo     string   `json:"motivo" binding:"required"`
 }
 
 // CreateUnidadRequest define el cuerpo de la solicitud para crear una unidad.
@@ -22,15 +31,28 @@ type CreateClaseRequest struct {
     Nombre      string `json:"nombre" binding:"required"`
     Descripcion string `json:"descripcion" binding:"required"`
     VideoURL    string `json:"video_url" binding:"required"`
+    Duracion    int    `json:"duracion" binding:"min=0"` // Duración del video en segundos
+}
+
+// UpdateClaseRequest define los parámetros necesarios para editar una clase.
+type UpdateClaseRequest struct {
+    Email       string `json:"email" binding:"required"`
+    Password    string `json:"password" binding:"required"`
+    Nombre      string `json:"nombre" binding:"required"`
+    Descripcion string `json:"descripcion" binding:"required"`
+    VideoURL    string `json:"video_url" binding:"required"`
+    Duracion    int    `json:"duracion" binding:"min=0"` // Duración del video en segundos
 }
 
 // CreateComentarioRequest define los parámetros necesarios para crear un comentario.
+// Los contadores de "me gusta" se calculan a partir de las reacciones de los usuarios.
 type CreateComentarioRequest struct {
-    Autor     string `json:"autor" binding:"required"`
-    Titulo    string `json:"titulo" binding:"required"`
-    Detalle   string `json:"detalle" binding:"required"`
-    MeGusta   int    `json:"me_gusta" binding:"required"`
-    NoMeGusta int    `json:"no_me_gusta" binding:"required"`
+    Autor    string `json:"autor" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Titulo   string `json:"titulo" binding:"required"`
+    Detalle  string `json:"detalle" binding:"required"`
+    PadreID  string `json:"padre_id"` // ID del comentario al que responde (opcional)
+    EsPregunta bool `json:"es_pregunta"` // Marca el comentario como pregunta para el instructor (opcional)
 }
 
 // InscripcionRequest define los parámetros necesarios para inscribir a un usuario en un curso.
@@ -45,4 +67,241 @@ type CreateUsuarioRequest struct {
     Nombre   string `json:"nombre" binding:"required"`
     Email    string `json:"email" binding:"required,email"`
     Password string `json:"password" binding:"required"`
-}
\ No newline at end of file
+    Idioma   string `json:"idioma" binding:"omitempty,oneof=es en"` // Idioma de los correos (es por defecto)
+}
+
+// CreateResenaRequest define los parámetros necesarios para crear una reseña de un curso.
+type CreateResenaRequest struct {
+    Email    string  `json:"email" binding:"required"`
+    Password string  `json:"password" binding:"required"`
+    Valor    float32 `json:"valor"`
+    Texto    string  `json:"texto" binding:"required"`
+}
+
+// VotoResenaRequest define los parámetros necesarios para votar la utilidad de una reseña.
+type VotoResenaRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Util     *bool  `json:"util" binding:"required"`
+}
+
+// CredencialesRequest define las credenciales de un usuario para operaciones que solo requieren autenticación.
+type CredencialesRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+}
+
+// ReaccionRequest define los parámetros necesarios para reaccionar a una clase o a un comentario.
+type ReaccionRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Tipo     string `json:"tipo" binding:"required,oneof=me_gusta no_me_gusta"`
+}
+
+// UpdateComentarioRequest define los parámetros necesarios para editar un comentario de clase.
+type UpdateComentarioRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Titulo   string `json:"titulo" binding:"required"`
+    Detalle  string `json:"detalle" binding:"required"`
+}
+
+// UpdateComentarioCursoRequest define los parámetros necesarios para editar un comentario de curso.
+type UpdateComentarioCursoRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Texto    string `json:"texto" binding:"required"`
+}
+
+// ReporteComentarioRequest define los parámetros necesarios para reportar un comentario de clase o de curso.
+type ReporteComentarioRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Motivo   string `json:"motivo" binding:"required"`
+}
+
+// ModeracionRequest define los parámetros necesarios para que un moderador resuelva un comentario.
+type ModeracionRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Accion   string `json:"accion" binding:"required,oneof=aprobar ocultar eliminar"`
+}
+
+// CreateComentarioCursoRequest define los parámetros necesarios para crear un comentario de curso.
+type CreateComentarioCursoRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    CursoID  string `json:"curso_id" binding:"required"`
+    Texto    string `json:"texto" binding:"required"`
+}
+
+// AsignarInstructorRequest define los parámetros necesarios para asignar el instructor de un curso.
+type AsignarInstructorRequest struct {
+    Email      string `json:"email" binding:"required"`
+    Password   string `json:"password" binding:"required"`
+    Instructor string `json:"instructor" binding:"required,email"`
+}
+
+// AceptarRespuestaRequest define los parámetros necesarios para marcar la respuesta aceptada de una pregunta.
+type AceptarRespuestaRequest struct {
+    Email       string `json:"email" binding:"required"`
+    Password    string `json:"password" binding:"required"`
+    RespuestaID string `json:"respuesta_id" binding:"required"`
+}
+
+// MarcarNotificacionesLeidasRequest define los parámetros necesarios para marcar notificaciones como leídas.
+// Si no se indican IDs se marcan todas.
+type MarcarNotificacionesLeidasRequest struct {
+    Email    string   `json:"email" binding:"required"`
+    Password string   `json:"password" binding:"required"`
+    IDs      []string `json:"ids"`
+}
+
+// PreferenciasNotificacionRequest define los parámetros necesarios para activar o desactivar tipos de
+// notificación (respuesta, mencion, inscripcion, certificado, anuncio).
+type PreferenciasNotificacionRequest struct {
+    Email        string          `json:"email" binding:"required"`
+    Password     string          `json:"password" binding:"required"`
+    Preferencias map[string]bool `json:"preferencias" binding:"required"`
+}
+
+// SolicitudRestablecimientoRequest define los parámetros necesarios para pedir el restablecimiento de la contraseña.
+type SolicitudRestablecimientoRequest struct {
+    Email string `json:"email" binding:"required,email"`
+}
+
+// RestablecerPasswordRequest define los parámetros necesarios para elegir una nueva contraseña con el código recibido por correo.
+type RestablecerPasswordRequest struct {
+    Token    string `json:"token" binding:"required"`
+    Password string `json:"password" binding:"required"`
+}
+
+// AnuncioRequest define los parámetros necesarios para publicar o editar el anuncio de un curso.
+type AnuncioRequest struct {
+    Email     string `json:"email" binding:"required"`
+    Password  string `json:"password" binding:"required"`
+    Titulo    string `json:"titulo" binding:"required"`
+    Contenido string `json:"contenido" binding:"required"`
+}
+
+// MarcarAnunciosLeidosRequest define los parámetros necesarios para marcar anuncios como leídos.
+// Si no se indican IDs se marcan todos los de los cursos inscritos.
+type MarcarAnunciosLeidosRequest struct {
+    Email    string   `json:"email" binding:"required"`
+    Password string   `json:"password" binding:"required"`
+    IDs      []string `json:"ids"`
+}
+
+// CreateCategoriaForoRequest define los parámetros necesarios para crear una categoría en el foro de un curso.
+type CreateCategoriaForoRequest struct {
+    Email       string `json:"email" binding:"required"`
+    Password    string `json:"password" binding:"required"`
+    Nombre      string `json:"nombre" binding:"required"`
+    Descripcion string `json:"descripcion"`
+}
+
+// CreateHiloForoRequest define los parámetros necesarios para iniciar un hilo en el foro con su primera publicación.
+type CreateHiloForoRequest struct {
+    Email     string `json:"email" binding:"required"`
+    Password  string `json:"password" binding:"required"`
+    Titulo    string `json:"titulo" binding:"required"`
+    Contenido string `json:"contenido" binding:"required"`
+}
+
+// CreatePublicacionForoRequest define los parámetros necesarios para publicar en un hilo del foro.
+type CreatePublicacionForoRequest struct {
+    Email     string `json:"email" binding:"required"`
+    Password  string `json:"password" binding:"required"`
+    Contenido string `json:"contenido" binding:"required"`
+}
+
+// EstadoHiloForoRequest define los parámetros necesarios para fijar o bloquear un hilo. Los campos omitidos no se modifican.
+type EstadoHiloForoRequest struct {
+    Email     string `json:"email" binding:"required"`
+    Password  string `json:"password" binding:"required"`
+    Fijado    *bool  `json:"fijado"`
+    Bloqueado *bool  `json:"bloqueado"`
+}
+
+// PreguntaCuestionarioRequest define una pregunta al crear un cuestionario. Según el tipo se indica la
+// respuesta correcta en opcion_correcta (índice de opciones), respuesta_vf o respuestas_aceptadas.
+type PreguntaCuestionarioRequest struct {
+    Tipo                string   `json:"tipo" binding:"required,oneof=opcion_multiple verdadero_falso respuesta_corta"`
+    Enunciado           string   `json:"enunciado" binding:"required"`
+    Opciones            []string `json:"opciones"`
+    Puntos              int      `json:"puntos"` // 1 por defecto
+    OpcionCorrecta      *int     `json:"opcion_correcta"`
+    RespuestaVF         *bool    `json:"respuesta_vf"`
+    RespuestasAceptadas []string `json:"respuestas_aceptadas"`
+}
+
+// CreateCuestionarioRequest define los parámetros necesarios para crear el cuestionario de una clase o una unidad.
+type CreateCuestionarioRequest struct {
+    Email             string                        `json:"email" binding:"required"`
+    Password          string                        `json:"password" binding:"required"`
+    Titulo            string                        `json:"titulo" binding:"required"`
+    Preguntas         []PreguntaCuestionarioRequest `json:"preguntas" binding:"required,min=1,dive"`
+    IntentosMaximos   int                           `json:"intentos_maximos" binding:"min=0"`                       // 0 para intentos ilimitados
+    PuntajeAprobacion *float64                      `json:"puntaje_aprobacion" binding:"omitempty,min=0,max=100"` // 60 por defecto
+    Requerido         bool                          `json:"requerido"`                                              // Solo para cuestionarios de clase
+}
+
+// RespuestaCuestionarioRequest define la respuesta a una pregunta de un cuestionario: opcion para
+// opcion_multiple, valor para verdadero_falso y texto para respuesta_corta.
+type RespuestaCuestionarioRequest struct {
+    PreguntaID string `json:"pregunta_id" binding:"required"`
+    Opcion     *int   `json:"opcion"`
+    Valor      *bool  `json:"valor"`
+    Texto      string `json:"texto"`
+}
+
+// IntentoCuestionarioRequest define los parámetros necesarios para enviar un intento de un cuestionario.
+type IntentoCuestionarioRequest struct {
+    Email      string                         `json:"email" binding:"required"`
+    Password   string                         `json:"password" binding:"required"`
+    Respuestas []RespuestaCuestionarioRequest `json:"respuestas" binding:"dive"`
+}
+
+// CreateTareaRequest define los parámetros necesarios para crear la tarea de una unidad.
+type CreateTareaRequest struct {
+    Email         string    `json:"email" binding:"required"`
+    Password      string    `json:"password" binding:"required"`
+    Titulo        string    `json:"titulo" binding:"required"`
+    Descripcion   string    `json:"descripcion"`
+    FechaEntrega  time.Time `json:"fecha_entrega" binding:"required"`
+    PuntajeMaximo float64   `json:"puntaje_maximo" binding:"required,gt=0"`
+}
+
+// CalificarEntregaRequest define los parámetros necesarios para calificar la entrega de una tarea.
+type CalificarEntregaRequest struct {
+    Email        string   `json:"email" binding:"required"`
+    Password     string   `json:"password" binding:"required"`
+    Calificacion *float64 `json:"calificacion" binding:"required"`
+    Comentario   string   `json:"comentario"`
+}
+
+// DesbloqueoUnidadRequest define la regla de desbloqueo de una unidad. La fecha se usa con el tipo
+// "fecha" y los días con el tipo "dias_inscripcion".
+type DesbloqueoUnidadRequest struct {
+    Email    string     `json:"email" binding:"required"`
+    Password string     `json:"password" binding:"required"`
+    Tipo     string     `json:"tipo" binding:"required,oneof=fecha dias_inscripcion unidad_anterior"`
+    Fecha    *time.Time `json:"fecha"`
+    Dias     int        `json:"dias" binding:"min=0"`
+}
+
+// NotaRequest define los parámetros necesarios para crear o editar una nota sobre una clase.
+type NotaRequest struct {
+    Email    string `json:"email" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Segundo  int    `json:"segundo" binding:"min=0"` // Momento del video en segundos
+    Texto    string `json:"texto" binding:"required"`
+}
+
+// PublicarCursoRequest define los parámetros para publicar un curso. Si la fecha es futura la
+// publicación se programa para esa fecha.
+type PublicarCursoRequest struct {
+    Email    string     `json:"email" binding:"required"`
+    Password string     `json:"password" binding:"required"`
+    Fecha    *time.Time `json:"fecha"`
+}
@@ -12,9 +12,17 @@ type CursoResponse struct {
     Descripcion string   `json:"descripcion"`
     Imagen      string   `json:"imagen_url"`
     Valoracion  float32  `json:"valoracion"`
+    ValoracionPonderada float32 `json:"valoracion_ponderada"`
     Unidades    []string `json:"unidades"` // IDs de las unidades
     Usuarios    int      `json:"cant_usuarios"`
     Comentarios []string `json:"comentarios"` // IDs de los comentarios
+    Instructor  string   `json:"instructor"`  // email del instructor
+    Clases      int      `json:"cant_clases"`
+    Duracion    int      `json:"duracion"` // Suma de la duración de las clases en segundos
+    MiniaturaPequena string `json:"miniatura_pequena_url,omitempty"` // Solo si el curso tiene portada subida
+    MiniaturaMediana string `json:"miniatura_mediana_url,omitempty"`
+    Estado      string   `json:"estado"` // borrador, publicado o archivado
+    PublicacionProgramada *time.Time `json:"publicacion_programada,omitempty"`
 }
 
 // NewCursoResponse convierte un modelo Curso en una respuesta CursoResponse.
@@ -29,16 +37,27 @@ func NewCursoResponse(curso models.Curso) CursoResponse {
         comentarios[i] = comentario.Hex()
     }
 
-    return CursoResponse{
+    respuesta := CursoResponse{
         ID:          curso.ID.Hex(),
         Nombre:      curso.Nombre,
         Descripcion: curso.Descripcion,
         Imagen:      curso.Imagen,
         Valoracion:  curso.Valoracion,
+        ValoracionPonderada: curso.ValoracionPonderada,
         Unidades:    unidades,
         Usuarios:    curso.Usuarios,
         Comentarios: comentarios,
+        Instructor:  curso.Instructor,
+        Clases:      curso.Clases,
+        Duracion:    curso.Duracion,
+        Estado:      curso.EstadoActual(),
+        PublicacionProgramada: curso.PublicacionProgramada,
     }
+    if curso.Portada != nil {
+        respuesta.MiniaturaPequena = curso.Portada.URLPequena
+        respuesta.MiniaturaMediana = curso.Portada.URLMediana
+    }
+    return respuesta
 }
 
 // ErrorResponse define la estructura de las respuestas de error.
@@ -59,10 +78,11 @@ type UpdateValoracionResponse struct {
 
 // UnidadResponse define la estructura de la respuesta para una unidad.
 type UnidadResponse struct {
-    ID      string   `json:"id"`
-    IDcurso string   `json:"idcurso"`
-    Nombre  string   `json:"nombre"`
-    Clases  []string `json:"clases"` // IDs de las clases en formato string
+    ID       string   `json:"id"`
+    IDcurso  string   `json:"idcurso"`
+    Nombre   string   `json:"nombre"`
+    Clases   []string `json:"clases"`   // IDs de las clases en formato string
+    Duracion int      `json:"duracion"` // Suma de la duración de las clases en segundos
 }
 
 // NewUnidadResponse convierte un modelo Unidad en una respuesta UnidadResponse.
@@ -73,10 +93,11 @@ func NewUnidadResponse(unidad models.Unidad) UnidadResponse {
     }
 
     return UnidadResponse{
-        ID:      unidad.ID.Hex(),
-        IDcurso: unidad.IDcurso.Hex(),
-        Nombre:  unidad.Nombre,
-        Clases:  clases,
+        ID:       unidad.ID.Hex(),
+        IDcurso:  unidad.IDcurso.Hex(),
+        Nombre:   unidad.Nombre,
+        Clases:   clases,
+        Duracion: unidad.Duracion,
     }
 }
 
@@ -87,15 +108,20 @@ type CrearUnidad struct {
 
 // ClaseResponse define la estructura de la respuesta para una clase.
 type ClaseResponse struct {
-    ID           string   `json:"id"`
-    UnidadID     string   `json:"unidad_id"`
-    Nombre       string   `json:"nombre"`
-    Descripcion  string   `json:"descripcion"`
-    VideoURL     string   `json:"video_url"`
-    Adjuntos_url []string `json:"adjuntos_url"`
-    MeGusta      int      `json:"me_gusta"`
-    NoMeGusta    int      `json:"no_me_gusta"`
-    Comentarios  []string `json:"comentarios"`
+    ID           string                   `json:"id"`
+    UnidadID     string                   `json:"unidad_id"`
+    Nombre       string                   `json:"nombre"`
+    Descripcion  string                   `json:"descripcion"`
+    VideoURL     string                   `json:"video_url"`
+    Duracion     int                      `json:"duracion"` // Duración del video en segundos
+    Adjuntos_url []string                 `json:"adjuntos_url"`
+    Adjuntos     []models.Adjunto         `json:"adjuntos"`
+    Subtitulos   []models.PistaSubtitulos `json:"subtitulos"`
+    MeGusta      int                      `json:"me_gusta"`
+    NoMeGusta    int                      `json:"no_me_gusta"`
+    Comentarios  []string                 `json:"comentarios"`
+    Bloqueada    bool                     `json:"bloqueada"` // Sin video, adjuntos ni subtítulos hasta que se desbloquee
+    DesbloqueaEn *time.Time               `json:"desbloquea_en,omitempty"`
 }
 
 // NewClaseResponse convierte un modelo Clase en una respuesta ClaseResponse.
@@ -111,10 +137,15 @@ func NewClaseResponse(clase models.Clase) ClaseResponse {
         Nombre:       clase.Nombre,
         Descripcion:  clase.Descripcion,
         VideoURL:     clase.VideoURL,
+        Duracion:     clase.Duracion,
         Adjuntos_url: clase.Adjuntos_url,
+        Adjuntos:     clase.Adjuntos,
+        Subtitulos:   clase.Subtitulos,
         MeGusta:      clase.MeGusta,
         NoMeGusta:    clase.NoMeGusta,
         Comentarios:  comentarios,
+        Bloqueada:    clase.Bloqueada,
+        DesbloqueaEn: clase.DesbloqueaEn,
     }
 }
 
@@ -167,4 +198,55 @@ type MessageResponse struct {
 
     Message string `json:"message"`
 
-}
\ No newline at end of file
+}
+
+// ResenasPaginadasResponse define la estructura de la respuesta para una página de reseñas.
+type ResenasPaginadasResponse struct {
+    Resenas []models.Resena `json:"resenas"`
+    Pagina  int             `json:"pagina"`
+    Limite  int             `json:"limite"`
+    Total   int             `json:"total"`
+}
+
+// ReaccionResponse define la estructura de la respuesta al reaccionar a una clase o a un comentario.
+type ReaccionResponse struct {
+    Reaccion  string `json:"reaccion"` // Reacción vigente del usuario ("" si la quitó)
+    MeGusta   int    `json:"me_gusta"`
+    NoMeGusta int    `json:"no_me_gusta"`
+}
+
+// ComentariosCursoPaginadosResponse define la estructura de la respuesta para una página de comentarios de un curso.
+type ComentariosCursoPaginadosResponse struct {
+    Comentarios     []models.ComentarioCurso `json:"comentarios"`
+    Limite          int                      `json:"limite"`
+    SiguienteCursor string                   `json:"siguiente_cursor"` // Vacío si no hay más comentarios
+}
+
+// PanelInstructorResponse define la estructura del panel de preguntas sin responder de un instructor.
+type PanelInstructorResponse struct {
+    TotalSinResponder int                     `json:"total_sin_responder"`
+    Cursos            []models.PreguntasCurso `json:"cursos"`
+}
+
+
+// NotificacionesNoLeidasResponse define la estructura de la respuesta con la cantidad de notificaciones sin leer.
+type NotificacionesNoLeidasResponse struct {
+    NoLeidas int `json:"no_leidas"`
+}
+
+// HilosForoPaginadosResponse define la estructura de la respuesta para una página de hilos de una categoría del foro.
+type HilosForoPaginadosResponse struct {
+    Hilos  []models.HiloForo `json:"hilos"`
+    Pagina int               `json:"pagina"`
+    Limite int               `json:"limite"`
+    Total  int               `json:"total"`
+}
+
+// HiloForoResponse define la estructura de la respuesta para un hilo del foro con una página de sus publicaciones.
+type HiloForoResponse struct {
+    Hilo          models.HiloForo          `json:"hilo"`
+    Publicaciones []models.PublicacionForo `json:"publicaciones"`
+    Pagina        int                      `json:"pagina"`
+    Limite        int                      `json:"limite"`
+    Total         int                      `json:"total"`
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-API/almacenamiento"
+	"go-API/models"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MaxTamanoAdjunto es el tamaño máximo en bytes de un adjunto de clase.
+const MaxTamanoAdjunto = 50 << 20
+
+// tiposAdjunto son las extensiones permitidas para los adjuntos con el tipo de contenido con el que
+// se sirven. El tipo se deduce de la extensión para no depender del que declara el cliente.
+var tiposAdjunto = map[string]string{
+	".pdf":  "application/pdf",
+	".txt":  "text/plain; charset=utf-8",
+	".md":   "text/markdown; charset=utf-8",
+	".csv":  "text/csv; charset=utf-8",
+	".zip":  "application/zip",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xls":  "application/vnd.ms-excel",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".ppt":  "application/vnd.ms-powerpoint",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// AdjuntoService gestiona los archivos adjuntos de las clases. Los archivos se guardan en el
+// almacenamiento bajo la clave adjuntos/<clase>/<adjunto> y sus datos en la propia clase, junto con
+// su URL de descarga en Adjuntos_url.
+type AdjuntoService struct {
+	ClaseCollection  *mongo.Collection
+	UnidadCollection *mongo.Collection
+	CursoCollection  *mongo.Collection
+	RedisClient      *redis.Client
+	Almacenamiento   almacenamiento.Almacenamiento
+}
+
+func NewAdjuntoService(db *mongo.Database, redisClient *redis.Client, almacen almacenamiento.Almacenamiento) *AdjuntoService {
+	return &AdjuntoService{
+		ClaseCollection:  db.Collection("clases"),
+		UnidadCollection: db.Collection("unidades"),
+		CursoCollection:  db.Collection("cursos"),
+		RedisClient:      redisClient,
+		Almacenamiento:   almacen,
+	}
+}
+
+const errorInstructorAdjuntos = "solo el instructor del curso o un administrador puede gestionar los adjuntos"
+
+// urlAdjunto devuelve la ruta de descarga de un adjunto.
+func urlAdjunto(claseID, adjuntoID primitive.ObjectID) string {
+	return "/api/clases/" + claseID.Hex() + "/adjuntos/" + adjuntoID.Hex()
+}
+
+// SubirAdjunto guarda un archivo como adjunto de una clase y calcula su suma SHA-256. Solo el
+// instructor del curso o un administrador puede hacerlo.
+func (s *AdjuntoService) SubirAdjunto(ctx context.Context, claseID, email, password, nombre string, tamano int64, contenido io.Reader) (*models.Adjunto, error) {
+	if tamano > MaxTamanoAdjunto {
+		return nil, fmt.Errorf("el archivo supera el tamaño máximo de %d MB", MaxTamanoAdjunto>>20)
+	}
+	tipoContenido, ok := tiposAdjunto[strings.ToLower(filepath.Ext(nombre))]
+	if !ok {
+		return nil, errors.New("tipo de archivo no permitido")
+	}
+
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorAdjuntos); err != nil {
+		return nil, err
+	}
+
+	adjunto := &models.Adjunto{
+		ID:            primitive.NewObjectID(),
+		Nombre:        filepath.Base(nombre),
+		TipoContenido: tipoContenido,
+		SubidoPor:     email,
+		Fecha:         time.Now(),
+	}
+	adjunto.Clave = "adjuntos/" + clase.ID.Hex() + "/" + adjunto.ID.Hex()
+	adjunto.URL = urlAdjunto(clase.ID, adjunto.ID)
+
+	// La suma se calcula mientras se escribe; se limita la lectura por si el tamaño declarado no coincide
+	hash := sha256.New()
+	escritos, err := s.Almacenamiento.Guardar(ctx, adjunto.Clave, io.TeeReader(io.LimitReader(contenido, MaxTamanoAdjunto+1), hash))
+	if err != nil {
+		return nil, err
+	}
+	if escritos > MaxTamanoAdjunto {
+		eliminarArchivo(ctx, s.Almacenamiento, adjunto.Clave)
+		return nil, fmt.Errorf("el archivo supera el tamaño máximo de %d MB", MaxTamanoAdjunto>>20)
+	}
+	adjunto.Tamano = escritos
+	adjunto.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	_, err = s.ClaseCollection.UpdateOne(ctx, bson.M{"_id": clase.ID}, bson.M{"$push": bson.M{
+		"adjuntos":     adjunto,
+		"adjuntos_url": adjunto.URL,
+	}})
+	if err != nil {
+		eliminarArchivo(ctx, s.Almacenamiento, adjunto.Clave)
+		return nil, err
+	}
+
+	return adjunto, nil
+}
+
+// AbrirAdjunto devuelve el contenido de un adjunto. Pueden descargarlo los inscritos en el curso, si la
+// unidad de la clase no está bloqueada para ellos, su instructor y los administradores.
+func (s *AdjuntoService) AbrirAdjunto(ctx context.Context, claseID, adjuntoID, email, password string) (*models.Adjunto, io.ReadCloser, error) {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	adjunto, err := buscarAdjunto(clase, adjuntoID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usuario, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID.Hex())
+	if err != nil {
+		if err.Error() != "el usuario no está inscrito en este curso" {
+			return nil, nil, err
+		}
+		if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorAdjuntos); err != nil {
+			return nil, nil, errors.New("el usuario no está inscrito en este curso")
+		}
+	} else {
+		estado, err := bloqueoUnidad(ctx, s.CursoCollection, s.UnidadCollection, cursoID, clase.UnidadID, usuario)
+		if err != nil {
+			return nil, nil, err
+		}
+		if estado.bloqueada {
+			return nil, nil, errorClaseBloqueada(estado)
+		}
+	}
+
+	archivo, err := s.Almacenamiento.Abrir(ctx, adjunto.Clave)
+	if err == almacenamiento.ErrNoEncontrado {
+		return nil, nil, errors.New("adjunto no encontrado")
+	} else if err != nil {
+		return nil, nil, err
+	}
+	return adjunto, archivo, nil
+}
+
+// EliminarAdjunto quita un adjunto de la clase y borra su archivo. Solo el instructor del curso o un
+// administrador puede hacerlo.
+func (s *AdjuntoService) EliminarAdjunto(ctx context.Context, claseID, adjuntoID, email, password string) error {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return err
+	}
+	adjunto, err := buscarAdjunto(clase, adjuntoID)
+	if err != nil {
+		return err
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorAdjuntos); err != nil {
+		return err
+	}
+
+	_, err = s.ClaseCollection.UpdateOne(ctx, bson.M{"_id": clase.ID}, bson.M{"$pull": bson.M{
+		"adjuntos":     bson.M{"_id": adjunto.ID},
+		"adjuntos_url": adjunto.URL,
+	}})
+	if err != nil {
+		return err
+	}
+
+	eliminarArchivo(ctx, s.Almacenamiento, adjunto.Clave)
+	return nil
+}
+
+// obtenerClaseYCurso obtiene una clase y el ID del curso al que pertenece. Las clases que una
+// restauración de versión dejó fuera del curso se tratan como inexistentes.
+func obtenerClaseYCurso(ctx context.Context, clases, unidades *mongo.Collection, id string) (*models.Clase, primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, primitive.NilObjectID, errors.New("ID de clase inválido")
+	}
+
+	var clase models.Clase
+	if err := clases.FindOne(ctx, bson.M{"_id": objectID}).Decode(&clase); err == mongo.ErrNoDocuments {
+		return nil, primitive.NilObjectID, errors.New("clase no encontrada")
+	} else if err != nil {
+		return nil, primitive.NilObjectID, err
+	}
+	if clase.UnidadID.IsZero() {
+		return nil, primitive.NilObjectID, errors.New("clase no encontrada")
+	}
+
+	var unidad models.Unidad
+	if err := unidades.FindOne(ctx, bson.M{"_id": clase.UnidadID}).Decode(&unidad); err == mongo.ErrNoDocuments {
+		return nil, primitive.NilObjectID, errors.New("unidad no encontrada")
+	} else if err != nil {
+		return nil, primitive.NilObjectID, err
+	}
+	if unidad.IDcurso.IsZero() {
+		return nil, primitive.NilObjectID, errors.New("clase no encontrada")
+	}
+
+	return &clase, unidad.IDcurso, nil
+}
+
+// verificarInstructorCurso verifica las credenciales y que el usuario sea el instructor del curso o un
+// administrador. Si no lo es devuelve un error con el mensaje indicado.
+func verificarInstructorCurso(ctx context.Context, redisClient *redis.Client, cursos *mongo.Collection, cursoID primitive.ObjectID, email, password, mensaje string) error {
+	if _, err := obtenerUsuarioRedis(ctx, redisClient, email, password); err != nil {
+		return err
+	}
+
+	var curso models.Curso
+	if err := cursos.FindOne(ctx, bson.M{"_id": cursoID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return errors.New("curso no encontrado")
+	} else if err != nil {
+		return err
+	}
+
+	if curso.Instructor != email && !esAdmin(email) {
+		return errors.New(mensaje)
+	}
+	return nil
+}
+
+func buscarAdjunto(clase *models.Clase, id string) (*models.Adjunto, error) {
+	for i := range clase.Adjuntos {
+		if clase.Adjuntos[i].ID.Hex() == id {
+			return &clase.Adjuntos[i], nil
+		}
+	}
+	return nil, errors.New("adjunto no encontrado")
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-API/models"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AnuncioService gestiona los anuncios que los instructores publican en sus cursos. Los anuncios se
+// guardan en MongoDB y los IDs de los ya leídos por cada usuario en un conjunto de Redis
+// (anuncios:leidos:<email>). Al publicar un anuncio se notifica a todos los inscritos en el curso.
+type AnuncioService struct {
+	AnuncioCollection *mongo.Collection
+	CursoCollection   *mongo.Collection
+	RedisClient       *redis.Client
+	Notificaciones    *NotificacionService
+}
+
+func NewAnuncioService(anuncioCollection, cursoCollection *mongo.Collection, redisClient *redis.Client, notificaciones *NotificacionService) *AnuncioService {
+	return &AnuncioService{
+		AnuncioCollection: anuncioCollection,
+		CursoCollection:   cursoCollection,
+		RedisClient:       redisClient,
+		Notificaciones:    notificaciones,
+	}
+}
+
+func claveAnunciosLeidos(email string) string {
+	return "anuncios:leidos:" + email
+}
+
+// CrearAnuncio publica un anuncio en un curso. Solo el instructor del curso o un administrador puede
+// hacerlo.
+func (s *AnuncioService) CrearAnuncio(ctx context.Context, cursoID, email, password, titulo, contenido string) (*models.Anuncio, error) {
+	curso, err := s.obtenerCursoEditable(ctx, cursoID, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	anuncio := &models.Anuncio{
+		ID:        primitive.NewObjectID(),
+		CursoID:   curso.ID,
+		Autor:     email,
+		Titulo:    titulo,
+		Contenido: contenido,
+		Fecha:     time.Now(),
+	}
+	if _, err := s.AnuncioCollection.InsertOne(ctx, anuncio); err != nil {
+		return nil, err
+	}
+
+	// La notificación a los inscritos no debe demorar la respuesta al instructor
+	go s.notificarInscritos(context.Background(), curso, anuncio)
+
+	return anuncio, nil
+}
+
+// EditarAnuncio cambia el título y el contenido de un anuncio.
+func (s *AnuncioService) EditarAnuncio(ctx context.Context, id, email, password, titulo, contenido string) (*models.Anuncio, error) {
+	anuncio, err := s.obtenerAnuncioEditable(ctx, id, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	ahora := time.Now()
+	_, err = s.AnuncioCollection.UpdateOne(ctx, bson.M{"_id": anuncio.ID}, bson.M{"$set": bson.M{
+		"titulo":     titulo,
+		"contenido":  contenido,
+		"editado_en": ahora,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	anuncio.Titulo = titulo
+	anuncio.Contenido = contenido
+	anuncio.EditadoEn = &ahora
+	return anuncio, nil
+}
+
+// EliminarAnuncio elimina un anuncio.
+func (s *AnuncioService) EliminarAnuncio(ctx context.Context, id, email, password string) error {
+	anuncio, err := s.obtenerAnuncioEditable(ctx, id, email, password)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.AnuncioCollection.DeleteOne(ctx, bson.M{"_id": anuncio.ID})
+	return err
+}
+
+// ObtenerAnunciosPorCurso obtiene los anuncios de un curso, del más reciente al más antiguo, si el
+// usuario puede ver el curso.
+func (s *AnuncioService) ObtenerAnunciosPorCurso(ctx context.Context, cursoID, email, password string) ([]models.Anuncio, error) {
+	objectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+
+	usuario, err := usuarioOpcional(ctx, s.RedisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := obtenerCursoVisible(ctx, s.CursoCollection, objectID, usuario); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.AnuncioCollection.Find(ctx, bson.M{"curso_id": objectID}, options.Find().SetSort(bson.D{{Key: "fecha", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	anuncios := []models.Anuncio{}
+	if err := cursor.All(ctx, &anuncios); err != nil {
+		return nil, err
+	}
+	return anuncios, nil
+}
+
+// ObtenerFeed obtiene los anuncios más recientes de los cursos en los que el usuario está inscrito.
+func (s *AnuncioService) ObtenerFeed(ctx context.Context, email, password string, limite int) ([]models.AnuncioFeed, error) {
+	usuario, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := []models.AnuncioFeed{}
+	if len(usuario.Inscritos) == 0 {
+		return feed, nil
+	}
+
+	opciones := options.Find().SetSort(bson.D{{Key: "fecha", Value: -1}}).SetLimit(int64(limite))
+	cursor, err := s.AnuncioCollection.Find(ctx, bson.M{"curso_id": bson.M{"$in": usuario.Inscritos}}, opciones)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &feed); err != nil {
+		return nil, err
+	}
+
+	nombres, err := s.nombresCursos(ctx, usuario.Inscritos)
+	if err != nil {
+		return nil, err
+	}
+	leidos, err := s.RedisClient.SMembers(ctx, claveAnunciosLeidos(email)).Result()
+	if err != nil {
+		return nil, err
+	}
+	idsLeidos := map[string]bool{}
+	for _, id := range leidos {
+		idsLeidos[id] = true
+	}
+
+	for i := range feed {
+		feed[i].Curso = nombres[feed[i].CursoID]
+		feed[i].Leido = idsLeidos[feed[i].ID.Hex()]
+	}
+	return feed, nil
+}
+
+// MarcarAnunciosLeidos marca como leídos los anuncios indicados, o todos los de los cursos inscritos
+// si no se indica ninguno.
+func (s *AnuncioService) MarcarAnunciosLeidos(ctx context.Context, email, password string, ids []string) error {
+	usuario, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password)
+	if err != nil {
+		return err
+	}
+
+	leidos := []interface{}{}
+	if len(ids) > 0 {
+		for _, id := range ids {
+			if _, err := primitive.ObjectIDFromHex(id); err != nil {
+				return errors.New("ID de anuncio inválido")
+			}
+			leidos = append(leidos, id)
+		}
+	} else if len(usuario.Inscritos) > 0 {
+		todos, err := s.AnuncioCollection.Distinct(ctx, "_id", bson.M{"curso_id": bson.M{"$in": usuario.Inscritos}})
+		if err != nil {
+			return err
+		}
+		for _, id := range todos {
+			if objectID, ok := id.(primitive.ObjectID); ok {
+				leidos = append(leidos, objectID.Hex())
+			}
+		}
+	}
+
+	if len(leidos) == 0 {
+		return nil
+	}
+	return s.RedisClient.SAdd(ctx, claveAnunciosLeidos(email), leidos...).Err()
+}
+
+// obtenerCursoEditable obtiene un curso verificando que el usuario sea su instructor o un administrador.
+func (s *AnuncioService) obtenerCursoEditable(ctx context.Context, cursoID, email, password string) (*models.Curso, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+
+	var curso models.Curso
+	if err := s.CursoCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if curso.Instructor != email && !esAdmin(email) {
+		return nil, errors.New("solo el instructor del curso o un administrador puede gestionar sus anuncios")
+	}
+	return &curso, nil
+}
+
+// obtenerAnuncioEditable obtiene un anuncio verificando que el usuario pueda gestionar los anuncios de su curso.
+func (s *AnuncioService) obtenerAnuncioEditable(ctx context.Context, id, email, password string) (*models.Anuncio, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+
+	var anuncio models.Anuncio
+	if err := s.AnuncioCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&anuncio); err == mongo.ErrNoDocuments {
+		return nil, errors.New("anuncio no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.obtenerCursoEditable(ctx, anuncio.CursoID.Hex(), email, password); err != nil {
+		return nil, err
+	}
+	return &anuncio, nil
+}
+
+// notificarInscritos envía una notificación del anuncio a cada usuario inscrito en el curso, salvo a su autor.
+func (s *AnuncioService) notificarInscritos(ctx context.Context, curso *models.Curso, anuncio *models.Anuncio) {
+	emails, err := obtenerEmailsInscritos(ctx, s.RedisClient, curso.ID)
+	if err != nil {
+		log.Printf("Error al obtener los inscritos del curso %s: %v", curso.ID.Hex(), err)
+		return
+	}
+
+	datos := map[string]string{
+		"curso_id":   curso.ID.Hex(),
+		"curso":      curso.Nombre,
+		"anuncio_id": anuncio.ID.Hex(),
+	}
+	for _, email := range emails {
+		if email != anuncio.Autor {
+			s.Notificaciones.notificarRegistrandoError(ctx, email, NotificacionAnuncio, "Nuevo anuncio en "+curso.Nombre+": "+anuncio.Titulo, datos)
+		}
+	}
+}
+
+// nombresCursos obtiene el nombre de cada uno de los cursos indicados.
+func (s *AnuncioService) nombresCursos(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	cursor, err := s.CursoCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find().SetProjection(bson.M{"nombre": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var cursos []models.Curso
+	if err := cursor.All(ctx, &cursos); err != nil {
+		return nil, err
+	}
+
+	nombres := map[primitive.ObjectID]string{}
+	for _, curso := range cursos {
+		nombres[curso.ID] = curso.Nombre
+	}
+	return nombres, nil
+}
+
+// contarAnunciosNoLeidos obtiene, para cada curso indicado, cuántos de sus anuncios no leyó el usuario.
+func contarAnunciosNoLeidos(ctx context.Context, anuncioCollection *mongo.Collection, redisClient *redis.Client, email string, cursos []primitive.ObjectID) (map[primitive.ObjectID]int, error) {
+	noLeidos := map[primitive.ObjectID]int{}
+	if len(cursos) == 0 {
+		return noLeidos, nil
+	}
+
+	cursor, err := anuncioCollection.Find(ctx, bson.M{"curso_id": bson.M{"$in": cursos}}, options.Find().SetProjection(bson.M{"curso_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var anuncios []models.Anuncio
+	if err := cursor.All(ctx, &anuncios); err != nil {
+		return nil, err
+	}
+
+	leidos, err := redisClient.SMembers(ctx, claveAnunciosLeidos(email)).Result()
+	if err != nil {
+		return nil, err
+	}
+	idsLeidos := map[string]bool{}
+	for _, id := range leidos {
+		idsLeidos[id] = true
+	}
+
+	for _, anuncio := range anuncios {
+		if !idsLeidos[anuncio.ID.Hex()] {
+			noLeidos[anuncio.CursoID]++
+		}
+	}
+	return noLeidos, nil
+}
+
+// obtenerEmailsInscritos obtiene los emails de los usuarios inscritos en un curso a partir del
+// conjunto de inscritos que mantiene InscribirseACurso.
+func obtenerEmailsInscritos(ctx context.Context, redisClient *redis.Client, cursoID primitive.ObjectID) ([]string, error) {
+	return redisClient.SMembers(ctx, claveInscritosCurso(cursoID)).Result()
+}
@@ -4,31 +4,64 @@ import (
 	"context"
 	"errors"
 
+	"go-API/almacenamiento"
 	"go-API/models"
+	"log"
 
+	"github.com/go-redis/redis/v8"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// ClaseService gestiona la lógica relacionada con las clases.
+const errorInstructorClases = "solo el instructor del curso o un administrador puede modificar las clases"
+
+// ClaseService gestiona la lógica relacionada con las clases. La duración de cada clase se suma en
+// su unidad y en su curso, que se actualizan al crear, editar o eliminar clases.
 type ClaseService struct {
-	CursoCollection  *mongo.Collection
-	UnidadCollection *mongo.Collection
-	ClaseCollection  *mongo.Collection
+	CursoCollection         *mongo.Collection
+	UnidadCollection        *mongo.Collection
+	ClaseCollection         *mongo.Collection
+	TranscripcionCollection *mongo.Collection
+	NotaCollection          *mongo.Collection
+	MarcadorCollection      *mongo.Collection
+	CuestionarioCollection  *mongo.Collection
+	IntentoCollection       *mongo.Collection
+	Driver                  neo4j.DriverWithContext
+	RedisClient             *redis.Client
+	Almacenamiento          almacenamiento.Almacenamiento
+	historial               *historialVersiones
 }
 
 // NewClaseService crea un nuevo servicio para las clases.
-func NewClaseService(db *mongo.Database) *ClaseService {
+func NewClaseService(db *mongo.Database, driver neo4j.DriverWithContext, redisClient *redis.Client, almacen almacenamiento.Almacenamiento) *ClaseService {
 	return &ClaseService{
-		CursoCollection:  db.Collection("cursos"),
-		UnidadCollection: db.Collection("unidades"),
-		ClaseCollection:  db.Collection("clases"), // Asegúrate de asignar la colección de clases aquí
+		CursoCollection:         db.Collection("cursos"),
+		UnidadCollection:        db.Collection("unidades"),
+		ClaseCollection:         db.Collection("clases"), // Asegúrate de asignar la colección de clases aquí
+		TranscripcionCollection: db.Collection("transcripciones"),
+		NotaCollection:          db.Collection("notas"),
+		MarcadorCollection:      db.Collection("marcadores"),
+		CuestionarioCollection:  db.Collection("cuestionarios"),
+		IntentoCollection:       db.Collection("intentos_cuestionario"),
+		Driver:                  driver,
+		RedisClient:             redisClient,
+		Almacenamiento:          almacen,
+		historial:               nuevoHistorialVersiones(db),
 	}
 }
 
-// ObtenerClasesPorUnidad obtiene todas las clases de una unidad.
-func (s *ClaseService) ObtenerClasesPorUnidad(id string) ([]models.Clase, error) {
+// ObtenerClasesPorUnidad obtiene todas las clases de una unidad. Si la unidad está bloqueada para el
+// usuario de las credenciales (o para un usuario no inscrito, si no se indican) las clases se
+// devuelven sin su contenido.
+func (s *ClaseService) ObtenerClasesPorUnidad(id, email, password string) ([]models.Clase, error) {
+	usuario, err := usuarioOpcional(context.TODO(), s.RedisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convertir el ID a ObjectID
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -44,6 +77,9 @@ func (s *ClaseService) ObtenerClasesPorUnidad(id string) ([]models.Clase, error)
 		}
 		return nil, err
 	}
+	if unidad.IDcurso.IsZero() {
+		return nil, errors.New("unidad no encontrada")
+	}
 
 	// Buscar las clases asociadas a la unidad
 	cursor, err := s.ClaseCollection.Find(context.TODO(), bson.M{"unidad_id": objectID})
@@ -56,10 +92,23 @@ func (s *ClaseService) ObtenerClasesPorUnidad(id string) ([]models.Clase, error)
 		return nil, err
 	}
 
+	if _, err := obtenerCursoVisible(context.TODO(), s.CursoCollection, unidad.IDcurso, usuario); err != nil {
+		return nil, err
+	}
+
+	estado, err := bloqueoUnidad(context.TODO(), s.CursoCollection, s.UnidadCollection, unidad.IDcurso, unidad.ID, usuario)
+	if err != nil {
+		return nil, err
+	}
+	if estado.bloqueada {
+		for i := range clases {
+			ocultarClaseBloqueada(&clases[i], estado)
+		}
+	}
+
 	return clases, nil
 }
 
-// CrearClaseParaUnidad crea una nueva clase y la asocia a una unidad.
 // CrearClaseParaUnidad crea una nueva clase y la asocia a una unidad.
 func (s *ClaseService) CrearClaseParaUnidad(unidadID string, clase *models.Clase) (*mongo.InsertOneResult, error) {
     // Convertir el ID de la unidad a ObjectID
@@ -78,14 +127,32 @@ func (s *ClaseService) CrearClaseParaUnidad(unidadID string, clase *models.Clase
         return nil, err
     }
 
+    // Verificar si el curso existe y obtener el curso
+    var curso models.Curso
+    err = s.CursoCollection.FindOne(context.TODO(), bson.M{"_id": unidad.IDcurso}).Decode(&curso)
+    if err != nil {
+        if err == mongo.ErrNoDocuments {
+            return nil, errors.New("curso no encontrado")
+        }
+        return nil, err
+    }
+
     // Asegurarse de que las listas estén inicializadas
     if clase.Adjuntos_url == nil {
         clase.Adjuntos_url = []string{}
     }
+    if clase.Adjuntos == nil {
+        clase.Adjuntos = []models.Adjunto{}
+    }
+    if clase.Subtitulos == nil {
+        clase.Subtitulos = []models.PistaSubtitulos{}
+    }
     if clase.Comentarios == nil {
         clase.Comentarios = []primitive.ObjectID{}
     }
 
+    s.historial.versionInicial(context.TODO(), unidad.IDcurso)
+
     // Asignar el ID de la unidad a la clase
     clase.UnidadID = objectID
     clase.ID = primitive.NewObjectID() // Generar un nuevo ObjectID para la clase
@@ -96,31 +163,30 @@ func (s *ClaseService) CrearClaseParaUnidad(unidadID string, clase *models.Clase
         return nil, err
     }
 
-    // Actualizar la unidad con el ID de la nueva clase
+    // Crear el nodo Clase en Neo4j enlazado a su unidad y su curso
+    if err := sincronizarClaseEnNeo4j(context.TODO(), s.Driver, curso, unidad, *clase); err != nil {
+        // Si falla crear el nodo en Neo4j, revertimos la inserción en MongoDB
+        if _, delErr := s.ClaseCollection.DeleteOne(context.TODO(), bson.M{"_id": clase.ID}); delErr != nil {
+            log.Printf("No se pudo eliminar la clase de MongoDB tras fallo en Neo4j: %v", delErr)
+        }
+        return nil, err
+    }
+
+    // Actualizar la unidad con el ID y la duración de la nueva clase
     _, err = s.UnidadCollection.UpdateOne(
         context.TODO(),
         bson.M{"_id": objectID},
-		bson.M{"$push": bson.M{"clases": clase.ID}},
+		bson.M{"$push": bson.M{"clases": clase.ID}, "$inc": bson.M{"duracion": clase.Duracion}},
     )
     if err != nil {
         return nil, err
     }
 
-    // Verificar si el curso existe y obtener el curso
-    var curso models.Curso
-    err = s.CursoCollection.FindOne(context.TODO(), bson.M{"_id": unidad.IDcurso}).Decode(&curso)
-    if err != nil {
-        if err == mongo.ErrNoDocuments {
-            return nil, errors.New("curso no encontrado")
-        }
-        return nil, err
-    }
-
-    // Actualizar la cantidad de clases en el curso
+    // Actualizar la cantidad de clases y la duración del curso
     updateResult, err := s.CursoCollection.UpdateOne(
         context.TODO(),
         bson.M{"_id": unidad.IDcurso},
-        bson.M{"$inc": bson.M{"cant_clases": 1}},
+        bson.M{"$inc": bson.M{"cant_clases": 1, "duracion": clase.Duracion}},
     )
     if err != nil {
         return nil, err
@@ -130,5 +196,210 @@ func (s *ClaseService) CrearClaseParaUnidad(unidadID string, clase *models.Clase
         return nil, errors.New("no se encontró el curso para actualizar")
     }
 
+    s.historial.registrar(context.TODO(), unidad.IDcurso, "clase creada: "+clase.Nombre, "")
     return result, nil
-}
\ No newline at end of file
+}
+
+// ActualizarClase reemplaza el nombre, la descripción, el video y la duración de una clase, y ajusta
+// la duración de su unidad y su curso. Solo el instructor del curso o un administrador puede hacerlo.
+func (s *ClaseService) ActualizarClase(ctx context.Context, claseID, email, password string, cambios models.Clase) (*models.Clase, error) {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorClases); err != nil {
+		return nil, err
+	}
+
+	s.historial.versionInicial(ctx, cursoID)
+
+	// Se obtiene la clase anterior en la misma operación para calcular la diferencia de duración
+	var anterior models.Clase
+	err = s.ClaseCollection.FindOneAndUpdate(ctx, bson.M{"_id": clase.ID}, bson.M{"$set": bson.M{
+		"nombre":      cambios.Nombre,
+		"descripcion": cambios.Descripcion,
+		"video_url":   cambios.VideoURL,
+		"duracion":    cambios.Duracion,
+	}}).Decode(&anterior)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.New("clase no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if diferencia := cambios.Duracion - anterior.Duracion; diferencia != 0 {
+		if err := s.sumarDuracion(ctx, anterior.UnidadID, cursoID, diferencia, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	if cambios.Nombre != anterior.Nombre {
+		if err := s.renombrarClaseEnNeo4j(ctx, anterior.ID.Hex(), cambios.Nombre); err != nil {
+			return nil, err
+		}
+	}
+
+	anterior.Nombre = cambios.Nombre
+	anterior.Descripcion = cambios.Descripcion
+	anterior.VideoURL = cambios.VideoURL
+	anterior.Duracion = cambios.Duracion
+	s.historial.registrar(ctx, cursoID, "clase actualizada: "+anterior.Nombre, email)
+	return &anterior, nil
+}
+
+// EliminarClase elimina una clase con sus comentarios, adjuntos, subtítulos y las notas y marcadores
+// de los usuarios, y la descuenta de su unidad y su curso. Solo el instructor del curso o un administrador puede hacerlo.
+func (s *ClaseService) EliminarClase(ctx context.Context, claseID, email, password string) error {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return err
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorClases); err != nil {
+		return err
+	}
+
+	s.historial.versionInicial(ctx, cursoID)
+
+	var eliminada models.Clase
+	if err := s.ClaseCollection.FindOneAndDelete(ctx, bson.M{"_id": clase.ID}).Decode(&eliminada); err == mongo.ErrNoDocuments {
+		return errors.New("clase no encontrada")
+	} else if err != nil {
+		return err
+	}
+
+	_, err = s.UnidadCollection.UpdateOne(ctx, bson.M{"_id": eliminada.UnidadID}, bson.M{"$pull": bson.M{"clases": eliminada.ID}})
+	if err != nil {
+		return err
+	}
+	if err := s.sumarDuracion(ctx, eliminada.UnidadID, cursoID, -eliminada.Duracion, -1); err != nil {
+		return err
+	}
+
+	if err := s.eliminarDatosClase(ctx, eliminada); err != nil {
+		return err
+	}
+	s.historial.registrar(ctx, cursoID, "clase eliminada: "+eliminada.Nombre, email)
+	return nil
+}
+
+// eliminarDatosClase elimina de Neo4j el nodo de una clase ya eliminada de MongoDB junto con sus
+// comentarios, y borra su transcripción, sus cuestionarios con los intentos de los usuarios, las
+// notas y marcadores de los usuarios y sus archivos.
+func (s *ClaseService) eliminarDatosClase(ctx context.Context, eliminada models.Clase) error {
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, `
+            MATCH (cl:Clase {id: $id})
+            OPTIONAL MATCH (c:Comentario)-[:PERTENECE_A]->(cl)
+            DETACH DELETE c, cl
+        `, map[string]interface{}{"id": eliminada.ID.Hex()})
+		return nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.TranscripcionCollection.DeleteMany(ctx, bson.M{"clase_id": eliminada.ID}); err != nil {
+		log.Printf("Error al eliminar la transcripción de la clase %s: %v", eliminada.ID.Hex(), err)
+	}
+	if err := s.eliminarCuestionariosClase(ctx, eliminada.ID); err != nil {
+		log.Printf("Error al eliminar los cuestionarios de la clase %s: %v", eliminada.ID.Hex(), err)
+	}
+	if _, err := s.NotaCollection.DeleteMany(ctx, bson.M{"clase_id": eliminada.ID}); err != nil {
+		log.Printf("Error al eliminar las notas de la clase %s: %v", eliminada.ID.Hex(), err)
+	}
+	if _, err := s.MarcadorCollection.DeleteMany(ctx, bson.M{"clase_id": eliminada.ID}); err != nil {
+		log.Printf("Error al eliminar los marcadores de la clase %s: %v", eliminada.ID.Hex(), err)
+	}
+	for _, adjunto := range eliminada.Adjuntos {
+		eliminarArchivo(ctx, s.Almacenamiento, adjunto.Clave)
+	}
+	for _, pista := range eliminada.Subtitulos {
+		eliminarArchivo(ctx, s.Almacenamiento, pista.Clave)
+	}
+	return nil
+}
+
+// eliminarCuestionariosClase elimina los cuestionarios de una clase y los intentos de los usuarios.
+// Los intentos se eliminan primero para que un error no deje intentos de cuestionarios inexistentes.
+func (s *ClaseService) eliminarCuestionariosClase(ctx context.Context, claseID primitive.ObjectID) error {
+	cursor, err := s.CuestionarioCollection.Find(ctx, bson.M{"clase_id": claseID}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	var cuestionarios []models.Cuestionario
+	if err := cursor.All(ctx, &cuestionarios); err != nil {
+		return err
+	}
+	if len(cuestionarios) == 0 {
+		return nil
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, cuestionario := range cuestionarios {
+		ids = append(ids, cuestionario.ID)
+	}
+	if _, err := s.IntentoCollection.DeleteMany(ctx, bson.M{"cuestionario_id": bson.M{"$in": ids}}); err != nil {
+		return err
+	}
+	_, err = s.CuestionarioCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// sumarDuracion suma segundos a la duración de una unidad y de su curso, y clases a la cantidad de
+// clases del curso. Los valores negativos restan.
+func (s *ClaseService) sumarDuracion(ctx context.Context, unidadID, cursoID primitive.ObjectID, segundos, clases int) error {
+	_, err := s.UnidadCollection.UpdateOne(ctx, bson.M{"_id": unidadID}, bson.M{"$inc": bson.M{"duracion": segundos}})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.CursoCollection.UpdateOne(ctx, bson.M{"_id": cursoID}, bson.M{"$inc": bson.M{"duracion": segundos, "cant_clases": clases}})
+	return err
+}
+
+func (s *ClaseService) renombrarClaseEnNeo4j(ctx context.Context, id, nombre string) error {
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, `MATCH (cl:Clase {id: $id}) SET cl.nombre = $nombre`, map[string]interface{}{
+			"id":     id,
+			"nombre": nombre,
+		})
+		return nil, err
+	})
+	return err
+}
+
+// sincronizarClaseEnNeo4j crea (o actualiza) el nodo Clase y lo enlaza con su Unidad, que a su vez
+// se enlaza con su Curso: (:Curso)-[:CONTENEDOR_DE]->(:Unidad)-[:CONTENEDOR_DE]->(:Clase).
+func sincronizarClaseEnNeo4j(ctx context.Context, driver neo4j.DriverWithContext, curso models.Curso, unidad models.Unidad, clase models.Clase) error {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+            MERGE (c:Curso {id: $cursoID})
+            ON CREATE SET c.nombre = $cursoNombre
+            MERGE (u:Unidad {id: $unidadID})
+            ON CREATE SET u.nombre = $unidadNombre
+            MERGE (c)-[:CONTENEDOR_DE]->(u)
+            MERGE (cl:Clase {id: $id})
+            SET cl.nombre = $nombre
+            MERGE (u)-[:CONTENEDOR_DE]->(cl)
+        `
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"cursoID":      curso.ID.Hex(),
+			"cursoNombre":  curso.Nombre,
+			"unidadID":     unidad.ID.Hex(),
+			"unidadNombre": unidad.Nombre,
+			"id":           clase.ID.Hex(),
+			"nombre":       clase.Nombre,
+		})
+		return nil, err
+	})
+
+	return err
+}
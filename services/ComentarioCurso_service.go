@@ -2,88 +2,448 @@ package services
 
 import (
     "context"
+    "encoding/base64"
     "errors"
+    "go-API/models"
+    "strings"
+    "time"
 
+    "github.com/go-redis/redis/v8"
+    "github.com/google/uuid"
     "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+    "go.mongodb.org/mongo-driver/mongo"
 )
 
 type ComentarioCursoService struct {
-    Driver neo4j.DriverWithContext
+    Driver          neo4j.DriverWithContext
+    CursoCollection *mongo.Collection
+    RedisClient     *redis.Client
+    Notificaciones  *NotificacionService
 }
 
-func NewComentarioCursoService(driver neo4j.DriverWithContext) *ComentarioCursoService {
+func NewComentarioCursoService(driver neo4j.DriverWithContext, cursoCollection *mongo.Collection, redisClient *redis.Client, notificaciones *NotificacionService) *ComentarioCursoService {
     return &ComentarioCursoService{
-        Driver: driver,
+        Driver:          driver,
+        CursoCollection: cursoCollection,
+        RedisClient:     redisClient,
+        Notificaciones:  notificaciones,
     }
 }
 
-// CrearComentarioCurso crea un nuevo comentario para un curso.
-func (s *ComentarioCursoService) CrearComentarioCurso(email, cursoID, texto string) error {
+// CrearComentarioCurso crea un nuevo comentario para un curso y devuelve su ID y su estado de
+// moderación. El autor debe autenticarse y estar inscrito en el curso. Los comentarios con palabras
+// filtradas quedan pendientes hasta que un moderador los apruebe; los visibles notifican a los
+// usuarios mencionados.
+func (s *ComentarioCursoService) CrearComentarioCurso(email, password, cursoID, texto string) (string, string, error) {
     if len(texto) < 15 {
-        return errors.New("el comentario debe tener al menos 15 caracteres")
+        return "", "", errors.New("el comentario debe tener al menos 15 caracteres")
     }
 
+    // Verificar las credenciales y la inscripción del usuario en el curso
+    usuario, err := obtenerUsuarioInscrito(context.TODO(), s.RedisClient, email, password, cursoID)
+    if err != nil {
+        return "", "", err
+    }
+
+    comentarioID := uuid.New().String()
+    moderacion := estadoModeracionInicial(texto)
+
     session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
     defer session.Close(context.TODO())
 
-    _, err := session.ExecuteWrite(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
+    _, err = session.ExecuteWrite(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        // Verificar que existan los nodos del usuario y del curso; sin ellos el CREATE no haría nada
+        checkQuery := `
+            OPTIONAL MATCH (u:Usuario {email: $email})
+            OPTIONAL MATCH (c:Curso {id: $cursoID})
+            RETURN u IS NOT NULL AS existeUsuario, c IS NOT NULL AS existeCurso
+        `
+        checkResult, err := tx.Run(context.TODO(), checkQuery, map[string]interface{}{
+            "email":   email,
+            "cursoID": cursoID,
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !checkResult.Next(context.TODO()) {
+            return nil, errors.New("usuario no encontrado")
+        }
+        record := checkResult.Record()
+        if !record.Values[0].(bool) {
+            return nil, errors.New("usuario no encontrado")
+        }
+        if !record.Values[1].(bool) {
+            return nil, errors.New("curso no encontrado")
+        }
+
         query := `
             MATCH (u:Usuario {email: $email}), (c:Curso {id: $cursoID})
-            CREATE (u)-[r:REALIZO_COMENTARIO {texto: $texto}]->(c)
-            RETURN r
+            CREATE (u)-[r:REALIZO_COMENTARIO {
+                id: $id, texto: $texto, fecha: datetime(), moderacion: $moderacion, notificar_al_aprobar: $notificarAlAprobar
+            }]->(c)
+            RETURN r.id
         `
         params := map[string]interface{}{
-            "email":   email,
-            "cursoID": cursoID,
-            "texto":   texto,
+            "id":         comentarioID,
+            "email":      email,
+            "cursoID":    cursoID,
+            "texto":      texto,
+            "moderacion": moderacion,
+            // Las menciones de un comentario pendiente de moderación se notifican al aprobarlo
+            "notificarAlAprobar": notificarAlAprobar(moderacion),
         }
-        _, err := tx.Run(context.TODO(), query, params)
-        return nil, err
+        res, err := tx.Run(context.TODO(), query, params)
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(context.TODO()) {
+            return nil, errors.New("no se pudo crear el comentario")
+        }
+        return nil, res.Err()
     })
+    if err != nil {
+        return "", "", err
+    }
 
-    return err
+    if moderacion != nil {
+        return comentarioID, EstadoModeracionPendiente, nil
+    }
+
+    notificarComentarioCurso(context.TODO(), s.Notificaciones, cursoID, comentarioID, email, usuario.Nombre, texto)
+    return comentarioID, EstadoModeracionAprobado, nil
 }
 
-// ObtenerComentariosCursoPorUsuario obtiene todos los comentarios hechos por un usuario.
-func (s *ComentarioCursoService) ObtenerComentariosCursoPorUsuario(email string) ([]map[string]interface{}, error) {
+// notificarComentarioCurso notifica a los usuarios mencionados en un comentario de curso. Se usa al
+// crear el comentario y, si quedó pendiente de moderación, al aprobarlo.
+func notificarComentarioCurso(ctx context.Context, notificaciones *NotificacionService, cursoID, comentarioID, autor, nombreAutor, texto string) {
+    notificaciones.NotificarMenciones(ctx, autor, nombreAutor+" te mencionó en un comentario de curso",
+        map[string]string{"curso_id": cursoID, "comentario_id": comentarioID}, texto)
+}
+
+// camposComentarioCurso son las columnas que espera comentarioCursoDesdeRecord, con "u" como autor,
+// "r" como la relación REALIZO_COMENTARIO y "c" como el curso.
+const camposComentarioCurso = `r.id, c.id, c.nombre, u.email, u.nombre, r.texto, r.fecha, r.editado_en, coalesce(r.moderacion, $aprobado)`
+
+// ObtenerComentariosCursoPorUsuario obtiene todos los comentarios visibles hechos por un usuario,
+// del más reciente al más antiguo.
+func (s *ComentarioCursoService) ObtenerComentariosCursoPorUsuario(email string) ([]models.ComentarioCurso, error) {
     session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
     defer session.Close(context.TODO())
 
     result, err := session.ExecuteRead(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
         query := `
             MATCH (u:Usuario {email: $email})-[r:REALIZO_COMENTARIO]->(c:Curso)
-            RETURN c.nombre AS curso, r.texto AS comentario
+            WHERE coalesce(r.eliminado, false) = false AND coalesce(r.moderacion, $aprobado) = $aprobado
+            RETURN ` + camposComentarioCurso + `
+            ORDER BY r.fecha DESC
         `
         params := map[string]interface{}{
-            "email": email,
+            "email":    email,
+            "aprobado": EstadoModeracionAprobado,
         }
         res, err := tx.Run(context.TODO(), query, params)
         if err != nil {
             return nil, err
         }
 
-        var comentarios []map[string]interface{}
+        comentarios := []models.ComentarioCurso{}
         for res.Next(context.TODO()) {
-            record := res.Record()
-            curso, _ := record.Get("curso")
-            comentario, _ := record.Get("comentario")
-            comentarioCurso := map[string]interface{}{
-                "curso":      curso,
-                "comentario": comentario,
-            }
-            comentarios = append(comentarios, comentarioCurso)
+            comentarios = append(comentarios, comentarioCursoDesdeRecord(res.Record()))
         }
-        return comentarios, nil
+        return comentarios, res.Err()
     })
 
     if err != nil {
         return nil, err
     }
 
-    comentarios, ok := result.([]map[string]interface{})
+    comentarios, ok := result.([]models.ComentarioCurso)
     if !ok {
         return nil, errors.New("error al obtener los comentarios")
     }
 
     return comentarios, nil
-}
\ No newline at end of file
+}
+
+// ObtenerComentariosPorCurso obtiene una página de los comentarios visibles de un curso, ordenados
+// por fecha ("recientes" por defecto o "antiguos"). La página empieza después del cursor indicado
+// ("" para la primera) y se devuelve el cursor de la página siguiente ("" si no hay más). El usuario
+// debe poder ver el curso.
+func (s *ComentarioCursoService) ObtenerComentariosPorCurso(cursoID, email, password, orden, cursor string, limite int) ([]models.ComentarioCurso, string, error) {
+    if err := verificarCursoVisible(context.TODO(), s.RedisClient, s.CursoCollection, cursoID, email, password); err != nil {
+        return nil, "", err
+    }
+
+    comparador, direccion := "<", "DESC"
+    if orden == "antiguos" {
+        comparador, direccion = ">", "ASC"
+    }
+
+    var cursorFecha interface{}
+    cursorID := ""
+    if cursor != "" {
+        fecha, id, err := decodificarCursorComentario(cursor)
+        if err != nil {
+            return nil, "", err
+        }
+        cursorFecha, cursorID = fecha, id
+    }
+
+    session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+    defer session.Close(context.TODO())
+
+    result, err := session.ExecuteRead(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        checkResult, err := tx.Run(context.TODO(), `MATCH (c:Curso {id: $cursoID}) RETURN c.id`, map[string]interface{}{"cursoID": cursoID})
+        if err != nil {
+            return nil, err
+        }
+        if !checkResult.Next(context.TODO()) {
+            return nil, errors.New("curso no encontrado")
+        }
+
+        // El ID desempata los comentarios con la misma fecha para que el cursor sea estable
+        query := `
+            MATCH (u:Usuario)-[r:REALIZO_COMENTARIO]->(c:Curso {id: $cursoID})
+            WHERE coalesce(r.eliminado, false) = false AND coalesce(r.moderacion, $aprobado) = $aprobado
+              AND ($cursorFecha IS NULL
+                   OR r.fecha ` + comparador + ` $cursorFecha
+                   OR (r.fecha = $cursorFecha AND r.id ` + comparador + ` $cursorID))
+            RETURN ` + camposComentarioCurso + `
+            ORDER BY r.fecha ` + direccion + `, r.id ` + direccion + `
+            LIMIT $limite
+        `
+        res, err := tx.Run(context.TODO(), query, map[string]interface{}{
+            "cursoID":     cursoID,
+            "aprobado":    EstadoModeracionAprobado,
+            "cursorFecha": cursorFecha,
+            "cursorID":    cursorID,
+            // Se pide un comentario de más para saber si existe una página siguiente
+            "limite": limite + 1,
+        })
+        if err != nil {
+            return nil, err
+        }
+
+        comentarios := []models.ComentarioCurso{}
+        for res.Next(context.TODO()) {
+            comentarios = append(comentarios, comentarioCursoDesdeRecord(res.Record()))
+        }
+        return comentarios, res.Err()
+    })
+
+    if err != nil {
+        return nil, "", err
+    }
+
+    comentarios := result.([]models.ComentarioCurso)
+    siguiente := ""
+    if len(comentarios) > limite {
+        comentarios = comentarios[:limite]
+        ultimo := comentarios[limite-1]
+        siguiente = codificarCursorComentario(ultimo.Fecha, ultimo.ID)
+    }
+
+    return comentarios, siguiente, nil
+}
+
+// codificarCursorComentario genera el cursor opaco que identifica la posición de un comentario.
+func codificarCursorComentario(fecha time.Time, id string) string {
+    return base64.RawURLEncoding.EncodeToString([]byte(fecha.Format(time.RFC3339Nano) + "|" + id))
+}
+
+// decodificarCursorComentario obtiene la fecha y el ID de un cursor generado por codificarCursorComentario.
+func decodificarCursorComentario(cursor string) (time.Time, string, error) {
+    datos, err := base64.RawURLEncoding.DecodeString(cursor)
+    if err != nil {
+        return time.Time{}, "", errors.New("cursor inválido")
+    }
+    partes := strings.SplitN(string(datos), "|", 2)
+    if len(partes) != 2 {
+        return time.Time{}, "", errors.New("cursor inválido")
+    }
+    fecha, err := time.Parse(time.RFC3339Nano, partes[0])
+    if err != nil {
+        return time.Time{}, "", errors.New("cursor inválido")
+    }
+    return fecha, partes[1], nil
+}
+
+// comentarioCursoDesdeRecord construye un comentario de curso a partir de un registro con las
+// columnas de camposComentarioCurso.
+func comentarioCursoDesdeRecord(record *neo4j.Record) models.ComentarioCurso {
+    comentario := models.ComentarioCurso{}
+    comentario.ID, _ = record.Values[0].(string)
+    comentario.CursoID, _ = record.Values[1].(string)
+    comentario.Curso, _ = record.Values[2].(string)
+    comentario.Email, _ = record.Values[3].(string)
+    comentario.Nombre, _ = record.Values[4].(string)
+    comentario.Texto, _ = record.Values[5].(string)
+    comentario.Fecha, _ = record.Values[6].(time.Time)
+    if editadoEn, ok := record.Values[7].(time.Time); ok {
+        comentario.Editado = true
+        comentario.EditadoEn = &editadoEn
+    }
+    if moderacion, _ := record.Values[8].(string); moderacion != EstadoModeracionAprobado {
+        comentario.EstadoModeracion = moderacion
+    }
+    return comentario
+}
+
+// EditarComentarioCurso reemplaza el texto de un comentario de curso a pedido de su autor. El texto
+// anterior se agrega al historial de revisiones guardado en la relación. Si el nuevo texto contiene
+// palabras filtradas, el comentario vuelve a quedar pendiente de moderación.
+func (s *ComentarioCursoService) EditarComentarioCurso(comentarioID, email, password, texto string) (*models.ComentarioCurso, error) {
+    if len(texto) < 15 {
+        return nil, errors.New("el comentario debe tener al menos 15 caracteres")
+    }
+    if _, err := obtenerUsuarioRedis(context.TODO(), s.RedisClient, email, password); err != nil {
+        return nil, err
+    }
+
+    session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+    defer session.Close(context.TODO())
+
+    result, err := session.ExecuteWrite(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        checkQuery := `
+            MATCH (u:Usuario)-[r:REALIZO_COMENTARIO {id: $id}]->(:Curso)
+            WHERE coalesce(r.eliminado, false) = false
+            RETURN u.email = $email AS esAutor
+        `
+        checkResult, err := tx.Run(context.TODO(), checkQuery, map[string]interface{}{
+            "id":    comentarioID,
+            "email": email,
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !checkResult.Next(context.TODO()) {
+            return nil, errors.New("comentario no encontrado")
+        }
+        if !checkResult.Record().Values[0].(bool) {
+            return nil, errors.New("solo el autor puede editar el comentario")
+        }
+
+        // Las propiedades se asignan en orden: primero se guarda la versión vigente en el historial
+        updateQuery := `
+            MATCH (u:Usuario)-[r:REALIZO_COMENTARIO {id: $id}]->(c:Curso)
+            SET r.revisiones_texto = coalesce(r.revisiones_texto, []) + r.texto,
+                r.revisiones_fecha = coalesce(r.revisiones_fecha, []) + coalesce(r.editado_en, r.fecha, datetime()),
+                r.texto = $texto,
+                r.editado_en = datetime(),
+                r.moderacion = coalesce($moderacion, r.moderacion)
+            RETURN ` + camposComentarioCurso + `
+        `
+        res, err := tx.Run(context.TODO(), updateQuery, map[string]interface{}{
+            "id":         comentarioID,
+            "texto":      texto,
+            "moderacion": estadoModeracionInicial(texto),
+            "aprobado":   EstadoModeracionAprobado,
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(context.TODO()) {
+            return nil, errors.New("comentario no encontrado")
+        }
+
+        comentario := comentarioCursoDesdeRecord(res.Record())
+        return &comentario, res.Err()
+    })
+
+    if err != nil {
+        return nil, err
+    }
+
+    return result.(*models.ComentarioCurso), nil
+}
+
+// EliminarComentarioCurso elimina un comentario de curso a pedido de su autor o de un moderador.
+// El comentario se marca como eliminado para conservar su historial.
+func (s *ComentarioCursoService) EliminarComentarioCurso(comentarioID, email, password string) error {
+    if _, err := obtenerUsuarioRedis(context.TODO(), s.RedisClient, email, password); err != nil {
+        return err
+    }
+
+    session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+    defer session.Close(context.TODO())
+
+    _, err := session.ExecuteWrite(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        query := `
+            MATCH (u:Usuario)-[r:REALIZO_COMENTARIO {id: $id}]->(:Curso)
+            WHERE coalesce(r.eliminado, false) = false
+            WITH r, u.email = $email OR $moderador AS permitido
+            FOREACH (_ IN CASE WHEN permitido THEN [1] ELSE [] END |
+                SET r.eliminado = true, r.eliminado_en = datetime(), r.eliminado_por = $email
+            )
+            RETURN permitido
+        `
+        res, err := tx.Run(context.TODO(), query, map[string]interface{}{
+            "id":        comentarioID,
+            "email":     email,
+            "moderador": esModerador(email),
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(context.TODO()) {
+            return nil, errors.New("comentario no encontrado")
+        }
+        if !res.Record().Values[0].(bool) {
+            return nil, errors.New("solo el autor o un moderador puede eliminar el comentario")
+        }
+        return nil, res.Err()
+    })
+
+    return err
+}
+
+// ObtenerRevisionesComentarioCurso obtiene los textos anteriores de un comentario de curso, del más
+// antiguo al más reciente. Como en el listado, el usuario debe poder ver el curso.
+func (s *ComentarioCursoService) ObtenerRevisionesComentarioCurso(ctx context.Context, comentarioID, email, password string) ([]models.RevisionComentario, error) {
+    session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+    defer session.Close(ctx)
+
+    var cursoID string
+    result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        query := `
+            MATCH (:Usuario)-[r:REALIZO_COMENTARIO {id: $id}]->(curso:Curso)
+            WHERE coalesce(r.eliminado, false) = false AND coalesce(r.moderacion, $aprobado) = $aprobado
+            RETURN coalesce(r.revisiones_texto, []), coalesce(r.revisiones_fecha, []), curso.id
+        `
+        res, err := tx.Run(ctx, query, map[string]interface{}{
+            "id":       comentarioID,
+            "aprobado": EstadoModeracionAprobado,
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(ctx) {
+            return nil, errors.New("comentario no encontrado")
+        }
+
+        textos := res.Record().Values[0].([]interface{})
+        fechas := res.Record().Values[1].([]interface{})
+        cursoID, _ = res.Record().Values[2].(string)
+
+        revisiones := []models.RevisionComentario{}
+        for i := range textos {
+            revision := models.RevisionComentario{}
+            revision.Texto, _ = textos[i].(string)
+            if i < len(fechas) {
+                revision.Fecha, _ = fechas[i].(time.Time)
+            }
+            revisiones = append(revisiones, revision)
+        }
+        return revisiones, res.Err()
+    })
+
+    if err != nil {
+        return nil, err
+    }
+
+    if err := verificarCursoVisible(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password); err != nil {
+        return nil, err
+    }
+
+    return result.([]models.RevisionComentario), nil
+}
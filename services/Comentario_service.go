@@ -10,30 +10,174 @@ import (
     "github.com/go-redis/redis/v8"
     "github.com/google/uuid"
     "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
 )
 
 type ComentarioService struct {
-    Driver      neo4j.DriverWithContext
-    RedisClient *redis.Client
+    Driver           neo4j.DriverWithContext
+    CursoCollection  *mongo.Collection
+    UnidadCollection *mongo.Collection
+    RedisClient      *redis.Client
+    Notificaciones   *NotificacionService
 }
 
-func NewComentarioService(driver neo4j.DriverWithContext, redisClient *redis.Client) *ComentarioService {
-    return &ComentarioService{Driver: driver, RedisClient: redisClient}
+func NewComentarioService(driver neo4j.DriverWithContext, cursoCollection *mongo.Collection, redisClient *redis.Client, notificaciones *NotificacionService) *ComentarioService {
+    return &ComentarioService{
+        Driver:           driver,
+        CursoCollection:  cursoCollection,
+        UnidadCollection: cursoCollection.Database().Collection("unidades"),
+        RedisClient:      redisClient,
+        Notificaciones:   notificaciones,
+    }
+}
+
+// textoComentarioEliminado reemplaza el detalle de un comentario eliminado que aún tiene respuestas.
+const textoComentarioEliminado = "[comentario eliminado]"
+
+// textoComentarioOculto reemplaza el detalle de un comentario oculto o pendiente de moderación que aún tiene respuestas.
+const textoComentarioOculto = "[comentario oculto por moderación]"
+
+// ObtenerComentariosPorClase obtiene los hilos de comentarios de una clase, del más reciente al más
+// antiguo. Las respuestas se anidan en orden cronológico hasta la profundidad indicada
+// (0 devuelve solo los comentarios principales; un valor negativo no limita la profundidad). El
+// usuario debe poder ver el curso de la clase y la clase no debe estar bloqueada para él.
+func (s *ComentarioService) ObtenerComentariosPorClase(ctx context.Context, claseID, email, password string, profundidad int) ([]models.Comentario, error) {
+    if err := s.verificarClaseVisible(ctx, claseID, email, password); err != nil {
+        return nil, err
+    }
+
+    comentarios, err := s.obtenerComentariosDeClase(ctx, claseID)
+    if err != nil {
+        return nil, err
+    }
+
+    return construirHilos(comentarios, "", profundidad), nil
 }
 
-// ObtenerComentariosPorClase (sin cambios)
-func (s *ComentarioService) ObtenerComentariosPorClase(ctx context.Context, claseID string) ([]models.Comentario, error) {
+// ObtenerHilo obtiene un comentario con todas sus respuestas anidadas hasta la profundidad indicada.
+// El usuario debe poder ver el curso de la clase del comentario y la clase no debe estar bloqueada
+// para él.
+func (s *ComentarioService) ObtenerHilo(ctx context.Context, comentarioID, email, password string, profundidad int) (*models.Comentario, error) {
+    session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+    defer session.Close(ctx)
+
+    claseID, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        query := `
+            MATCH (c:Comentario {id: $id})-[:PERTENECE_A]->(clase:Clase)
+            RETURN clase.id
+        `
+        res, err := tx.Run(ctx, query, map[string]interface{}{"id": comentarioID})
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(ctx) {
+            return nil, errors.New("comentario no encontrado")
+        }
+        return res.Record().Values[0], nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    if err := s.verificarClaseVisible(ctx, claseID.(string), email, password); err != nil {
+        return nil, err
+    }
+
+    comentarios, err := s.obtenerComentariosDeClase(ctx, claseID.(string))
+    if err != nil {
+        return nil, err
+    }
+
+    // El hilo se construye a partir del padre del comentario para poder aplicar las mismas reglas de visibilidad
+    var padreID string
+    for _, comentario := range comentarios {
+        if comentario.ID == comentarioID {
+            padreID = comentario.PadreID
+        }
+    }
+    for _, hilo := range construirHilos(comentarios, padreID, -1) {
+        if hilo.ID == comentarioID {
+            truncarHilo(&hilo, profundidad)
+            return &hilo, nil
+        }
+    }
+
+    return nil, errors.New("comentario no encontrado")
+}
+
+// verificarClaseVisible verifica que el usuario de las credenciales, o un usuario anónimo si no se
+// indicaron, pueda ver el curso al que pertenece la clase y que la unidad de la clase no esté
+// bloqueada para él.
+func (s *ComentarioService) verificarClaseVisible(ctx context.Context, claseID, email, password string) error {
+    session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+    defer session.Close(ctx)
+
+    ids, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        res, err := tx.Run(ctx, `
+            MATCH (curso:Curso)-[:CONTENEDOR_DE]->(unidad:Unidad)-[:CONTENEDOR_DE]->(:Clase {id: $claseID})
+            RETURN curso.id, unidad.id
+        `, map[string]interface{}{"claseID": claseID})
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(ctx) {
+            return nil, errors.New("clase no encontrada")
+        }
+        return res.Record().Values, nil
+    })
+    if err != nil {
+        return err
+    }
+
+    valores := ids.([]interface{})
+    cursoHex, _ := valores[0].(string)
+    unidadHex, _ := valores[1].(string)
+    cursoID, err := primitive.ObjectIDFromHex(cursoHex)
+    if err != nil {
+        return errors.New("curso no encontrado")
+    }
+    unidadID, err := primitive.ObjectIDFromHex(unidadHex)
+    if err != nil {
+        return errors.New("clase no encontrada")
+    }
+
+    usuario, err := usuarioOpcional(ctx, s.RedisClient, email, password)
+    if err != nil {
+        return err
+    }
+    if _, err := obtenerCursoVisible(ctx, s.CursoCollection, cursoID, usuario); err != nil {
+        return err
+    }
+
+    estado, err := bloqueoUnidad(ctx, s.CursoCollection, s.UnidadCollection, cursoID, unidadID, usuario)
+    if err != nil {
+        return err
+    }
+    if estado.bloqueada {
+        return errorClaseBloqueada(estado)
+    }
+    return nil
+}
+
+// obtenerComentariosDeClase obtiene todos los comentarios de una clase (principales y respuestas) sin anidar.
+func (s *ComentarioService) obtenerComentariosDeClase(ctx context.Context, claseID string) ([]models.Comentario, error) {
     session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
     defer session.Close(ctx)
 
     result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
         query := `
-            MATCH (u:User)-[:COMENTÓ]->(c:Comment)-[:PERTENECE_A]->(:Course)-[:CONTENEDOR_DE]->(:Clase {id: $claseID})
-            RETURN c.id AS id, c.autor AS autor, c.fecha AS fecha, c.titulo AS titulo, c.detalle AS detalle, c.meGusta AS meGusta, c.noMeGusta AS noMeGusta
+            MATCH (u:Usuario)-[:COMENTO]->(c:Comentario)-[:PERTENECE_A]->(:Clase {id: $claseID})
+            OPTIONAL MATCH (c)-[:RESPONDE_A]->(padre:Comentario)
+            RETURN c.id AS id, c.autor AS autor, c.fecha AS fecha, c.titulo AS titulo, c.detalle AS detalle, c.meGusta AS meGusta, c.noMeGusta AS noMeGusta,
+                   padre.id AS padreID, coalesce(c.eliminado, false) AS eliminado, c.editadoEn AS editadoEn,
+                   coalesce(c.moderacion, $aprobado) AS moderacion, coalesce(c.esPregunta, false) AS esPregunta,
+                   c.respuestaAceptadaId AS respuestaAceptadaID
             ORDER BY c.fecha DESC
         `
         records, err := tx.Run(ctx, query, map[string]interface{}{
-            "claseID": claseID,
+            "claseID":  claseID,
+            "aprobado": EstadoModeracionAprobado,
         })
         if err != nil {
             return nil, err
@@ -44,12 +188,24 @@ func (s *ComentarioService) ObtenerComentariosPorClase(ctx context.Context, clas
             record := records.Record()
             comentario := models.Comentario{
                 ID:        record.Values[0].(string),
+                ClaseID:   claseID,
                 Autor:     record.Values[1].(string),
                 Fecha:     record.Values[2].(time.Time),
                 Titulo:    record.Values[3].(string),
                 Detalle:   record.Values[4].(string),
                 MeGusta:   int(record.Values[5].(int64)),
                 NoMeGusta: int(record.Values[6].(int64)),
+                Eliminado: record.Values[8].(bool),
+                EsPregunta: record.Values[11].(bool),
+            }
+            comentario.PadreID, _ = record.Values[7].(string)
+            comentario.RespuestaAceptadaID, _ = record.Values[12].(string)
+            if editadoEn, ok := record.Values[9].(time.Time); ok {
+                comentario.Editado = true
+                comentario.EditadoEn = &editadoEn
+            }
+            if moderacion := record.Values[10].(string); moderacion != EstadoModeracionAprobado {
+                comentario.EstadoModeracion = moderacion
             }
             comentarios = append(comentarios, comentario)
         }
@@ -68,7 +224,255 @@ func (s *ComentarioService) ObtenerComentariosPorClase(ctx context.Context, clas
     return result.([]models.Comentario), nil
 }
 
-// CrearComentarioParaClase crea un nuevo comentario asociado a una clase.
+// construirHilos anida las respuestas bajo sus comentarios padre a partir de la lista plana de
+// comentarios (ordenada del más reciente al más antiguo) y devuelve los hilos que responden a padreID
+// ("" para los comentarios principales). Los comentarios eliminados, ocultos o pendientes de moderación
+// se muestran como marcadores solo si tienen respuestas visibles; en caso contrario se omiten.
+func construirHilos(comentarios []models.Comentario, padreID string, profundidad int) []models.Comentario {
+    hijos := map[string][]models.Comentario{}
+    for _, comentario := range comentarios {
+        hijos[comentario.PadreID] = append(hijos[comentario.PadreID], comentario)
+    }
+
+    var construir func(padreID string, principal bool) []models.Comentario
+    construir = func(padreID string, principal bool) []models.Comentario {
+        hilos := []models.Comentario{}
+        respuestas := hijos[padreID]
+        for i := range respuestas {
+            // Las respuestas se muestran en orden cronológico
+            comentario := respuestas[i]
+            if !principal {
+                comentario = respuestas[len(respuestas)-1-i]
+            }
+
+            comentario.Respuestas = construir(comentario.ID, false)
+            comentario.CantRespuestas = len(comentario.Respuestas)
+            if comentario.Eliminado || comentario.EstadoModeracion != "" {
+                if comentario.CantRespuestas == 0 {
+                    continue
+                }
+                comentario.Autor = ""
+                comentario.Titulo = ""
+                comentario.Detalle = textoComentarioEliminado
+                if !comentario.Eliminado {
+                    comentario.Detalle = textoComentarioOculto
+                }
+            }
+            hilos = append(hilos, comentario)
+        }
+        return hilos
+    }
+
+    hilos := construir(padreID, padreID == "")
+    for i := range hilos {
+        truncarHilo(&hilos[i], profundidad)
+    }
+    return hilos
+}
+
+// truncarHilo elimina las respuestas que superan la profundidad indicada, conservando el contador
+// de respuestas. Un valor negativo no limita la profundidad.
+func truncarHilo(comentario *models.Comentario, profundidad int) {
+    if profundidad < 0 {
+        return
+    }
+    if profundidad == 0 {
+        comentario.Respuestas = nil
+        return
+    }
+    for i := range comentario.Respuestas {
+        truncarHilo(&comentario.Respuestas[i], profundidad-1)
+    }
+}
+
+// EliminarComentario elimina un comentario de clase a pedido de su autor o de un moderador. El
+// comentario se marca como eliminado para que sus respuestas sigan visibles bajo un marcador.
+func (s *ComentarioService) EliminarComentario(ctx context.Context, comentarioID, email, password string) error {
+    if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+        return err
+    }
+
+    session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+    defer session.Close(ctx)
+
+    _, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        query := `
+            MATCH (autor:Usuario)-[:COMENTO]->(c:Comentario {id: $id})
+            WHERE coalesce(c.eliminado, false) = false
+            WITH c, autor.email = $email OR $moderador AS permitido
+            FOREACH (_ IN CASE WHEN permitido THEN [1] ELSE [] END |
+                SET c.eliminado = true, c.eliminadoEn = datetime(), c.eliminadoPor = $email
+            )
+            RETURN permitido
+        `
+        res, err := tx.Run(ctx, query, map[string]interface{}{
+            "id":        comentarioID,
+            "email":     email,
+            "moderador": esModerador(email),
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(ctx) {
+            return nil, errors.New("comentario no encontrado")
+        }
+        if !res.Record().Values[0].(bool) {
+            return nil, errors.New("solo el autor o un moderador puede eliminar el comentario")
+        }
+        return nil, res.Err()
+    })
+
+    return err
+}
+
+// EditarComentario reemplaza el título y el detalle de un comentario de clase a pedido de su autor.
+// La versión anterior se agrega al historial de revisiones del comentario. Si el nuevo texto contiene
+// palabras filtradas, el comentario vuelve a quedar pendiente de moderación.
+func (s *ComentarioService) EditarComentario(ctx context.Context, comentarioID, email, password, titulo, detalle string) (*models.Comentario, error) {
+    if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+        return nil, err
+    }
+
+    session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+    defer session.Close(ctx)
+
+    result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        checkQuery := `
+            MATCH (autor:Usuario)-[:COMENTO]->(c:Comentario {id: $id})
+            WHERE coalesce(c.eliminado, false) = false
+            RETURN autor.email = $email AS esAutor
+        `
+        checkResult, err := tx.Run(ctx, checkQuery, map[string]interface{}{
+            "id":    comentarioID,
+            "email": email,
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !checkResult.Next(ctx) {
+            return nil, errors.New("comentario no encontrado")
+        }
+        if !checkResult.Record().Values[0].(bool) {
+            return nil, errors.New("solo el autor puede editar el comentario")
+        }
+
+        // Las propiedades se asignan en orden: primero se guarda la versión vigente en el historial
+        updateQuery := `
+            MATCH (c:Comentario {id: $id})-[:PERTENECE_A]->(clase:Clase)
+            OPTIONAL MATCH (c)-[:RESPONDE_A]->(padre:Comentario)
+            SET c.revisionesTitulo = coalesce(c.revisionesTitulo, []) + c.titulo,
+                c.revisionesDetalle = coalesce(c.revisionesDetalle, []) + c.detalle,
+                c.revisionesFecha = coalesce(c.revisionesFecha, []) + coalesce(c.editadoEn, c.fecha),
+                c.titulo = $titulo,
+                c.detalle = $detalle,
+                c.editadoEn = datetime(),
+                c.moderacion = coalesce($moderacion, c.moderacion)
+            RETURN c.id, clase.id, c.autor, c.fecha, c.titulo, c.detalle, c.meGusta, c.noMeGusta, padre.id, c.editadoEn,
+                   coalesce(c.moderacion, $aprobado), coalesce(c.esPregunta, false), c.respuestaAceptadaId
+        `
+        res, err := tx.Run(ctx, updateQuery, map[string]interface{}{
+            "id":         comentarioID,
+            "titulo":     titulo,
+            "detalle":    detalle,
+            "moderacion": estadoModeracionInicial(titulo, detalle),
+            "aprobado":   EstadoModeracionAprobado,
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(ctx) {
+            return nil, errors.New("comentario no encontrado")
+        }
+
+        record := res.Record()
+        editadoEn := record.Values[9].(time.Time)
+        comentario := &models.Comentario{
+            ID:        record.Values[0].(string),
+            ClaseID:   record.Values[1].(string),
+            Autor:     record.Values[2].(string),
+            Fecha:     record.Values[3].(time.Time),
+            Titulo:    record.Values[4].(string),
+            Detalle:   record.Values[5].(string),
+            MeGusta:   int(record.Values[6].(int64)),
+            NoMeGusta: int(record.Values[7].(int64)),
+            Editado:   true,
+            EditadoEn: &editadoEn,
+            EsPregunta: record.Values[11].(bool),
+        }
+        comentario.PadreID, _ = record.Values[8].(string)
+        comentario.RespuestaAceptadaID, _ = record.Values[12].(string)
+        if moderacion := record.Values[10].(string); moderacion != EstadoModeracionAprobado {
+            comentario.EstadoModeracion = moderacion
+        }
+        return comentario, res.Err()
+    })
+
+    if err != nil {
+        return nil, err
+    }
+
+    return result.(*models.Comentario), nil
+}
+
+// ObtenerRevisionesComentario obtiene las versiones anteriores de un comentario de clase, de la más
+// antigua a la más reciente. Como en el listado, el usuario debe poder ver el curso de la clase y la
+// clase no debe estar bloqueada para él.
+func (s *ComentarioService) ObtenerRevisionesComentario(ctx context.Context, comentarioID, email, password string) ([]models.RevisionComentario, error) {
+    session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+    defer session.Close(ctx)
+
+    var claseID string
+    result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        query := `
+            MATCH (c:Comentario {id: $id})-[:PERTENECE_A]->(clase:Clase)
+            WHERE coalesce(c.eliminado, false) = false AND coalesce(c.moderacion, $aprobado) = $aprobado
+            RETURN coalesce(c.revisionesTitulo, []), coalesce(c.revisionesDetalle, []), coalesce(c.revisionesFecha, []), clase.id
+        `
+        res, err := tx.Run(ctx, query, map[string]interface{}{
+            "id":       comentarioID,
+            "aprobado": EstadoModeracionAprobado,
+        })
+        if err != nil {
+            return nil, err
+        }
+        if !res.Next(ctx) {
+            return nil, errors.New("comentario no encontrado")
+        }
+
+        record := res.Record()
+        titulos := record.Values[0].([]interface{})
+        detalles := record.Values[1].([]interface{})
+        fechas := record.Values[2].([]interface{})
+        claseID, _ = record.Values[3].(string)
+
+        revisiones := []models.RevisionComentario{}
+        for i := range detalles {
+            revision := models.RevisionComentario{}
+            revision.Texto, _ = detalles[i].(string)
+            if i < len(titulos) {
+                revision.Titulo, _ = titulos[i].(string)
+            }
+            if i < len(fechas) {
+                revision.Fecha, _ = fechas[i].(time.Time)
+            }
+            revisiones = append(revisiones, revision)
+        }
+        return revisiones, res.Err()
+    })
+
+    if err != nil {
+        return nil, err
+    }
+
+    if err := s.verificarClaseVisible(ctx, claseID, email, password); err != nil {
+        return nil, err
+    }
+
+    return result.([]models.RevisionComentario), nil
+}
+
+// CrearComentarioParaClase crea un nuevo comentario asociado a una clase. Si el comentario queda
+// visible, se notifica al autor del comentario al que responde y a los usuarios mencionados.
 func (s *ComentarioService) CrearComentarioParaClase(ctx context.Context, claseID string, comentario *models.Comentario) (*models.Comentario, error) {
     // Verificar el usuario en Redis
     key := "usuario:" + comentario.Autor + ":" + comentario.Password
@@ -84,9 +488,15 @@ func (s *ComentarioService) CrearComentarioParaClase(ctx context.Context, claseI
         return nil, err
     }
 
+    // Solo los comentarios principales pueden ser preguntas
+    if comentario.EsPregunta && comentario.PadreID != "" {
+        return nil, errors.New("una respuesta no puede ser una pregunta")
+    }
+
     session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
     defer session.Close(ctx)
 
+    var autorPadre string
     result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
         // Verificar que la clase existe
         checkQuery := `
@@ -107,32 +517,68 @@ func (s *ComentarioService) CrearComentarioParaClase(ctx context.Context, claseI
             return nil, errors.New("clase no encontrada")
         }
 
+        // Si es una respuesta, verificar que el comentario padre pertenece a la misma clase y es visible
+        if comentario.PadreID != "" {
+            padreQuery := `
+                MATCH (padre:Comentario {id: $padreID})-[:PERTENECE_A]->(:Clase {id: $claseID})
+                WHERE coalesce(padre.moderacion, $aprobado) = $aprobado
+                RETURN coalesce(padre.eliminado, false) AS eliminado
+            `
+            padreResult, err := tx.Run(ctx, padreQuery, map[string]interface{}{
+                "padreID":  comentario.PadreID,
+                "claseID":  claseID,
+                "aprobado": EstadoModeracionAprobado,
+            })
+            if err != nil {
+                return nil, err
+            }
+            if !padreResult.Next(ctx) {
+                return nil, errors.New("comentario padre no encontrado")
+            }
+            if padreResult.Record().Values[0].(bool) {
+                return nil, errors.New("no se puede responder un comentario eliminado")
+            }
+        }
+
         // Generar un ID único para el comentario
         comentarioID := uuid.New().String()
         comentario.ID = comentarioID
+        moderacion := estadoModeracionInicial(comentario.Titulo, comentario.Detalle)
 
         // Crear el comentario y las relaciones
         createQuery := `
-            MATCH (u:User {email: $autor}), (clase:Clase {id: $claseID})
-            CREATE (u)-[:COMENTÓ]->(c:Comment {
+            MATCH (u:Usuario {email: $autor}), (clase:Clase {id: $claseID})
+            CREATE (u)-[:COMENTO]->(c:Comentario {
                 id: $id,
                 autor: $autor,
                 fecha: datetime(),
                 titulo: $titulo,
                 detalle: $detalle,
-                meGusta: $meGusta,
-                noMeGusta: $noMeGusta
-            })-[:PERTENECE_A]->(:Course)-[:CONTENEDOR_DE]->(clase)
-            RETURN c.id, c.autor, c.fecha, c.titulo, c.detalle, c.meGusta, c.noMeGusta
+                meGusta: 0,
+                noMeGusta: 0,
+                moderacion: $moderacion,
+                notificarAlAprobar: $notificarAlAprobar,
+                esPregunta: $esPregunta
+            })-[:PERTENECE_A]->(clase)
+            WITH c
+            OPTIONAL MATCH (padre:Comentario {id: $padreID})
+            FOREACH (_ IN CASE WHEN padre IS NULL THEN [] ELSE [1] END |
+                CREATE (c)-[:RESPONDE_A]->(padre)
+            )
+            RETURN c.id, c.autor, c.fecha, c.titulo, c.detalle, c.meGusta, c.noMeGusta, c.moderacion, c.esPregunta, padre.autor
         `
         res, err := tx.Run(ctx, createQuery, map[string]interface{}{
             "id":        comentario.ID,
             "autor":     comentario.Autor,
             "titulo":    comentario.Titulo,
             "detalle":   comentario.Detalle,
-            "meGusta":   comentario.MeGusta,
-            "noMeGusta": comentario.NoMeGusta,
             "claseID":   claseID,
+            "padreID":   comentario.PadreID,
+            "esPregunta": comentario.EsPregunta,
+            // Los comentarios con palabras filtradas quedan pendientes hasta que un moderador los apruebe, y
+            // sus notificaciones se envían recién entonces
+            "moderacion":         moderacion,
+            "notificarAlAprobar": notificarAlAprobar(moderacion),
         })
         if err != nil {
             return nil, err
@@ -145,12 +591,18 @@ func (s *ComentarioService) CrearComentarioParaClase(ctx context.Context, claseI
         record := res.Record()
         // Actualizar los campos del comentario con los retornados desde Neo4j
         comentario.ID = record.Values[0].(string)
+        comentario.ClaseID = claseID
         comentario.Autor = record.Values[1].(string)
         comentario.Fecha = record.Values[2].(time.Time)
         comentario.Titulo = record.Values[3].(string)
         comentario.Detalle = record.Values[4].(string)
         comentario.MeGusta = int(record.Values[5].(int64))
         comentario.NoMeGusta = int(record.Values[6].(int64))
+        comentario.EstadoModeracion, _ = record.Values[7].(string)
+        comentario.EsPregunta = record.Values[8].(bool)
+        autorPadre, _ = record.Values[9].(string)
+        comentario.Password = ""
+        comentario.Respuestas = nil
 
         // Consumir el resultado
         if err = res.Err(); err != nil {
@@ -164,5 +616,27 @@ func (s *ComentarioService) CrearComentarioParaClase(ctx context.Context, claseI
         return nil, err
     }
 
-    return result.(*models.Comentario), nil
+    creado := result.(*models.Comentario)
+    if creado.EstadoModeracion == "" {
+        notificarComentarioClase(ctx, s.Notificaciones, claseID, creado.ID, creado.Autor, u.Nombre, autorPadre, creado.Titulo, creado.Detalle)
+    }
+
+    return creado, nil
+}
+
+// notificarComentarioClase notifica la publicación de un comentario de clase al autor del comentario
+// al que responde (si lo hay) y a los usuarios mencionados. Se usa al crear el comentario y, si quedó
+// pendiente de moderación, al aprobarlo.
+func notificarComentarioClase(ctx context.Context, notificaciones *NotificacionService, claseID, comentarioID, autor, nombreAutor, autorPadre, titulo, detalle string) {
+    if autorPadre != "" && autorPadre != autor {
+        notificaciones.notificarEnSegundoPlano(autorPadre, NotificacionRespuesta,
+            nombreAutor+" respondió tu comentario", map[string]string{
+                "clase_id":      claseID,
+                "comentario_id": comentarioID,
+                "autor":         nombreAutor,
+                "titulo":        titulo,
+            })
+    }
+    notificaciones.NotificarMenciones(ctx, autor, nombreAutor+" te mencionó en un comentario",
+        map[string]string{"clase_id": claseID, "comentario_id": comentarioID}, titulo, detalle)
 }
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"go-API/correo"
+	"log"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// CorreoService genera los correos a partir de las plantillas, en el idioma de cada usuario, y los
+// deja en la cola de envío.
+type CorreoService struct {
+	Cola        *correo.Cola
+	RedisClient *redis.Client
+}
+
+func NewCorreoService(cola *correo.Cola, redisClient *redis.Client) *CorreoService {
+	return &CorreoService{
+		Cola:        cola,
+		RedisClient: redisClient,
+	}
+}
+
+// Enviar encola el correo de una plantilla para un usuario. Si el email no corresponde a un usuario
+// registrado no se envía nada.
+func (s *CorreoService) Enviar(ctx context.Context, email, plantilla string, datos map[string]string) error {
+	usuario, _, err := obtenerUsuarioPorEmail(ctx, s.RedisClient, email)
+	if err != nil {
+		if err.Error() == "usuario no encontrado" {
+			return nil
+		}
+		return err
+	}
+
+	mensaje, err := correo.Renderizar(plantilla, usuario.Idioma, usuario.Email, correo.DatosPlantilla{
+		Nombre: usuario.Nombre,
+		Datos:  datos,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.Cola.Encolar(mensaje)
+}
+
+// encolar encola un correo registrando el error en el log en lugar de devolverlo, para que un fallo
+// al generarlo o encolarlo no haga fallar la operación que lo originó. El envío lo hace la cola.
+func (s *CorreoService) encolar(ctx context.Context, email, plantilla string, datos map[string]string) {
+	if s == nil {
+		return
+	}
+	if err := s.Enviar(ctx, email, plantilla, datos); err != nil {
+		log.Printf("Error al enviar el correo %s a %s: %v", plantilla, email, err)
+	}
+}
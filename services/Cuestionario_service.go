@@ -0,0 +1,457 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-API/models"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	TipoPreguntaOpcionMultiple = "opcion_multiple"
+	TipoPreguntaVerdaderoFalso = "verdadero_falso"
+	TipoPreguntaRespuestaCorta = "respuesta_corta"
+
+	// maxReintentosIntento es la cantidad de veces que se reintenta registrar un intento enviado al
+	// mismo tiempo que otro del mismo usuario
+	maxReintentosIntento = 3
+)
+
+// CuestionarioService gestiona los cuestionarios de las clases y las unidades y los intentos de los
+// usuarios, que se corrigen automáticamente al enviarse.
+type CuestionarioService struct {
+	CuestionarioCollection *mongo.Collection
+	IntentoCollection      *mongo.Collection
+	CursoCollection        *mongo.Collection
+	UnidadCollection       *mongo.Collection
+	ClaseCollection        *mongo.Collection
+	RedisClient            *redis.Client
+}
+
+func NewCuestionarioService(db *mongo.Database, redisClient *redis.Client) *CuestionarioService {
+	return &CuestionarioService{
+		CuestionarioCollection: db.Collection("cuestionarios"),
+		IntentoCollection:      db.Collection("intentos_cuestionario"),
+		CursoCollection:        db.Collection("cursos"),
+		UnidadCollection:       db.Collection("unidades"),
+		ClaseCollection:        db.Collection("clases"),
+		RedisClient:            redisClient,
+	}
+}
+
+// CrearCuestionarioParaClase crea un cuestionario en una clase. Solo el instructor del curso o un
+// administrador puede hacerlo.
+func (s *CuestionarioService) CrearCuestionarioParaClase(ctx context.Context, claseID, email, password string, cuestionario *models.Cuestionario) (*models.Cuestionario, error) {
+	claseObjectID, err := primitive.ObjectIDFromHex(claseID)
+	if err != nil {
+		return nil, errors.New("ID de clase inválido")
+	}
+
+	var clase models.Clase
+	if err := s.ClaseCollection.FindOne(ctx, bson.M{"_id": claseObjectID}).Decode(&clase); err == mongo.ErrNoDocuments {
+		return nil, errors.New("clase no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+
+	cuestionario.ClaseID = &claseObjectID
+	cuestionario.UnidadID = nil
+	return s.crearCuestionario(ctx, clase.UnidadID, email, password, cuestionario)
+}
+
+// CrearCuestionarioParaUnidad crea un cuestionario en una unidad. Solo el instructor del curso o un
+// administrador puede hacerlo. Los cuestionarios de unidad no bloquean el avance en las clases.
+func (s *CuestionarioService) CrearCuestionarioParaUnidad(ctx context.Context, unidadID, email, password string, cuestionario *models.Cuestionario) (*models.Cuestionario, error) {
+	unidadObjectID, err := primitive.ObjectIDFromHex(unidadID)
+	if err != nil {
+		return nil, errors.New("ID de unidad inválido")
+	}
+
+	cuestionario.UnidadID = &unidadObjectID
+	cuestionario.ClaseID = nil
+	cuestionario.Requerido = false
+	return s.crearCuestionario(ctx, unidadObjectID, email, password, cuestionario)
+}
+
+func (s *CuestionarioService) crearCuestionario(ctx context.Context, unidadID primitive.ObjectID, email, password string, cuestionario *models.Cuestionario) (*models.Cuestionario, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	var unidad models.Unidad
+	if err := s.UnidadCollection.FindOne(ctx, bson.M{"_id": unidadID}).Decode(&unidad); err == mongo.ErrNoDocuments {
+		return nil, errors.New("unidad no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+
+	var curso models.Curso
+	if err := s.CursoCollection.FindOne(ctx, bson.M{"_id": unidad.IDcurso}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if curso.Instructor != email && !esAdmin(email) {
+		return nil, errors.New("solo el instructor del curso o un administrador puede crear cuestionarios")
+	}
+
+	if err := validarCuestionario(cuestionario); err != nil {
+		return nil, err
+	}
+
+	cuestionario.ID = primitive.NewObjectID()
+	cuestionario.CursoID = curso.ID
+	cuestionario.Fecha = time.Now()
+	if _, err := s.CuestionarioCollection.InsertOne(ctx, cuestionario); err != nil {
+		return nil, err
+	}
+
+	return cuestionario, nil
+}
+
+// validarCuestionario verifica las preguntas del cuestionario y les asigna un ID.
+func validarCuestionario(cuestionario *models.Cuestionario) error {
+	if len(cuestionario.Preguntas) == 0 {
+		return errors.New("el cuestionario debe tener al menos una pregunta")
+	}
+	if cuestionario.IntentosMaximos < 0 {
+		return errors.New("la cantidad de intentos no puede ser negativa")
+	}
+	if cuestionario.PuntajeAprobacion < 0 || cuestionario.PuntajeAprobacion > 100 {
+		return errors.New("el puntaje de aprobación debe estar entre 0 y 100")
+	}
+
+	for i := range cuestionario.Preguntas {
+		pregunta := &cuestionario.Preguntas[i]
+		invalida := func(motivo string) error {
+			return fmt.Errorf("pregunta %d inválida: %s", i+1, motivo)
+		}
+
+		if strings.TrimSpace(pregunta.Enunciado) == "" {
+			return invalida("el enunciado es requerido")
+		}
+		if pregunta.Puntos <= 0 {
+			pregunta.Puntos = 1
+		}
+
+		switch pregunta.Tipo {
+		case TipoPreguntaOpcionMultiple:
+			if len(pregunta.Opciones) < 2 {
+				return invalida("debe tener al menos dos opciones")
+			}
+			if pregunta.OpcionCorrecta == nil || *pregunta.OpcionCorrecta < 0 || *pregunta.OpcionCorrecta >= len(pregunta.Opciones) {
+				return invalida("la opción correcta debe ser el índice de una de las opciones")
+			}
+			pregunta.RespuestaVF = nil
+			pregunta.RespuestasAceptadas = nil
+		case TipoPreguntaVerdaderoFalso:
+			if pregunta.RespuestaVF == nil {
+				return invalida("la respuesta verdadero o falso es requerida")
+			}
+			pregunta.Opciones = nil
+			pregunta.OpcionCorrecta = nil
+			pregunta.RespuestasAceptadas = nil
+		case TipoPreguntaRespuestaCorta:
+			aceptadas := []string{}
+			for _, respuesta := range pregunta.RespuestasAceptadas {
+				if strings.TrimSpace(respuesta) != "" {
+					aceptadas = append(aceptadas, respuesta)
+				}
+			}
+			if len(aceptadas) == 0 {
+				return invalida("debe tener al menos una respuesta aceptada")
+			}
+			pregunta.RespuestasAceptadas = aceptadas
+			pregunta.Opciones = nil
+			pregunta.OpcionCorrecta = nil
+			pregunta.RespuestaVF = nil
+		default:
+			return invalida("el tipo debe ser opcion_multiple, verdadero_falso o respuesta_corta")
+		}
+
+		pregunta.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// ObtenerCuestionariosPorClase obtiene los cuestionarios de una clase, sin sus respuestas. El usuario
+// debe poder ver el curso de la clase y la unidad de la clase no debe estar bloqueada para él.
+func (s *CuestionarioService) ObtenerCuestionariosPorClase(ctx context.Context, claseID, email, password string) ([]models.Cuestionario, error) {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarUnidadDisponible(ctx, s.RedisClient, s.CursoCollection, s.UnidadCollection, cursoID, clase.UnidadID, email, password); err != nil {
+		return nil, err
+	}
+	return s.buscarCuestionarios(ctx, bson.M{"clase_id": clase.ID})
+}
+
+// ObtenerCuestionariosPorUnidad obtiene los cuestionarios de una unidad, sin sus respuestas. El
+// usuario debe poder ver el curso de la unidad y la unidad no debe estar bloqueada para él.
+func (s *CuestionarioService) ObtenerCuestionariosPorUnidad(ctx context.Context, unidadID, email, password string) ([]models.Cuestionario, error) {
+	objectID, err := primitive.ObjectIDFromHex(unidadID)
+	if err != nil {
+		return nil, errors.New("ID de unidad inválido")
+	}
+
+	var unidad models.Unidad
+	if err := s.UnidadCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&unidad); err == mongo.ErrNoDocuments {
+		return nil, errors.New("unidad no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+	if err := verificarUnidadDisponible(ctx, s.RedisClient, s.CursoCollection, s.UnidadCollection, unidad.IDcurso, unidad.ID, email, password); err != nil {
+		return nil, err
+	}
+	return s.buscarCuestionarios(ctx, bson.M{"unidad_id": objectID})
+}
+
+func (s *CuestionarioService) buscarCuestionarios(ctx context.Context, filtro bson.M) ([]models.Cuestionario, error) {
+	cursor, err := s.CuestionarioCollection.Find(ctx, filtro, options.Find().SetSort(bson.D{{Key: "fecha", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var encontrados []models.Cuestionario
+	if err := cursor.All(ctx, &encontrados); err != nil {
+		return nil, err
+	}
+
+	cuestionarios := []models.Cuestionario{}
+	for _, cuestionario := range encontrados {
+		cuestionarios = append(cuestionarios, cuestionario.SinRespuestas())
+	}
+	return cuestionarios, nil
+}
+
+// ObtenerCuestionario obtiene un cuestionario, sin sus respuestas, si el usuario puede ver su curso y
+// la unidad del cuestionario no está bloqueada para él.
+func (s *CuestionarioService) ObtenerCuestionario(ctx context.Context, id, email, password string) (*models.Cuestionario, error) {
+	cuestionario, err := s.obtenerCuestionario(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	unidadID, err := s.unidadDeCuestionario(ctx, cuestionario)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarUnidadDisponible(ctx, s.RedisClient, s.CursoCollection, s.UnidadCollection, cuestionario.CursoID, unidadID, email, password); err != nil {
+		return nil, err
+	}
+
+	sinRespuestas := cuestionario.SinRespuestas()
+	return &sinRespuestas, nil
+}
+
+func (s *CuestionarioService) obtenerCuestionario(ctx context.Context, id string) (*models.Cuestionario, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+
+	var cuestionario models.Cuestionario
+	if err := s.CuestionarioCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&cuestionario); err == mongo.ErrNoDocuments {
+		return nil, errors.New("cuestionario no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+	return &cuestionario, nil
+}
+
+// unidadDeCuestionario obtiene el ID de la unidad de un cuestionario: la suya, o la de su clase.
+func (s *CuestionarioService) unidadDeCuestionario(ctx context.Context, cuestionario *models.Cuestionario) (primitive.ObjectID, error) {
+	if cuestionario.UnidadID != nil {
+		return *cuestionario.UnidadID, nil
+	}
+
+	var clase models.Clase
+	err := s.ClaseCollection.FindOne(ctx, bson.M{"_id": cuestionario.ClaseID}).Decode(&clase)
+	if err == mongo.ErrNoDocuments || (err == nil && clase.UnidadID.IsZero()) {
+		return primitive.NilObjectID, errors.New("clase no encontrada")
+	} else if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return clase.UnidadID, nil
+}
+
+// EnviarIntento corrige y registra un intento de un usuario inscrito en el curso del cuestionario, si
+// la unidad del cuestionario no está bloqueada para él. Las preguntas sin responder cuentan como
+// incorrectas.
+func (s *CuestionarioService) EnviarIntento(ctx context.Context, id, email, password string, respuestas []models.RespuestaIntento) (*models.IntentoCuestionario, error) {
+	cuestionario, err := s.obtenerCuestionario(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	usuario, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cuestionario.CursoID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	unidadID, err := s.unidadDeCuestionario(ctx, cuestionario)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarUnidadDesbloqueada(ctx, s.CursoCollection, s.UnidadCollection, cuestionario.CursoID, unidadID, usuario); err != nil {
+		return nil, err
+	}
+
+	intento := corregirIntento(cuestionario, respuestas)
+	intento.Email = email
+
+	// El índice único sobre el número de intento impide que dos envíos simultáneos usen el mismo
+	// número; el envío que pierde vuelve a contar los intentos y a verificar el límite
+	for reintento := 0; ; reintento++ {
+		previos, err := s.IntentoCollection.CountDocuments(ctx, bson.M{"cuestionario_id": cuestionario.ID, "email": email})
+		if err != nil {
+			return nil, err
+		}
+		if cuestionario.IntentosMaximos > 0 && int(previos) >= cuestionario.IntentosMaximos {
+			return nil, errors.New("se alcanzó el límite de intentos del cuestionario")
+		}
+
+		intento.ID = primitive.NewObjectID()
+		intento.Numero = int(previos) + 1
+		intento.Fecha = time.Now()
+
+		_, err = s.IntentoCollection.InsertOne(ctx, intento)
+		if err == nil {
+			return intento, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) || reintento == maxReintentosIntento {
+			return nil, err
+		}
+	}
+}
+
+// CrearIndices crea el índice único que impide registrar dos intentos de un usuario con el mismo número.
+func (s *CuestionarioService) CrearIndices(ctx context.Context) error {
+	_, err := s.IntentoCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "cuestionario_id", Value: 1}, {Key: "email", Value: 1}, {Key: "numero", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// corregirIntento compara las respuestas con las del cuestionario. Las respuestas cortas se
+// comparan sin distinguir mayúsculas, tildes ni puntuación.
+func corregirIntento(cuestionario *models.Cuestionario, respuestas []models.RespuestaIntento) *models.IntentoCuestionario {
+	porPregunta := map[string]models.RespuestaIntento{}
+	for _, respuesta := range respuestas {
+		porPregunta[respuesta.PreguntaID] = respuesta
+	}
+
+	intento := &models.IntentoCuestionario{
+		CuestionarioID: cuestionario.ID,
+		Respuestas:     []models.RespuestaIntento{},
+	}
+	for _, pregunta := range cuestionario.Preguntas {
+		respuesta := porPregunta[pregunta.ID]
+		respuesta.PreguntaID = pregunta.ID
+
+		switch pregunta.Tipo {
+		case TipoPreguntaOpcionMultiple:
+			respuesta.Correcta = respuesta.Opcion != nil && pregunta.OpcionCorrecta != nil && *respuesta.Opcion == *pregunta.OpcionCorrecta
+		case TipoPreguntaVerdaderoFalso:
+			respuesta.Correcta = respuesta.Valor != nil && pregunta.RespuestaVF != nil && *respuesta.Valor == *pregunta.RespuestaVF
+		case TipoPreguntaRespuestaCorta:
+			texto := normalizarTexto(respuesta.Texto)
+			for _, aceptada := range pregunta.RespuestasAceptadas {
+				if texto != "" && texto == normalizarTexto(aceptada) {
+					respuesta.Correcta = true
+					break
+				}
+			}
+		}
+
+		intento.PuntajeMaximo += pregunta.Puntos
+		if respuesta.Correcta {
+			intento.Puntaje += pregunta.Puntos
+		}
+		intento.Respuestas = append(intento.Respuestas, respuesta)
+	}
+
+	if intento.PuntajeMaximo > 0 {
+		intento.Porcentaje = float64(intento.Puntaje) * 100 / float64(intento.PuntajeMaximo)
+	}
+	intento.Aprobado = intento.Porcentaje >= cuestionario.PuntajeAprobacion
+	return intento
+}
+
+// ObtenerResultados obtiene los intentos de un usuario sobre un cuestionario y su mejor puntaje.
+func (s *CuestionarioService) ObtenerResultados(ctx context.Context, id, email, password string) (*models.ResultadosCuestionario, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	cuestionario, err := s.obtenerCuestionario(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.IntentoCollection.Find(ctx, bson.M{"cuestionario_id": cuestionario.ID, "email": email},
+		options.Find().SetSort(bson.D{{Key: "numero", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	resultados := &models.ResultadosCuestionario{
+		CuestionarioID: cuestionario.ID,
+		Intentos:       []models.IntentoCuestionario{},
+	}
+	if err := cursor.All(ctx, &resultados.Intentos); err != nil {
+		return nil, err
+	}
+
+	for _, intento := range resultados.Intentos {
+		if intento.Puntaje > resultados.MejorPuntaje {
+			resultados.MejorPuntaje = intento.Puntaje
+			resultados.MejorPorcentaje = intento.Porcentaje
+		}
+		resultados.Aprobado = resultados.Aprobado || intento.Aprobado
+	}
+
+	if cuestionario.IntentosMaximos > 0 {
+		restantes := cuestionario.IntentosMaximos - len(resultados.Intentos)
+		if restantes < 0 {
+			restantes = 0
+		}
+		resultados.IntentosRestantes = &restantes
+	}
+
+	return resultados, nil
+}
+
+// cuestionarioPendiente indica si la clase tiene un cuestionario requerido que el usuario todavía
+// no aprobó.
+func (s *CuestionarioService) cuestionarioPendiente(ctx context.Context, email string, claseID primitive.ObjectID) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+
+	ids, err := s.CuestionarioCollection.Distinct(ctx, "_id", bson.M{"clase_id": claseID, "requerido": true})
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range ids {
+		err := s.IntentoCollection.FindOne(ctx, bson.M{"cuestionario_id": id, "email": email, "aprobado": true}).Err()
+		if err == mongo.ErrNoDocuments {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
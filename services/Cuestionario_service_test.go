@@ -0,0 +1,141 @@
+package services
+
+import (
+	"testing"
+
+	"go-API/models"
+)
+
+func TestCorregirIntento(t *testing.T) {
+	uno, dos := 1, 2
+	verdadero, falso := true, false
+	preguntas := []models.PreguntaCuestionario{
+		{ID: "p1", Tipo: TipoPreguntaOpcionMultiple, Opciones: []string{"a", "b", "c"}, Puntos: 2, OpcionCorrecta: &uno},
+		{ID: "p2", Tipo: TipoPreguntaVerdaderoFalso, Puntos: 1, RespuestaVF: &verdadero},
+		{ID: "p3", Tipo: TipoPreguntaRespuestaCorta, Puntos: 1, RespuestasAceptadas: []string{"Región Metropolitana", "RM"}},
+	}
+
+	casos := []struct {
+		nombre     string
+		aprobacion float64
+		respuestas []models.RespuestaIntento
+		correctas  []bool
+		puntaje    int
+		porcentaje float64
+		aprobado   bool
+	}{
+		{
+			nombre:     "todas correctas",
+			aprobacion: 60,
+			respuestas: []models.RespuestaIntento{
+				{PreguntaID: "p1", Opcion: &uno},
+				{PreguntaID: "p2", Valor: &verdadero},
+				{PreguntaID: "p3", Texto: "region metropolitana"},
+			},
+			correctas:  []bool{true, true, true},
+			puntaje:    4,
+			porcentaje: 100,
+			aprobado:   true,
+		},
+		{
+			nombre:     "todas incorrectas",
+			aprobacion: 60,
+			respuestas: []models.RespuestaIntento{
+				{PreguntaID: "p1", Opcion: &dos},
+				{PreguntaID: "p2", Valor: &falso},
+				{PreguntaID: "p3", Texto: "Valparaíso"},
+			},
+			correctas: []bool{false, false, false},
+		},
+		{
+			nombre:     "respuesta corta sin mayúsculas, tildes ni puntuación",
+			aprobacion: 25,
+			respuestas: []models.RespuestaIntento{
+				{PreguntaID: "p3", Texto: "  ¡REGIÓN   metropolitana!  "},
+			},
+			correctas:  []bool{false, false, true},
+			puntaje:    1,
+			porcentaje: 25,
+			aprobado:   true,
+		},
+		{
+			nombre:     "respuesta corta vacía o solo puntuación",
+			aprobacion: 0,
+			respuestas: []models.RespuestaIntento{
+				{PreguntaID: "p3", Texto: "?!"},
+			},
+			correctas: []bool{false, false, false},
+			aprobado:  true,
+		},
+		{
+			nombre:     "sin responder",
+			aprobacion: 50,
+			correctas:  []bool{false, false, false},
+		},
+		{
+			nombre:     "respuestas a preguntas que no existen",
+			aprobacion: 50,
+			respuestas: []models.RespuestaIntento{
+				{PreguntaID: "p9", Opcion: &uno},
+			},
+			correctas: []bool{false, false, false},
+		},
+		{
+			nombre:     "justo en el puntaje de aprobación",
+			aprobacion: 50,
+			respuestas: []models.RespuestaIntento{
+				{PreguntaID: "p1", Opcion: &uno},
+			},
+			correctas:  []bool{true, false, false},
+			puntaje:    2,
+			porcentaje: 50,
+			aprobado:   true,
+		},
+		{
+			nombre:     "bajo el puntaje de aprobación",
+			aprobacion: 75,
+			respuestas: []models.RespuestaIntento{
+				{PreguntaID: "p1", Opcion: &uno},
+				{PreguntaID: "p2", Valor: &falso},
+			},
+			correctas:  []bool{true, false, false},
+			puntaje:    2,
+			porcentaje: 50,
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			cuestionario := &models.Cuestionario{Preguntas: preguntas, PuntajeAprobacion: caso.aprobacion}
+			intento := corregirIntento(cuestionario, caso.respuestas)
+
+			if len(intento.Respuestas) != len(preguntas) {
+				t.Fatalf("respuestas = %d, se esperaba una por pregunta (%d)", len(intento.Respuestas), len(preguntas))
+			}
+			for i, respuesta := range intento.Respuestas {
+				if respuesta.PreguntaID != preguntas[i].ID {
+					t.Errorf("respuesta %d es de la pregunta %q, se esperaba %q", i, respuesta.PreguntaID, preguntas[i].ID)
+				}
+				if respuesta.Correcta != caso.correctas[i] {
+					t.Errorf("pregunta %q correcta = %v, se esperaba %v", respuesta.PreguntaID, respuesta.Correcta, caso.correctas[i])
+				}
+			}
+			if intento.Puntaje != caso.puntaje || intento.PuntajeMaximo != 4 {
+				t.Errorf("puntaje = %d/%d, se esperaba %d/4", intento.Puntaje, intento.PuntajeMaximo, caso.puntaje)
+			}
+			if intento.Porcentaje != caso.porcentaje {
+				t.Errorf("porcentaje = %v, se esperaba %v", intento.Porcentaje, caso.porcentaje)
+			}
+			if intento.Aprobado != caso.aprobado {
+				t.Errorf("aprobado = %v, se esperaba %v", intento.Aprobado, caso.aprobado)
+			}
+		})
+	}
+}
+
+func TestCorregirIntentoSinPreguntas(t *testing.T) {
+	intento := corregirIntento(&models.Cuestionario{PuntajeAprobacion: 60}, nil)
+	if intento.PuntajeMaximo != 0 || intento.Porcentaje != 0 || intento.Aprobado {
+		t.Errorf("intento = %+v, se esperaba sin puntaje y no aprobado", intento)
+	}
+}
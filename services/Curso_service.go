@@ -5,11 +5,15 @@ import (
     "errors"
     "go-API/models"
     "log"
+    "strings"
+    "time"
 
     "go.mongodb.org/mongo-driver/bson"
     "go.mongodb.org/mongo-driver/bson/primitive"
     "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
 
+    "github.com/go-redis/redis/v8"
     "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
@@ -18,23 +22,48 @@ type CursoService struct {
     CursoCollection  *mongo.Collection
     UnidadCollection *mongo.Collection
     ClaseCollection  *mongo.Collection
+    AuditoriaCollection *mongo.Collection
     Driver           neo4j.DriverWithContext
+    RedisClient      *redis.Client
+    historial        *historialVersiones
 }
 
 // NewCursoService crea un nuevo servicio para los cursos.
-func NewCursoService(db *mongo.Database, driver neo4j.DriverWithContext) *CursoService {
+func NewCursoService(db *mongo.Database, driver neo4j.DriverWithContext, redisClient *redis.Client) *CursoService {
     return &CursoService{
         CursoCollection:  db.Collection("cursos"),
         UnidadCollection: db.Collection("unidades"),
         ClaseCollection:  db.Collection("clases"),
+        AuditoriaCollection: db.Collection("auditoria_valoraciones"),
         Driver:           driver,
+        RedisClient:      redisClient,
+        historial:        nuevoHistorialVersiones(db),
     }
 }
 
-// ObtenerCursos obtiene todos los cursos de la base de datos.
-func (s *CursoService) ObtenerCursos() ([]models.Curso, error) {
+// ObtenerCursos obtiene los cursos publicados de la base de datos. Si se indican credenciales se
+// incluyen también los cursos en cualquier estado de los que el usuario es instructor, o todos si es
+// administrador. Si orden es "valoracion" se ordenan por valoración ponderada, de mayor a menor.
+func (s *CursoService) ObtenerCursos(orden, email, password string) ([]models.Curso, error) {
+    usuario, err := usuarioOpcional(context.TODO(), s.RedisClient, email, password)
+    if err != nil {
+        return nil, err
+    }
+
+    filtro := filtroCursosPublicados
+    if usuario != nil && esAdmin(usuario.Email) {
+        filtro = bson.M{}
+    } else if usuario != nil {
+        filtro = bson.M{"$or": bson.A{filtroCursosPublicados, bson.M{"instructor": usuario.Email}}}
+    }
+
+    opciones := options.Find()
+    if orden == "valoracion" {
+        opciones.SetSort(bson.D{{Key: "valoracion_ponderada", Value: -1}, {Key: "valoracion", Value: -1}})
+    }
+
     var cursos []models.Curso
-    cursor, err := s.CursoCollection.Find(context.TODO(), bson.M{})
+    cursor, err := s.CursoCollection.Find(context.TODO(), filtro, opciones)
     if err != nil {
         return nil, err
     }
@@ -44,8 +73,22 @@ func (s *CursoService) ObtenerCursos() ([]models.Curso, error) {
     return cursos, nil
 }
 
-// CrearCurso agrega un nuevo curso a la base de datos y crea el nodo Course en Neo4j.
-func (s *CursoService) CrearCurso(curso models.Curso) (*mongo.InsertOneResult, error) {
+// CrearCurso agrega un nuevo curso a la base de datos y crea el nodo Course en Neo4j. El curso se
+// crea como borrador y no aparece en el catálogo hasta que se publica. El instructor es el usuario de
+// las credenciales; solo un administrador puede crear un curso a nombre de otro instructor.
+func (s *CursoService) CrearCurso(curso models.Curso, email, password string) (*mongo.InsertOneResult, error) {
+    usuario, err := obtenerUsuarioRedis(context.TODO(), s.RedisClient, email, password)
+    if err != nil {
+        return nil, err
+    }
+    if curso.Instructor == "" {
+        curso.Instructor = usuario.Email
+    } else if curso.Instructor != usuario.Email && !esAdmin(usuario.Email) {
+        return nil, errors.New("solo un administrador puede crear un curso para otro instructor")
+    }
+
+    curso.Estado = models.EstadoCursoBorrador
+
     // Verificar si las listas son nulas e inicializarlas como vacías
     if curso.Unidades == nil {
         curso.Unidades = []primitive.ObjectID{}
@@ -72,13 +115,21 @@ func (s *CursoService) CrearCurso(curso models.Curso) (*mongo.InsertOneResult, e
         createQuery := `
             CREATE (c:Curso {
                 id: $id,
-                nombre: $nombre
+                nombre: $nombre,
+                instructor: $instructor
             })
         `
 
+        // Sin instructor la propiedad queda sin valor
+        var instructor interface{}
+        if curso.Instructor != "" {
+            instructor = curso.Instructor
+        }
+
         _, err := tx.Run(context.TODO(), createQuery, map[string]interface{}{
-            "id":     cursoIDHex,
-            "nombre": curso.Nombre,
+            "id":         cursoIDHex,
+            "nombre":     curso.Nombre,
+            "instructor": instructor,
         })
         return nil, err
     })
@@ -95,68 +146,133 @@ func (s *CursoService) CrearCurso(curso models.Curso) (*mongo.InsertOneResult, e
         return nil, err
     }
 
+    s.historial.registrar(context.TODO(), insertedID, "curso creado", curso.Instructor)
     return result, nil
 }
 
 
-// ObtenerCursoPorID obtiene un curso por su ID.
-func (s *CursoService) ObtenerCursoPorID(id string) (*models.Curso, error) {
+// ObtenerCursoPorID obtiene un curso por su ID. Los cursos que el usuario de las credenciales (o un
+// usuario anónimo, si no se indican) no puede ver se tratan como inexistentes.
+func (s *CursoService) ObtenerCursoPorID(id, email, password string) (*models.Curso, error) {
+    usuario, err := usuarioOpcional(context.TODO(), s.RedisClient, email, password)
+    if err != nil {
+        return nil, err
+    }
+
     objectID, err := primitive.ObjectIDFromHex(id) // Convertir a ObjectID
     if err != nil {
         return nil, errors.New("ID inválido")
     }
 
-    var curso models.Curso
-    err = s.CursoCollection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&curso)
+    return obtenerCursoVisible(context.TODO(), s.CursoCollection, objectID, usuario)
+}
+
+// AsignarInstructor asigna o reemplaza el instructor de un curso, en MongoDB y en el nodo Curso de
+// Neo4j. Solo un administrador puede hacerlo.
+func (s *CursoService) AsignarInstructor(id, instructor, email, password string) error {
+    if _, err := obtenerAdmin(context.TODO(), s.RedisClient, email, password); err != nil {
+        return err
+    }
+
+    objectID, err := primitive.ObjectIDFromHex(id)
     if err != nil {
-        if err == mongo.ErrNoDocuments {
-            return nil, errors.New("curso no encontrado")
-        }
-        return nil, err
+        return errors.New("ID inválido")
+    }
+
+    result, err := s.CursoCollection.UpdateOne(context.TODO(), bson.M{"_id": objectID}, bson.M{"$set": bson.M{"instructor": instructor}})
+    if err != nil {
+        return err
+    }
+    if result.MatchedCount == 0 {
+        return errors.New("curso no encontrado")
     }
 
-    return &curso, nil
+    session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+    defer session.Close(context.TODO())
+
+    _, err = session.ExecuteWrite(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
+        query := `
+            MATCH (c:Curso {id: $id})
+            SET c.instructor = $instructor
+        `
+        _, err := tx.Run(context.TODO(), query, map[string]interface{}{
+            "id":         id,
+            "instructor": instructor,
+        })
+        return nil, err
+    })
+
+    return err
 }
 
-// ActualizarValoracion actualiza la valoración promedio de un curso.
-func (s *CursoService) ActualizarValoracion(id string, valoracion float32) error {
+// ActualizarValoracion sobrescribe manualmente la valoración de un curso. Solo un administrador
+// puede hacerlo y el cambio queda registrado en la auditoría junto con su motivo.
+func (s *CursoService) ActualizarValoracion(id string, valoracion float32, email, password, motivo string) error {
+    if _, err := obtenerAdmin(context.TODO(), s.RedisClient, email, password); err != nil {
+        return err
+    }
+
+    if valoracion < 0 || valoracion > 5 {
+        return errors.New("la valoración debe estar entre 0 y 5")
+    }
+    if strings.TrimSpace(motivo) == "" {
+        return errors.New("el motivo es requerido")
+    }
+
     // Convertir el ID a ObjectID
     objectID, err := primitive.ObjectIDFromHex(id)
     if err != nil {
         return errors.New("ID inválido")
     }
 
-    // Actualizar la valoración del curso en MongoDB
-    _, err = s.CursoCollection.UpdateOne(
+    // Actualizar la valoración del curso en MongoDB, obteniendo la valoración anterior
+    var anterior models.Curso
+    err = s.CursoCollection.FindOneAndUpdate(
         context.TODO(),
         bson.M{"_id": objectID},
         bson.M{"$set": bson.M{"valoracion": valoracion}},
-    )
+    ).Decode(&anterior)
     if err != nil {
+        if err == mongo.ErrNoDocuments {
+            return errors.New("curso no encontrado")
+        }
         return err
     }
 
-    // Opcional: también podrías actualizar el nodo Course en Neo4j con la nueva valoracion,
-    // si deseas mantenerlo sincronizado.
-    // Podrías implementar algo similar a CrearCurso en este método.
+    return registrarAuditoriaValoracion(s.AuditoriaCollection, models.AuditoriaValoracion{
+        CursoID:            objectID,
+        Admin:              email,
+        ValoracionAnterior: anterior.Valoracion,
+        ValoracionNueva:    valoracion,
+        Motivo:             motivo,
+    })
+}
 
-    return nil
+// registrarAuditoriaValoracion guarda un registro de auditoría de un cambio de valoración.
+func registrarAuditoriaValoracion(collection *mongo.Collection, auditoria models.AuditoriaValoracion) error {
+    auditoria.ID = primitive.NewObjectID()
+    auditoria.Fecha = time.Now()
+    _, err := collection.InsertOne(context.TODO(), auditoria)
+    return err
 }
 
-// ObtenerClasesPorCurso obtiene todas las clases de un curso.
-func (s *CursoService) ObtenerClasesPorCurso(id string) ([]models.Clase, error) {
+// ObtenerClasesPorCurso obtiene todas las clases de un curso visible para el usuario. Las clases de las unidades bloqueadas
+// para el usuario de las credenciales (o para un usuario no inscrito, si no se indican) se devuelven
+// sin su contenido.
+func (s *CursoService) ObtenerClasesPorCurso(id, email, password string) ([]models.Clase, error) {
+    usuario, err := usuarioOpcional(context.TODO(), s.RedisClient, email, password)
+    if err != nil {
+        return nil, err
+    }
+
     objectID, err := primitive.ObjectIDFromHex(id)
     if err != nil {
         return nil, errors.New("ID inválido")
     }
 
     // Obtener el curso por su ID
-    var curso models.Curso
-    err = s.CursoCollection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&curso)
+    curso, err := obtenerCursoVisible(context.TODO(), s.CursoCollection, objectID, usuario)
     if err != nil {
-        if err == mongo.ErrNoDocuments {
-            return nil, errors.New("curso no encontrado")
-        }
         return nil, err
     }
 
@@ -170,6 +286,8 @@ func (s *CursoService) ObtenerClasesPorCurso(id string) ([]models.Clase, error)
         return nil, err
     }
 
+    bloqueos := calcularBloqueos(*curso, unidades, usuario, time.Now())
+
     // Obtener las clases de cada unidad
     var clases []models.Clase
     for _, unidad := range unidades {
@@ -181,6 +299,11 @@ func (s *CursoService) ObtenerClasesPorCurso(id string) ([]models.Clase, error)
         if err = cursor.All(context.TODO(), &unidadClases); err != nil {
             return nil, err
         }
+        if estado := bloqueos[unidad.ID]; estado.bloqueada {
+            for i := range unidadClases {
+                ocultarClaseBloqueada(&unidadClases[i], estado)
+            }
+        }
         clases = append(clases, unidadClases...)
     }
 
@@ -0,0 +1,593 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-API/models"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ForoService gestiona los foros de discusión de los cursos. Cada curso tiene categorías, cada
+// categoría hilos y cada hilo publicaciones, conectados en Neo4j:
+//
+//	(:CategoriaForo)-[:PERTENECE_A]->(:Curso)
+//	(:Usuario)-[:CREO_HILO]->(:Hilo)-[:PERTENECE_A]->(:CategoriaForo)
+//	(:Usuario)-[:PUBLICO]->(:Publicacion)-[:PERTENECE_A]->(:Hilo)
+//
+// Publican los usuarios inscritos en el curso; el instructor del curso y los administradores
+// moderan el foro: crean categorías, fijan, bloquean y eliminan hilos y eliminan publicaciones.
+type ForoService struct {
+	Driver          neo4j.DriverWithContext
+	CursoCollection *mongo.Collection
+	RedisClient     *redis.Client
+}
+
+func NewForoService(driver neo4j.DriverWithContext, cursoCollection *mongo.Collection, redisClient *redis.Client) *ForoService {
+	return &ForoService{
+		Driver:          driver,
+		CursoCollection: cursoCollection,
+		RedisClient:     redisClient,
+	}
+}
+
+// contextoForo es el curso al que pertenece un elemento del foro y el estado del hilo, si corresponde.
+type contextoForo struct {
+	cursoID    string
+	instructor string
+	bloqueado  bool
+	autor      string
+}
+
+// consultaHilos es el inicio de las consultas que devuelven hilos con camposHiloForo.
+const consultaHilos = `
+        MATCH (autor:Usuario)-[:CREO_HILO]->(h:Hilo)-[:PERTENECE_A]->(cat:CategoriaForo)
+`
+
+// camposHiloForo cuenta las publicaciones visibles del hilo y devuelve las columnas que lee hiloForoDesdeRecord.
+const camposHiloForo = `
+        OPTIONAL MATCH (p:Publicacion)-[:PERTENECE_A]->(h)
+        WHERE coalesce(p.eliminada, false) = false
+        WITH autor, h, cat, COUNT(p) AS publicaciones
+        RETURN h.id, cat.id, h.titulo, autor.email, autor.nombre, h.fecha, h.ultimaActividad,
+               coalesce(h.fijado, false), coalesce(h.bloqueado, false), publicaciones
+`
+
+func hiloForoDesdeRecord(record *neo4j.Record) models.HiloForo {
+	hilo := models.HiloForo{}
+	hilo.ID, _ = record.Values[0].(string)
+	hilo.CategoriaID, _ = record.Values[1].(string)
+	hilo.Titulo, _ = record.Values[2].(string)
+	hilo.Autor, _ = record.Values[3].(string)
+	hilo.NombreAutor, _ = record.Values[4].(string)
+	hilo.Fecha, _ = record.Values[5].(time.Time)
+	hilo.UltimaActividad, _ = record.Values[6].(time.Time)
+	hilo.Fijado, _ = record.Values[7].(bool)
+	hilo.Bloqueado, _ = record.Values[8].(bool)
+	hilo.CantPublicaciones = int(record.Values[9].(int64))
+	return hilo
+}
+
+func publicacionForoDesdeRecord(record *neo4j.Record) models.PublicacionForo {
+	publicacion := models.PublicacionForo{}
+	publicacion.ID, _ = record.Values[0].(string)
+	publicacion.HiloID, _ = record.Values[1].(string)
+	publicacion.Autor, _ = record.Values[2].(string)
+	publicacion.NombreAutor, _ = record.Values[3].(string)
+	publicacion.Contenido, _ = record.Values[4].(string)
+	publicacion.Fecha, _ = record.Values[5].(time.Time)
+	publicacion.Eliminada, _ = record.Values[6].(bool)
+	if publicacion.Eliminada {
+		publicacion.Contenido = ""
+	}
+	return publicacion
+}
+
+// ObtenerCategorias obtiene las categorías del foro de un curso con su cantidad de hilos, si el usuario
+// puede ver el curso.
+func (s *ForoService) ObtenerCategorias(ctx context.Context, cursoID, email, password string) ([]models.CategoriaForo, error) {
+	if err := verificarCursoVisible(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password); err != nil {
+		return nil, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		checkResult, err := tx.Run(ctx, `MATCH (c:Curso {id: $cursoID}) RETURN c.id`, map[string]interface{}{"cursoID": cursoID})
+		if err != nil {
+			return nil, err
+		}
+		if !checkResult.Next(ctx) {
+			return nil, errors.New("curso no encontrado")
+		}
+
+		res, err := tx.Run(ctx, `
+            MATCH (cat:CategoriaForo)-[:PERTENECE_A]->(:Curso {id: $cursoID})
+            OPTIONAL MATCH (h:Hilo)-[:PERTENECE_A]->(cat)
+            RETURN cat.id, cat.nombre, cat.descripcion, cat.fecha, COUNT(h)
+            ORDER BY cat.fecha ASC
+        `, map[string]interface{}{"cursoID": cursoID})
+		if err != nil {
+			return nil, err
+		}
+
+		categorias := []models.CategoriaForo{}
+		for res.Next(ctx) {
+			record := res.Record()
+			categoria := models.CategoriaForo{CursoID: cursoID}
+			categoria.ID, _ = record.Values[0].(string)
+			categoria.Nombre, _ = record.Values[1].(string)
+			categoria.Descripcion, _ = record.Values[2].(string)
+			categoria.Fecha, _ = record.Values[3].(time.Time)
+			categoria.CantHilos = int(record.Values[4].(int64))
+			categorias = append(categorias, categoria)
+		}
+		return categorias, res.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]models.CategoriaForo), nil
+}
+
+// CrearCategoria crea una categoría en el foro de un curso. Solo los moderadores del curso pueden hacerlo.
+func (s *ForoService) CrearCategoria(ctx context.Context, cursoID, email, password, nombre, descripcion string) (*models.CategoriaForo, error) {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (curso:Curso {id: $id})
+        RETURN curso.id, curso.instructor, false, null
+    `, cursoID, "curso no encontrado")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.autorizarModerador(ctx, email, password, contexto); err != nil {
+		return nil, err
+	}
+
+	categoria := &models.CategoriaForo{
+		ID:          uuid.New().String(),
+		CursoID:     cursoID,
+		Nombre:      nombre,
+		Descripcion: descripcion,
+		Fecha:       time.Now(),
+	}
+
+	err = s.crear(ctx, `
+        MATCH (curso:Curso {id: $cursoID})
+        CREATE (cat:CategoriaForo {id: $id, nombre: $nombre, descripcion: $descripcion, fecha: $fecha})-[:PERTENECE_A]->(curso)
+        RETURN cat.id
+    `, "no se pudo crear la categoría", map[string]interface{}{
+		"cursoID":     cursoID,
+		"id":          categoria.ID,
+		"nombre":      nombre,
+		"descripcion": descripcion,
+		"fecha":       categoria.Fecha,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return categoria, nil
+}
+
+// ObtenerHilos obtiene una página de los hilos de una categoría: primero los fijados y luego los de
+// actividad más reciente. El usuario debe poder ver el curso de la categoría.
+func (s *ForoService) ObtenerHilos(ctx context.Context, categoriaID, email, password string, pagina, limite int) ([]models.HiloForo, int, error) {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (:CategoriaForo {id: $id})-[:PERTENECE_A]->(curso:Curso)
+        RETURN curso.id, curso.instructor, false, null
+    `, categoriaID, "categoría no encontrada")
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := verificarCursoVisible(ctx, s.RedisClient, s.CursoCollection, contexto.cursoID, email, password); err != nil {
+		return nil, 0, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	type paginaHilos struct {
+		hilos []models.HiloForo
+		total int
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		countResult, err := tx.Run(ctx, `
+            MATCH (h:Hilo)-[:PERTENECE_A]->(:CategoriaForo {id: $categoriaID})
+            RETURN COUNT(h)
+        `, map[string]interface{}{"categoriaID": categoriaID})
+		if err != nil {
+			return nil, err
+		}
+		if !countResult.Next(ctx) {
+			return nil, countResult.Err()
+		}
+		total := int(countResult.Record().Values[0].(int64))
+
+		res, err := tx.Run(ctx, consultaHilos+`
+        WHERE cat.id = $categoriaID
+        `+camposHiloForo+`
+        ORDER BY coalesce(h.fijado, false) DESC, h.ultimaActividad DESC
+        SKIP $skip LIMIT $limite
+        `, map[string]interface{}{
+			"categoriaID": categoriaID,
+			"skip":        (pagina - 1) * limite,
+			"limite":      limite,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		hilos := []models.HiloForo{}
+		for res.Next(ctx) {
+			hilos = append(hilos, hiloForoDesdeRecord(res.Record()))
+		}
+		return paginaHilos{hilos: hilos, total: total}, res.Err()
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p := result.(paginaHilos)
+	return p.hilos, p.total, nil
+}
+
+// CrearHilo inicia un hilo en una categoría con su primera publicación. Pueden hacerlo los inscritos
+// en el curso y sus moderadores.
+func (s *ForoService) CrearHilo(ctx context.Context, categoriaID, email, password, titulo, contenido string) (*models.HiloForo, error) {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (:CategoriaForo {id: $id})-[:PERTENECE_A]->(curso:Curso)
+        RETURN curso.id, curso.instructor, false, null
+    `, categoriaID, "categoría no encontrada")
+	if err != nil {
+		return nil, err
+	}
+	usuario, _, err := s.autorizarParticipante(ctx, email, password, contexto)
+	if err != nil {
+		return nil, err
+	}
+
+	ahora := time.Now()
+	hilo := &models.HiloForo{
+		ID:                uuid.New().String(),
+		CategoriaID:       categoriaID,
+		Titulo:            titulo,
+		Autor:             usuario.Email,
+		NombreAutor:       usuario.Nombre,
+		Fecha:             ahora,
+		UltimaActividad:   ahora,
+		CantPublicaciones: 1,
+	}
+
+	err = s.crear(ctx, `
+        MATCH (cat:CategoriaForo {id: $categoriaID})
+        MATCH (u:Usuario {email: $email})
+        CREATE (u)-[:CREO_HILO]->(h:Hilo {id: $id, titulo: $titulo, fecha: $fecha, ultimaActividad: $fecha,
+                                          fijado: false, bloqueado: false})-[:PERTENECE_A]->(cat)
+        CREATE (u)-[:PUBLICO]->(:Publicacion {id: $publicacionID, contenido: $contenido, fecha: $fecha})-[:PERTENECE_A]->(h)
+        RETURN h.id
+    `, "no se pudo crear el hilo", map[string]interface{}{
+		"categoriaID":   categoriaID,
+		"email":         usuario.Email,
+		"id":            hilo.ID,
+		"titulo":        titulo,
+		"fecha":         ahora,
+		"publicacionID": uuid.New().String(),
+		"contenido":     contenido,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hilo, nil
+}
+
+// ObtenerHilo obtiene un hilo y una página de sus publicaciones, de la más antigua a la más reciente.
+// El usuario debe poder ver el curso del hilo.
+func (s *ForoService) ObtenerHilo(ctx context.Context, hiloID, email, password string, pagina, limite int) (*models.HiloForo, []models.PublicacionForo, int, error) {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (h:Hilo {id: $id})-[:PERTENECE_A]->(:CategoriaForo)-[:PERTENECE_A]->(curso:Curso)
+        RETURN curso.id, curso.instructor, false, null
+    `, hiloID, "hilo no encontrado")
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if err := verificarCursoVisible(ctx, s.RedisClient, s.CursoCollection, contexto.cursoID, email, password); err != nil {
+		return nil, nil, 0, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	type paginaHilo struct {
+		hilo          models.HiloForo
+		publicaciones []models.PublicacionForo
+		total         int
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		hiloResult, err := tx.Run(ctx, consultaHilos+`
+        WHERE h.id = $hiloID
+        `+camposHiloForo, map[string]interface{}{"hiloID": hiloID})
+		if err != nil {
+			return nil, err
+		}
+		if !hiloResult.Next(ctx) {
+			return nil, errors.New("hilo no encontrado")
+		}
+		p := paginaHilo{hilo: hiloForoDesdeRecord(hiloResult.Record())}
+
+		countResult, err := tx.Run(ctx, `
+            MATCH (p:Publicacion)-[:PERTENECE_A]->(:Hilo {id: $hiloID})
+            RETURN COUNT(p)
+        `, map[string]interface{}{"hiloID": hiloID})
+		if err != nil {
+			return nil, err
+		}
+		if countResult.Next(ctx) {
+			p.total = int(countResult.Record().Values[0].(int64))
+		}
+
+		res, err := tx.Run(ctx, `
+            MATCH (u:Usuario)-[:PUBLICO]->(p:Publicacion)-[:PERTENECE_A]->(h:Hilo {id: $hiloID})
+            RETURN p.id, h.id, u.email, u.nombre, p.contenido, p.fecha, coalesce(p.eliminada, false)
+            ORDER BY p.fecha ASC
+            SKIP $skip LIMIT $limite
+        `, map[string]interface{}{
+			"hiloID": hiloID,
+			"skip":   (pagina - 1) * limite,
+			"limite": limite,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		p.publicaciones = []models.PublicacionForo{}
+		for res.Next(ctx) {
+			p.publicaciones = append(p.publicaciones, publicacionForoDesdeRecord(res.Record()))
+		}
+		return p, res.Err()
+	})
+
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	p := result.(paginaHilo)
+	return &p.hilo, p.publicaciones, p.total, nil
+}
+
+// CrearPublicacion agrega una publicación a un hilo. En los hilos bloqueados solo pueden publicar
+// los moderadores del curso.
+func (s *ForoService) CrearPublicacion(ctx context.Context, hiloID, email, password, contenido string) (*models.PublicacionForo, error) {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (h:Hilo {id: $id})-[:PERTENECE_A]->(:CategoriaForo)-[:PERTENECE_A]->(curso:Curso)
+        RETURN curso.id, curso.instructor, coalesce(h.bloqueado, false), null
+    `, hiloID, "hilo no encontrado")
+	if err != nil {
+		return nil, err
+	}
+	usuario, moderador, err := s.autorizarParticipante(ctx, email, password, contexto)
+	if err != nil {
+		return nil, err
+	}
+	if contexto.bloqueado && !moderador {
+		return nil, errors.New("el hilo está bloqueado")
+	}
+
+	publicacion := &models.PublicacionForo{
+		ID:          uuid.New().String(),
+		HiloID:      hiloID,
+		Autor:       usuario.Email,
+		NombreAutor: usuario.Nombre,
+		Contenido:   contenido,
+		Fecha:       time.Now(),
+	}
+
+	err = s.crear(ctx, `
+        MATCH (h:Hilo {id: $hiloID})
+        MATCH (u:Usuario {email: $email})
+        CREATE (u)-[:PUBLICO]->(p:Publicacion {id: $id, contenido: $contenido, fecha: $fecha})-[:PERTENECE_A]->(h)
+        SET h.ultimaActividad = $fecha
+        RETURN p.id
+    `, "no se pudo crear la publicación", map[string]interface{}{
+		"hiloID":    hiloID,
+		"email":     usuario.Email,
+		"id":        publicacion.ID,
+		"contenido": contenido,
+		"fecha":     publicacion.Fecha,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return publicacion, nil
+}
+
+// ActualizarEstadoHilo fija o bloquea un hilo. Los valores nil se dejan sin cambios. Solo los
+// moderadores del curso pueden hacerlo.
+func (s *ForoService) ActualizarEstadoHilo(ctx context.Context, hiloID, email, password string, fijado, bloqueado *bool) error {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (h:Hilo {id: $id})-[:PERTENECE_A]->(:CategoriaForo)-[:PERTENECE_A]->(curso:Curso)
+        RETURN curso.id, curso.instructor, coalesce(h.bloqueado, false), null
+    `, hiloID, "hilo no encontrado")
+	if err != nil {
+		return err
+	}
+	if _, err := s.autorizarModerador(ctx, email, password, contexto); err != nil {
+		return err
+	}
+
+	return s.escribir(ctx, `
+        MATCH (h:Hilo {id: $hiloID})
+        SET h.fijado = coalesce($fijado, h.fijado),
+            h.bloqueado = coalesce($bloqueado, h.bloqueado)
+    `, map[string]interface{}{
+		"hiloID":    hiloID,
+		"fijado":    valorOpcional(fijado),
+		"bloqueado": valorOpcional(bloqueado),
+	})
+}
+
+// EliminarHilo elimina un hilo con todas sus publicaciones. Solo los moderadores del curso pueden hacerlo.
+func (s *ForoService) EliminarHilo(ctx context.Context, hiloID, email, password string) error {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (h:Hilo {id: $id})-[:PERTENECE_A]->(:CategoriaForo)-[:PERTENECE_A]->(curso:Curso)
+        RETURN curso.id, curso.instructor, false, null
+    `, hiloID, "hilo no encontrado")
+	if err != nil {
+		return err
+	}
+	if _, err := s.autorizarModerador(ctx, email, password, contexto); err != nil {
+		return err
+	}
+
+	return s.escribir(ctx, `
+        MATCH (h:Hilo {id: $hiloID})
+        OPTIONAL MATCH (p:Publicacion)-[:PERTENECE_A]->(h)
+        DETACH DELETE p, h
+    `, map[string]interface{}{"hiloID": hiloID})
+}
+
+// EliminarPublicacion elimina una publicación a pedido de su autor o de un moderador del curso. La
+// publicación se conserva sin contenido para no alterar la conversación.
+func (s *ForoService) EliminarPublicacion(ctx context.Context, publicacionID, email, password string) error {
+	contexto, err := s.obtenerContexto(ctx, `
+        MATCH (autor:Usuario)-[:PUBLICO]->(:Publicacion {id: $id})-[:PERTENECE_A]->(:Hilo)-[:PERTENECE_A]->(:CategoriaForo)-[:PERTENECE_A]->(curso:Curso)
+        RETURN curso.id, curso.instructor, false, autor.email
+    `, publicacionID, "publicación no encontrada")
+	if err != nil {
+		return err
+	}
+
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return err
+	}
+	if contexto.autor != email && !esModeradorForo(email, contexto) {
+		return errors.New("solo el autor o un moderador del curso puede eliminar la publicación")
+	}
+
+	return s.escribir(ctx, `
+        MATCH (p:Publicacion {id: $id})
+        SET p.eliminada = true, p.eliminadaEn = datetime(), p.eliminadaPor = $email
+    `, map[string]interface{}{"id": publicacionID, "email": email})
+}
+
+// obtenerContexto ejecuta una consulta que devuelve el ID y el instructor del curso, si el hilo está
+// bloqueado y el autor del elemento, o el error indicado si no hay resultados.
+func (s *ForoService) obtenerContexto(ctx context.Context, query, id, noEncontrado string) (*contextoForo, error) {
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		res, err := tx.Run(ctx, query, map[string]interface{}{"id": id})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return nil, errors.New(noEncontrado)
+		}
+
+		record := res.Record()
+		contexto := &contextoForo{}
+		contexto.cursoID, _ = record.Values[0].(string)
+		contexto.instructor, _ = record.Values[1].(string)
+		contexto.bloqueado, _ = record.Values[2].(bool)
+		contexto.autor, _ = record.Values[3].(string)
+		return contexto, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*contextoForo), nil
+}
+
+// autorizarParticipante verifica que el usuario pueda publicar en el foro del curso: debe estar
+// inscrito o ser moderador del curso. Devuelve también si es moderador.
+func (s *ForoService) autorizarParticipante(ctx context.Context, email, password string, contexto *contextoForo) (*models.Usuario, bool, error) {
+	usuario, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if esModeradorForo(usuario.Email, contexto) {
+		return usuario, true, nil
+	}
+
+	cursoObjectID, err := primitive.ObjectIDFromHex(contexto.cursoID)
+	if err != nil || !contains(usuario.Inscritos, cursoObjectID) {
+		return nil, false, errors.New("el usuario no está inscrito en este curso")
+	}
+	return usuario, false, nil
+}
+
+// autorizarModerador verifica que el usuario sea moderador del foro del curso.
+func (s *ForoService) autorizarModerador(ctx context.Context, email, password string, contexto *contextoForo) (*models.Usuario, error) {
+	usuario, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if !esModeradorForo(usuario.Email, contexto) {
+		return nil, errors.New("solo el instructor del curso o un administrador puede moderar el foro")
+	}
+	return usuario, nil
+}
+
+// esModeradorForo indica si el usuario modera el foro del curso: su instructor o un administrador.
+func esModeradorForo(email string, contexto *contextoForo) bool {
+	return (contexto.instructor != "" && contexto.instructor == email) || esAdmin(email)
+}
+
+// escribir ejecuta una consulta de escritura sin resultados.
+func (s *ForoService) escribir(ctx context.Context, query string, params map[string]interface{}) error {
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, query, params)
+		return nil, err
+	})
+	return err
+}
+
+// crear ejecuta una consulta de escritura que devuelve el elemento creado. Si no devuelve ninguna
+// fila, porque alguno de los nodos que enlaza no existe, devuelve un error con el mensaje indicado.
+func (s *ForoService) crear(ctx context.Context, query, mensaje string, params map[string]interface{}) error {
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			if err := res.Err(); err != nil {
+				return nil, err
+			}
+			return nil, errors.New(mensaje)
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// valorOpcional convierte un puntero en un parámetro de Cypher, usando null si es nil.
+func valorOpcional(valor *bool) interface{} {
+	if valor == nil {
+		return nil
+	}
+	return *valor
+}
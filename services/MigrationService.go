@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"go-API/models"
 	"log"
 	"strings"
 
@@ -169,3 +170,212 @@ func (ms *MigrationService) migrateCursos(ctx context.Context) error {
 	}
 	return nil
 }
+
+// MigrateResenas convierte en reseñas los pares PUNTUO y REALIZO_COMENTARIO que un mismo usuario
+// hizo sobre un mismo curso. Si el usuario comentó varias veces el curso, los textos se unen en orden.
+// Los comentarios migrados se marcan como eliminados, con el ID de la reseña en migrado_a_resena, para
+// que no aparezcan también en los comentarios del curso. Solo se migran los comentarios visibles y se
+// omiten los usuarios que ya tienen una reseña para el curso, por lo que puede ejecutarse varias veces.
+func (ms *MigrationService) MigrateResenas(ctx context.Context) (int, error) {
+	session := ms.Neo4j.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+            MATCH (u:Usuario)-[p:PUNTUO]->(c:Curso)
+            WHERE NOT EXISTS { MATCH (u)-[:ESCRIBIO]->(:Resena)-[:RESENA_DE]->(c) }
+            MATCH (u)-[rc:REALIZO_COMENTARIO]->(c)
+            WHERE coalesce(rc.eliminado, false) = false AND coalesce(rc.moderacion, $aprobado) = $aprobado
+            WITH u, c, p, rc ORDER BY rc.fecha
+            WITH u, c, p, collect(rc) AS comentarios
+            CREATE (u)-[:ESCRIBIO]->(r:Resena {
+                id: randomUUID(),
+                valor: p.valor,
+                texto: reduce(texto = head(comentarios).texto, x IN tail(comentarios) | texto + '\n\n' + x.texto),
+                fecha: datetime(),
+                util: 0,
+                no_util: 0
+            })-[:RESENA_DE]->(c)
+            FOREACH (x IN comentarios |
+                SET x.eliminado = true, x.eliminado_en = datetime(), x.eliminado_por = u.email, x.migrado_a_resena = r.id
+            )
+            RETURN COUNT(r) AS migradas
+        `
+		res, err := tx.Run(ctx, query, map[string]interface{}{"aprobado": EstadoModeracionAprobado})
+		if err != nil {
+			return 0, err
+		}
+		if res.Next(ctx) {
+			return int(res.Record().Values[0].(int64)), nil
+		}
+		return 0, res.Err()
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error al migrar reseñas: %v", err)
+	}
+
+	migradas := result.(int)
+	log.Printf("Migración de reseñas completada: %d reseñas creadas", migradas)
+	return migradas, nil
+}
+
+// MigrateComentariosCurso asigna un ID y una fecha a los comentarios de curso (relaciones
+// REALIZO_COMENTARIO) creados antes de que se guardaran. Como la fecha original no se conoce, se usa
+// la fecha de la migración. Solo modifica los comentarios que no los tienen, por lo que puede
+// ejecutarse varias veces.
+func (ms *MigrationService) MigrateComentariosCurso(ctx context.Context) (int, error) {
+	session := ms.Neo4j.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+            MATCH (:Usuario)-[r:REALIZO_COMENTARIO]->(:Curso)
+            WHERE r.id IS NULL OR r.fecha IS NULL
+            SET r.id = coalesce(r.id, randomUUID()),
+                r.fecha = coalesce(r.fecha, datetime())
+            RETURN COUNT(r) AS migrados
+        `
+		res, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return 0, err
+		}
+		if res.Next(ctx) {
+			return int(res.Record().Values[0].(int64)), nil
+		}
+		return 0, res.Err()
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error al migrar comentarios de curso: %v", err)
+	}
+
+	migrados := result.(int)
+	log.Printf("Migración de comentarios de curso completada: %d comentarios actualizados", migrados)
+	return migrados, nil
+}
+
+// MigrateInscritos arma el conjunto de inscritos de cada curso en Redis a partir de los cursos
+// inscritos de cada usuario, para las inscripciones hechas antes de que existiera. Agregar un email
+// que ya está en el conjunto no tiene efecto, por lo que puede ejecutarse varias veces.
+func (ms *MigrationService) MigrateInscritos(ctx context.Context) (int, error) {
+	keys, err := ms.Redis.Keys(ctx, "usuario:*:*").Result()
+	if err != nil {
+		return 0, fmt.Errorf("error al obtener claves de usuarios en Redis: %v", err)
+	}
+
+	inscripciones := 0
+	for _, key := range keys {
+		usuarioJSON, err := ms.Redis.Get(ctx, key).Result()
+		if err != nil {
+			log.Printf("Error al obtener usuario de Redis: %v", err)
+			continue
+		}
+
+		var usuario models.Usuario
+		if err := json.Unmarshal([]byte(usuarioJSON), &usuario); err != nil {
+			log.Printf("Error al deserializar usuario: %v", err)
+			continue
+		}
+
+		for _, cursoID := range usuario.Inscritos {
+			if err := ms.Redis.SAdd(ctx, claveInscritosCurso(cursoID), usuario.Email).Err(); err != nil {
+				return inscripciones, fmt.Errorf("error al agregar inscrito en Redis: %v", err)
+			}
+			inscripciones++
+		}
+	}
+
+	log.Printf("Migración de inscritos completada: %d inscripciones", inscripciones)
+	return inscripciones, nil
+}
+
+// MigrateContenido crea en Neo4j los nodos Unidad y Clase de las unidades y clases existentes en
+// MongoDB, enlazados a su Curso, y reescribe los comentarios de clase creados con el modelo
+// anterior ((:User)-[:COMENTÓ]->(:Comment)-[:PERTENECE_A]->(:Course)-[:CONTENEDOR_DE]->(:Clase)).
+func (ms *MigrationService) MigrateContenido(ctx context.Context) error {
+	if err := ms.migrateUnidadesYClases(ctx); err != nil {
+		return fmt.Errorf("error al migrar unidades y clases: %v", err)
+	}
+	if err := ms.migrateComentariosLegados(ctx); err != nil {
+		return fmt.Errorf("error al migrar comentarios de clase: %v", err)
+	}
+	log.Println("Migración de unidades, clases y comentarios completada")
+	return nil
+}
+
+func (ms *MigrationService) migrateUnidadesYClases(ctx context.Context) error {
+	var cursos []models.Curso
+	cursor, err := ms.MongoDB.Collection("cursos").Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("error al obtener cursos de MongoDB: %v", err)
+	}
+	if err := cursor.All(ctx, &cursos); err != nil {
+		return err
+	}
+
+	for _, curso := range cursos {
+		var unidades []models.Unidad
+		cursor, err := ms.MongoDB.Collection("unidades").Find(ctx, bson.M{"_id": bson.M{"$in": curso.Unidades}})
+		if err != nil {
+			return err
+		}
+		if err := cursor.All(ctx, &unidades); err != nil {
+			return err
+		}
+
+		for _, unidad := range unidades {
+			if err := sincronizarUnidadEnNeo4j(ctx, ms.Neo4j, curso, unidad); err != nil {
+				log.Printf("Error al crear nodo Unidad %s en Neo4j: %v", unidad.ID.Hex(), err)
+				continue
+			}
+
+			var clases []models.Clase
+			cursor, err := ms.MongoDB.Collection("clases").Find(ctx, bson.M{"_id": bson.M{"$in": unidad.Clases}})
+			if err != nil {
+				return err
+			}
+			if err := cursor.All(ctx, &clases); err != nil {
+				return err
+			}
+
+			for _, clase := range clases {
+				if err := sincronizarClaseEnNeo4j(ctx, ms.Neo4j, curso, unidad, clase); err != nil {
+					log.Printf("Error al crear nodo Clase %s en Neo4j: %v", clase.ID.Hex(), err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (ms *MigrationService) migrateComentariosLegados(ctx context.Context) error {
+	session := ms.Neo4j.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		// Reetiquetar los comentarios y enlazarlos directamente con la clase y el Usuario del mismo email
+		rewriteQuery := `
+            MATCH (legado:User)-[rc:COMENTÓ]->(c:Comment)-[rp:PERTENECE_A]->(curso:Course)-[:CONTENEDOR_DE]->(clase:Clase)
+            MERGE (u:Usuario {email: legado.email})
+            ON CREATE SET u.nombre = legado.nombre
+            MERGE (u)-[:COMENTO]->(c)
+            MERGE (c)-[:PERTENECE_A]->(clase)
+            SET c:Comentario
+            REMOVE c:Comment
+            DELETE rc, rp
+        `
+		if _, err := tx.Run(ctx, rewriteQuery, nil); err != nil {
+			return nil, err
+		}
+
+		// Eliminar los nodos intermedios Course y User que quedaron sin relaciones útiles
+		cleanupQuery := `
+            MATCH (n)
+            WHERE (n:Course AND NOT EXISTS { MATCH (n)<-[:PERTENECE_A]-() })
+               OR (n:User AND NOT EXISTS { MATCH (n)-[]-() })
+            DETACH DELETE n
+        `
+		_, err := tx.Run(ctx, cleanupQuery, nil)
+		return nil, err
+	})
+	return err
+}
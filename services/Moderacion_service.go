@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-API/models"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	TipoComentarioClase = "clase"
+	TipoComentarioCurso = "curso"
+
+	EstadoModeracionPendiente = "pendiente"
+	EstadoModeracionAprobado  = "aprobado"
+	EstadoModeracionOculto    = "oculto"
+
+	AccionModeracionAprobar  = "aprobar"
+	AccionModeracionOcultar  = "ocultar"
+	AccionModeracionEliminar = "eliminar"
+)
+
+// ModeracionService gestiona los reportes de comentarios de clase y de curso y la cola de revisión
+// de los moderadores. Los reportes se guardan como nodos Reporte en Neo4j, y el estado de moderación
+// de cada comentario en su propiedad "moderacion" (sin valor equivale a "aprobado").
+type ModeracionService struct {
+	Driver         neo4j.DriverWithContext
+	RedisClient    *redis.Client
+	Notificaciones *NotificacionService
+}
+
+func NewModeracionService(driver neo4j.DriverWithContext, redisClient *redis.Client, notificaciones *NotificacionService) *ModeracionService {
+	return &ModeracionService{
+		Driver:         driver,
+		RedisClient:    redisClient,
+		Notificaciones: notificaciones,
+	}
+}
+
+// consultasPorTipo contiene los fragmentos de Cypher que ubican cada tipo de comentario. Los comentarios
+// de clase son nodos Comentario; los de curso, relaciones REALIZO_COMENTARIO.
+var consultasPorTipo = map[string]struct {
+	match       string // Ubica el comentario "x" y su autor "autor" a partir de la variable "id"
+	pendientes  string // Devuelve los IDs de los comentarios retenidos por el filtro
+	campos      string // Devuelve título, texto y fecha del comentario "x"
+	eliminar    string // Marca el comentario "x" como eliminado por $email
+	moderadoPor string
+	moderadoEn  string
+	// Quita la marca de notificaciones pendientes del comentario "x" y devuelve autor, nombre del
+	// autor, título, texto, ID de la clase o del curso y autor del comentario al que responde
+	notificar string
+}{
+	TipoComentarioClase: {
+		match:       "MATCH (autor:Usuario)-[:COMENTO]->(x:Comentario {id: id})",
+		pendientes:  "MATCH (x:Comentario) WHERE x.moderacion = $pendiente RETURN x.id AS id",
+		campos:      "x.titulo, x.detalle, x.fecha",
+		eliminar:    "SET x.eliminado = true, x.eliminadoEn = datetime(), x.eliminadoPor = $email",
+		moderadoPor: "moderadoPor",
+		moderadoEn:  "moderadoEn",
+		notificar: `
+            MATCH (autor:Usuario)-[:COMENTO]->(x:Comentario {id: $id})-[:PERTENECE_A]->(clase:Clase)
+            WHERE x.notificarAlAprobar = true
+            REMOVE x.notificarAlAprobar
+            WITH x, autor, clase
+            OPTIONAL MATCH (x)-[:RESPONDE_A]->(padre:Comentario)
+            RETURN autor.email, autor.nombre, x.titulo, x.detalle, clase.id, padre.autor
+        `,
+	},
+	TipoComentarioCurso: {
+		match:       "MATCH (autor:Usuario)-[x:REALIZO_COMENTARIO {id: id}]->(:Curso)",
+		pendientes:  "MATCH (:Usuario)-[x:REALIZO_COMENTARIO]->(:Curso) WHERE x.moderacion = $pendiente RETURN x.id AS id",
+		campos:      "'', x.texto, x.fecha",
+		eliminar:    "SET x.eliminado = true, x.eliminado_en = datetime(), x.eliminado_por = $email",
+		moderadoPor: "moderado_por",
+		moderadoEn:  "moderado_en",
+		notificar: `
+            MATCH (autor:Usuario)-[x:REALIZO_COMENTARIO {id: $id}]->(curso:Curso)
+            WHERE x.notificar_al_aprobar = true
+            REMOVE x.notificar_al_aprobar
+            RETURN autor.email, autor.nombre, '', x.texto, curso.id, null
+        `,
+	},
+}
+
+// ReportarComentario registra el reporte de un usuario sobre un comentario de clase o de curso. Un
+// usuario solo puede tener un reporte abierto por comentario y no puede reportar sus propios comentarios.
+func (s *ModeracionService) ReportarComentario(ctx context.Context, email, password, tipo, comentarioID, motivo string) error {
+	consultas, ok := consultasPorTipo[tipo]
+	if !ok {
+		return errors.New("tipo de comentario inválido")
+	}
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		checkQuery := "WITH $id AS id " + consultas.match + `
+            WHERE coalesce(x.eliminado, false) = false
+            OPTIONAL MATCH (:Usuario {email: $email})-[:REPORTO]->(previo:Reporte {tipo: $tipo, comentario_id: $id, resuelto: false})
+            RETURN autor.email = $email AS propio, COUNT(previo) > 0 AS reportado
+        `
+		checkResult, err := tx.Run(ctx, checkQuery, map[string]interface{}{
+			"id":    comentarioID,
+			"email": email,
+			"tipo":  tipo,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !checkResult.Next(ctx) {
+			return nil, errors.New("comentario no encontrado")
+		}
+		record := checkResult.Record()
+		if record.Values[0].(bool) {
+			return nil, errors.New("no puede reportar su propio comentario")
+		}
+		if record.Values[1].(bool) {
+			return nil, errors.New("el usuario ya reportó este comentario")
+		}
+
+		createQuery := `
+            MATCH (u:Usuario {email: $email})
+            CREATE (u)-[:REPORTO]->(:Reporte {
+                id: $reporteID,
+                tipo: $tipo,
+                comentario_id: $id,
+                motivo: $motivo,
+                fecha: datetime(),
+                resuelto: false
+            })
+        `
+		_, err = tx.Run(ctx, createQuery, map[string]interface{}{
+			"reporteID": uuid.New().String(),
+			"email":     email,
+			"tipo":      tipo,
+			"id":        comentarioID,
+			"motivo":    motivo,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// ObtenerColaModeracion obtiene los comentarios que esperan revisión: los retenidos por el filtro de
+// palabras y los que tienen reportes abiertos. Los más reportados aparecen primero y, a igual cantidad
+// de reportes, los más antiguos.
+func (s *ModeracionService) ObtenerColaModeracion(ctx context.Context, email, password string) ([]models.ElementoModeracion, error) {
+	if _, err := obtenerModerador(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		cola := []models.ElementoModeracion{}
+		for _, tipo := range []string{TipoComentarioClase, TipoComentarioCurso} {
+			consultas := consultasPorTipo[tipo]
+			query := `
+                CALL {
+                    ` + consultas.pendientes + `
+                    UNION
+                    MATCH (rep:Reporte {tipo: $tipo, resuelto: false}) RETURN rep.comentario_id AS id
+                }
+                WITH id
+                ` + consultas.match + `
+                WHERE coalesce(x.eliminado, false) = false
+                OPTIONAL MATCH (rep:Reporte {tipo: $tipo, comentario_id: id, resuelto: false})
+                RETURN id, autor.email, ` + consultas.campos + `, coalesce(x.moderacion, $aprobado), COUNT(rep), collect(rep.motivo)
+            `
+			res, err := tx.Run(ctx, query, map[string]interface{}{
+				"tipo":      tipo,
+				"pendiente": EstadoModeracionPendiente,
+				"aprobado":  EstadoModeracionAprobado,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for res.Next(ctx) {
+				record := res.Record()
+				elemento := models.ElementoModeracion{Tipo: tipo, Motivos: []string{}}
+				elemento.ComentarioID, _ = record.Values[0].(string)
+				elemento.Autor, _ = record.Values[1].(string)
+				elemento.Titulo, _ = record.Values[2].(string)
+				elemento.Texto, _ = record.Values[3].(string)
+				elemento.Fecha, _ = record.Values[4].(time.Time)
+				elemento.Estado, _ = record.Values[5].(string)
+				elemento.Reportes = int(record.Values[6].(int64))
+				for _, motivo := range record.Values[7].([]interface{}) {
+					if m, ok := motivo.(string); ok {
+						elemento.Motivos = append(elemento.Motivos, m)
+					}
+				}
+				cola = append(cola, elemento)
+			}
+			if err = res.Err(); err != nil {
+				return nil, err
+			}
+		}
+		return cola, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	cola := result.([]models.ElementoModeracion)
+	sort.SliceStable(cola, func(i, j int) bool {
+		if cola[i].Reportes != cola[j].Reportes {
+			return cola[i].Reportes > cola[j].Reportes
+		}
+		return cola[i].Fecha.Before(cola[j].Fecha)
+	})
+	return cola, nil
+}
+
+// ModerarComentario aplica la decisión de un moderador sobre un comentario de clase o de curso:
+// aprobarlo (vuelve a ser visible), ocultarlo o eliminarlo. Los reportes abiertos del comentario
+// quedan resueltos con la acción aplicada. Al aprobar un comentario que el filtro retuvo al crearlo se
+// envían las notificaciones de respuesta y de menciones que quedaron pendientes.
+func (s *ModeracionService) ModerarComentario(ctx context.Context, email, password, tipo, comentarioID, accion string) error {
+	consultas, ok := consultasPorTipo[tipo]
+	if !ok {
+		return errors.New("tipo de comentario inválido")
+	}
+
+	var cambio string
+	switch accion {
+	case AccionModeracionAprobar:
+		cambio = "SET x.moderacion = '" + EstadoModeracionAprobado + "'"
+	case AccionModeracionOcultar:
+		cambio = "SET x.moderacion = '" + EstadoModeracionOculto + "'"
+	case AccionModeracionEliminar:
+		cambio = consultas.eliminar
+	default:
+		return errors.New("acción de moderación inválida")
+	}
+
+	if _, err := obtenerModerador(ctx, s.RedisClient, email, password); err != nil {
+		return err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	var pendiente []interface{}
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		pendiente = nil
+		updateQuery := "WITH $id AS id " + consultas.match + `
+            WHERE coalesce(x.eliminado, false) = false
+            ` + cambio + `
+            SET x.` + consultas.moderadoPor + ` = $email, x.` + consultas.moderadoEn + ` = datetime()
+            RETURN x.id
+        `
+		res, err := tx.Run(ctx, updateQuery, map[string]interface{}{
+			"id":    comentarioID,
+			"email": email,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return nil, errors.New("comentario no encontrado")
+		}
+
+		resolverQuery := `
+            MATCH (rep:Reporte {tipo: $tipo, comentario_id: $id, resuelto: false})
+            SET rep.resuelto = true, rep.accion = $accion, rep.resuelto_por = $email, rep.resuelto_en = datetime()
+        `
+		_, err = tx.Run(ctx, resolverQuery, map[string]interface{}{
+			"tipo":   tipo,
+			"id":     comentarioID,
+			"accion": accion,
+			"email":  email,
+		})
+		if err != nil || accion != AccionModeracionAprobar {
+			return nil, err
+		}
+
+		res, err = tx.Run(ctx, consultas.notificar, map[string]interface{}{"id": comentarioID})
+		if err != nil {
+			return nil, err
+		}
+		if res.Next(ctx) {
+			pendiente = res.Record().Values
+		}
+		return nil, res.Err()
+	})
+	if err != nil {
+		return err
+	}
+
+	if pendiente != nil {
+		s.notificarAprobado(ctx, tipo, comentarioID, pendiente)
+	}
+	return nil
+}
+
+// notificarAprobado envía las notificaciones que quedaron pendientes de un comentario retenido por el
+// filtro, con los valores que devuelve la consulta notificar de su tipo.
+func (s *ModeracionService) notificarAprobado(ctx context.Context, tipo, comentarioID string, valores []interface{}) {
+	autor, _ := valores[0].(string)
+	nombreAutor, _ := valores[1].(string)
+	titulo, _ := valores[2].(string)
+	texto, _ := valores[3].(string)
+	contenedorID, _ := valores[4].(string)
+	autorPadre, _ := valores[5].(string)
+
+	if tipo == TipoComentarioClase {
+		notificarComentarioClase(ctx, s.Notificaciones, contenedorID, comentarioID, autor, nombreAutor, autorPadre, titulo, texto)
+	} else {
+		notificarComentarioCurso(ctx, s.Notificaciones, contenedorID, comentarioID, autor, nombreAutor, texto)
+	}
+}
+
+// estadoModeracionInicial devuelve "pendiente" si alguno de los textos contiene palabras filtradas,
+// o nil para que el comentario se publique directamente.
+func estadoModeracionInicial(textos ...string) interface{} {
+	if contienePalabrasFiltradas(textos...) {
+		return EstadoModeracionPendiente
+	}
+	return nil
+}
+
+// notificarAlAprobar devuelve true si el comentario queda pendiente de moderación, para marcarlo y
+// enviar sus notificaciones cuando se apruebe, o nil para no guardar la marca.
+func notificarAlAprobar(moderacion interface{}) interface{} {
+	if moderacion == nil {
+		return nil
+	}
+	return true
+}
+
+// contienePalabrasFiltradas indica si alguno de los textos contiene una de las palabras o frases de
+// la variable de entorno MODERACION_PALABRAS (separadas por comas). La comparación se hace por
+// palabra completa y no distingue mayúsculas ni tildes.
+func contienePalabrasFiltradas(textos ...string) bool {
+	var filtradas []string
+	for _, palabra := range strings.Split(os.Getenv("MODERACION_PALABRAS"), ",") {
+		if palabra = normalizarTexto(palabra); palabra != "" {
+			filtradas = append(filtradas, palabra)
+		}
+	}
+	if len(filtradas) == 0 {
+		return false
+	}
+
+	for _, texto := range textos {
+		normalizado := " " + normalizarTexto(texto) + " "
+		for _, palabra := range filtradas {
+			if strings.Contains(normalizado, " "+palabra+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var reemplazoTildes = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u")
+
+// normalizarTexto pasa el texto a minúsculas sin tildes y deja sus palabras separadas por un espacio.
+func normalizarTexto(texto string) string {
+	texto = reemplazoTildes.Replace(strings.ToLower(texto))
+	return strings.Join(strings.FieldsFunc(texto, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-API/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotaService gestiona las notas y los marcadores privados de los usuarios sobre las clases de los
+// cursos en los que están inscritos. Solo su autor puede ver, editar o eliminar una nota.
+type NotaService struct {
+	NotaCollection     *mongo.Collection
+	MarcadorCollection *mongo.Collection
+	CursoCollection    *mongo.Collection
+	UnidadCollection   *mongo.Collection
+	ClaseCollection    *mongo.Collection
+	RedisClient        *redis.Client
+}
+
+func NewNotaService(db *mongo.Database, redisClient *redis.Client) *NotaService {
+	return &NotaService{
+		NotaCollection:     db.Collection("notas"),
+		MarcadorCollection: db.Collection("marcadores"),
+		CursoCollection:    db.Collection("cursos"),
+		UnidadCollection:   db.Collection("unidades"),
+		ClaseCollection:    db.Collection("clases"),
+		RedisClient:        redisClient,
+	}
+}
+
+// CrearNota agrega una nota del usuario en un momento del video de una clase. El usuario debe estar
+// inscrito en el curso y la unidad de la clase no debe estar bloqueada para él.
+func (s *NotaService) CrearNota(ctx context.Context, claseID, email, password string, segundo int, texto string) (*models.Nota, error) {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, err
+	}
+
+	usuario, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	estado, err := bloqueoUnidad(ctx, s.CursoCollection, s.UnidadCollection, cursoID, clase.UnidadID, usuario)
+	if err != nil {
+		return nil, err
+	}
+	if estado.bloqueada {
+		return nil, errorClaseBloqueada(estado)
+	}
+
+	texto, err = validarNota(clase, segundo, texto)
+	if err != nil {
+		return nil, err
+	}
+
+	nota := &models.Nota{
+		ID:      primitive.NewObjectID(),
+		Usuario: email,
+		CursoID: cursoID,
+		ClaseID: clase.ID,
+		Segundo: segundo,
+		Texto:   texto,
+		Fecha:   time.Now(),
+	}
+	if _, err := s.NotaCollection.InsertOne(ctx, nota); err != nil {
+		return nil, err
+	}
+
+	nota.Clase = clase.Nombre
+	return nota, nil
+}
+
+// EditarNota cambia el momento y el texto de una nota del usuario.
+func (s *NotaService) EditarNota(ctx context.Context, id, email, password string, segundo int, texto string) (*models.Nota, error) {
+	nota, err := s.obtenerNotaPropia(ctx, id, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var clase models.Clase
+	if err := s.ClaseCollection.FindOne(ctx, bson.M{"_id": nota.ClaseID}).Decode(&clase); err == mongo.ErrNoDocuments {
+		return nil, errors.New("clase no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+
+	texto, err = validarNota(&clase, segundo, texto)
+	if err != nil {
+		return nil, err
+	}
+
+	ahora := time.Now()
+	_, err = s.NotaCollection.UpdateOne(ctx, bson.M{"_id": nota.ID}, bson.M{"$set": bson.M{
+		"segundo":    segundo,
+		"texto":      texto,
+		"editada_en": ahora,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	nota.Segundo = segundo
+	nota.Texto = texto
+	nota.EditadaEn = &ahora
+	nota.Clase = clase.Nombre
+	return nota, nil
+}
+
+// EliminarNota elimina una nota del usuario.
+func (s *NotaService) EliminarNota(ctx context.Context, id, email, password string) error {
+	nota, err := s.obtenerNotaPropia(ctx, id, email, password)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.NotaCollection.DeleteOne(ctx, bson.M{"_id": nota.ID})
+	return err
+}
+
+// ObtenerNotasPorCurso obtiene las notas del usuario en un curso, en el orden de las clases del curso
+// y, dentro de cada clase, por momento del video.
+func (s *NotaService) ObtenerNotasPorCurso(ctx context.Context, cursoID, email, password string) ([]models.Nota, error) {
+	_, _, notas, err := s.notasCurso(ctx, cursoID, email, password)
+	return notas, err
+}
+
+// ExportarNotas genera un documento Markdown con todas las notas del usuario en un curso, agrupadas por
+// unidad y clase en el orden del curso. Devuelve también el nombre del curso.
+func (s *NotaService) ExportarNotas(ctx context.Context, cursoID, email, password string) (string, []byte, error) {
+	curso, unidades, notas, err := s.notasCurso(ctx, cursoID, email, password)
+	if err != nil {
+		return "", nil, err
+	}
+
+	notasPorClase := map[primitive.ObjectID][]models.Nota{}
+	for _, nota := range notas {
+		notasPorClase[nota.ClaseID] = append(notasPorClase[nota.ClaseID], nota)
+	}
+
+	var md strings.Builder
+	fmt.Fprintf(&md, "# Notas de %s\n", curso.Nombre)
+	if len(notas) == 0 {
+		md.WriteString("\nTodavía no hay notas en este curso.\n")
+	}
+
+	for _, unidad := range unidades {
+		titulo := false
+		for _, claseID := range unidad.Clases {
+			notasClase := notasPorClase[claseID]
+			if len(notasClase) == 0 {
+				continue
+			}
+			if !titulo {
+				fmt.Fprintf(&md, "\n## %s\n", unidad.Nombre)
+				titulo = true
+			}
+			fmt.Fprintf(&md, "\n### %s\n\n", notasClase[0].Clase)
+			for _, nota := range notasClase {
+				// Las líneas siguientes se indentan para que sigan dentro del mismo elemento de la lista
+				texto := strings.ReplaceAll(nota.Texto, "\n", "\n  ")
+				fmt.Fprintf(&md, "- **[%s]** %s\n", formatearMomento(nota.Segundo), texto)
+			}
+		}
+	}
+
+	return curso.Nombre, []byte(md.String()), nil
+}
+
+// GuardarMarcador agrega la clase a los marcadores del usuario. Si ya estaba guardada devuelve el
+// marcador existente.
+func (s *NotaService) GuardarMarcador(ctx context.Context, claseID, email, password string) (*models.Marcador, error) {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID.Hex()); err != nil {
+		return nil, err
+	}
+
+	var marcador models.Marcador
+	err = s.MarcadorCollection.FindOneAndUpdate(ctx,
+		bson.M{"usuario": email, "clase_id": clase.ID},
+		bson.M{"$setOnInsert": bson.M{"curso_id": cursoID, "fecha": time.Now()}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&marcador)
+	if err != nil {
+		return nil, err
+	}
+
+	marcador.Clase = clase.Nombre
+	return &marcador, nil
+}
+
+// EliminarMarcador quita la clase de los marcadores del usuario.
+func (s *NotaService) EliminarMarcador(ctx context.Context, claseID, email, password string) error {
+	objectID, err := primitive.ObjectIDFromHex(claseID)
+	if err != nil {
+		return errors.New("ID de clase inválido")
+	}
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return err
+	}
+
+	result, err := s.MarcadorCollection.DeleteOne(ctx, bson.M{"usuario": email, "clase_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("marcador no encontrado")
+	}
+	return nil
+}
+
+// ObtenerMarcadoresPorCurso obtiene los marcadores del usuario en un curso, en el orden de las clases
+// del curso.
+func (s *NotaService) ObtenerMarcadoresPorCurso(ctx context.Context, cursoID, email, password string) ([]models.Marcador, error) {
+	if _, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID); err != nil {
+		return nil, err
+	}
+
+	curso, err := s.obtenerCurso(ctx, cursoID)
+	if err != nil {
+		return nil, err
+	}
+	_, clases, err := s.estructuraCurso(ctx, curso)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.MarcadorCollection.Find(ctx, bson.M{"usuario": email, "curso_id": curso.ID})
+	if err != nil {
+		return nil, err
+	}
+	var todos []models.Marcador
+	if err := cursor.All(ctx, &todos); err != nil {
+		return nil, err
+	}
+
+	marcadores := []models.Marcador{}
+	for _, marcador := range todos {
+		if clase, ok := clases[marcador.ClaseID]; ok {
+			marcador.Clase = clase.nombre
+			marcadores = append(marcadores, marcador)
+		}
+	}
+	sort.SliceStable(marcadores, func(i, j int) bool {
+		return clases[marcadores[i].ClaseID].posicion < clases[marcadores[j].ClaseID].posicion
+	})
+
+	return marcadores, nil
+}
+
+// notasCurso verifica que el usuario esté inscrito en el curso y obtiene el curso, sus unidades en
+// orden y las notas del usuario ordenadas como las clases del curso. Se omiten las notas de clases que
+// ya no forman parte del curso.
+func (s *NotaService) notasCurso(ctx context.Context, cursoID, email, password string) (*models.Curso, []models.Unidad, []models.Nota, error) {
+	if _, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	curso, err := s.obtenerCurso(ctx, cursoID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	unidades, clases, err := s.estructuraCurso(ctx, curso)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	opciones := options.Find().SetSort(bson.D{{Key: "segundo", Value: 1}, {Key: "fecha", Value: 1}})
+	cursor, err := s.NotaCollection.Find(ctx, bson.M{"usuario": email, "curso_id": curso.ID}, opciones)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var todas []models.Nota
+	if err := cursor.All(ctx, &todas); err != nil {
+		return nil, nil, nil, err
+	}
+
+	notas := []models.Nota{}
+	for _, nota := range todas {
+		if clase, ok := clases[nota.ClaseID]; ok {
+			nota.Clase = clase.nombre
+			notas = append(notas, nota)
+		}
+	}
+	sort.SliceStable(notas, func(i, j int) bool {
+		return clases[notas[i].ClaseID].posicion < clases[notas[j].ClaseID].posicion
+	})
+
+	return curso, unidades, notas, nil
+}
+
+// obtenerNotaPropia verifica las credenciales y obtiene una nota del usuario. Las notas de otros
+// usuarios se tratan como inexistentes.
+func (s *NotaService) obtenerNotaPropia(ctx context.Context, id, email, password string) (*models.Nota, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	var nota models.Nota
+	if err := s.NotaCollection.FindOne(ctx, bson.M{"_id": objectID, "usuario": email}).Decode(&nota); err == mongo.ErrNoDocuments {
+		return nil, errors.New("nota no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+	return &nota, nil
+}
+
+func (s *NotaService) obtenerCurso(ctx context.Context, cursoID string) (*models.Curso, error) {
+	objectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return nil, errors.New("ID de curso inválido")
+	}
+
+	var curso models.Curso
+	if err := s.CursoCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+	return &curso, nil
+}
+
+// claseOrdenada es el nombre de una clase y su posición entre todas las clases del curso.
+type claseOrdenada struct {
+	nombre   string
+	posicion int
+}
+
+// estructuraCurso obtiene las unidades del curso en el orden del curso y sus clases por ID.
+func (s *NotaService) estructuraCurso(ctx context.Context, curso *models.Curso) ([]models.Unidad, map[primitive.ObjectID]claseOrdenada, error) {
+	cursor, err := s.UnidadCollection.Find(ctx, bson.M{"_id": bson.M{"$in": curso.Unidades}})
+	if err != nil {
+		return nil, nil, err
+	}
+	var encontradas []models.Unidad
+	if err := cursor.All(ctx, &encontradas); err != nil {
+		return nil, nil, err
+	}
+
+	porID := map[primitive.ObjectID]models.Unidad{}
+	idsClases := []primitive.ObjectID{}
+	for _, unidad := range encontradas {
+		porID[unidad.ID] = unidad
+		idsClases = append(idsClases, unidad.Clases...)
+	}
+
+	cursor, err = s.ClaseCollection.Find(ctx, bson.M{"_id": bson.M{"$in": idsClases}},
+		options.Find().SetProjection(bson.M{"nombre": 1}))
+	if err != nil {
+		return nil, nil, err
+	}
+	var encontradasClases []models.Clase
+	if err := cursor.All(ctx, &encontradasClases); err != nil {
+		return nil, nil, err
+	}
+	nombres := map[primitive.ObjectID]string{}
+	for _, clase := range encontradasClases {
+		nombres[clase.ID] = clase.Nombre
+	}
+
+	unidades := []models.Unidad{}
+	clases := map[primitive.ObjectID]claseOrdenada{}
+	for _, unidadID := range curso.Unidades {
+		unidad, ok := porID[unidadID]
+		if !ok {
+			continue
+		}
+		unidades = append(unidades, unidad)
+		for _, claseID := range unidad.Clases {
+			if nombre, ok := nombres[claseID]; ok {
+				clases[claseID] = claseOrdenada{nombre: nombre, posicion: len(clases)}
+			}
+		}
+	}
+
+	return unidades, clases, nil
+}
+
+// validarNota verifica que el momento esté dentro de la duración de la clase, si se conoce, y que el
+// texto no esté vacío. Devuelve el texto sin espacios al principio ni al final.
+func validarNota(clase *models.Clase, segundo int, texto string) (string, error) {
+	if segundo < 0 || (clase.Duracion > 0 && segundo > clase.Duracion) {
+		return "", errors.New("el momento de la nota está fuera de la duración de la clase")
+	}
+	texto = strings.TrimSpace(texto)
+	if texto == "" {
+		return "", errors.New("el texto de la nota no puede estar vacío")
+	}
+	return texto, nil
+}
+
+// formatearMomento escribe un momento del video como mm:ss, o h:mm:ss si dura una hora o más.
+func formatearMomento(segundos int) string {
+	h, m, s := segundos/3600, segundos%3600/60, segundos%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
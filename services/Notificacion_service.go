@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-API/correo"
+	"go-API/models"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	NotificacionRespuesta   = "respuesta"
+	NotificacionMencion     = "mencion"
+	NotificacionInscripcion = "inscripcion"
+	NotificacionCertificado = "certificado"
+	NotificacionAnuncio     = "anuncio"
+
+	// maxNotificaciones es la cantidad de notificaciones que se conservan por usuario
+	maxNotificaciones = 200
+)
+
+// TiposNotificacion son los tipos de notificación que el usuario puede activar o desactivar.
+var TiposNotificacion = []string{NotificacionRespuesta, NotificacionMencion, NotificacionInscripcion, NotificacionCertificado, NotificacionAnuncio}
+
+// plantillasCorreo indica qué tipos de notificación se envían también por correo y con qué plantilla.
+var plantillasCorreo = map[string]string{
+	NotificacionRespuesta:   correo.PlantillaRespuesta,
+	NotificacionInscripcion: correo.PlantillaInscripcion,
+	NotificacionCertificado: correo.PlantillaCertificado,
+}
+
+// expresionMencion reconoce las menciones a usuarios, escritas como "@" seguido de su email.
+var expresionMencion = regexp.MustCompile(`@([\w.+-]+@[\w-]+(?:\.[\w-]+)+)`)
+
+// NotificacionService gestiona las notificaciones dentro de la aplicación. Cada usuario tiene en Redis
+// una lista con sus notificaciones más recientes (notificaciones:<email>), un conjunto con los IDs de
+// las ya leídas (notificaciones:leidas:<email>) y un hash con sus preferencias por tipo
+// (notificaciones:preferencias:<email>); los tipos sin preferencia guardada están activados. Los
+// tipos de plantillasCorreo se envían además por correo.
+type NotificacionService struct {
+	RedisClient *redis.Client
+	Driver      neo4j.DriverWithContext
+	Correo      *CorreoService
+}
+
+func NewNotificacionService(redisClient *redis.Client, driver neo4j.DriverWithContext, correo *CorreoService) *NotificacionService {
+	return &NotificacionService{
+		RedisClient: redisClient,
+		Driver:      driver,
+		Correo:      correo,
+	}
+}
+
+func claveNotificaciones(email string) string {
+	return "notificaciones:" + email
+}
+
+func claveNotificacionesLeidas(email string) string {
+	return "notificaciones:leidas:" + email
+}
+
+func clavePreferenciasNotificacion(email string) string {
+	return "notificaciones:preferencias:" + email
+}
+
+// Notificar agrega una notificación a la lista del usuario y, si el tipo lo prevé, le envía el correo
+// correspondiente, salvo que haya desactivado ese tipo. Los datos se usan también en la plantilla.
+func (s *NotificacionService) Notificar(ctx context.Context, email, tipo, mensaje string, datos map[string]string) error {
+	activa, err := s.RedisClient.HGet(ctx, clavePreferenciasNotificacion(email), tipo).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	if activa == "0" {
+		return nil
+	}
+
+	data, err := json.Marshal(models.Notificacion{
+		ID:      uuid.New().String(),
+		Tipo:    tipo,
+		Mensaje: mensaje,
+		Datos:   datos,
+		Fecha:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	pipe := s.RedisClient.TxPipeline()
+	pipe.LPush(ctx, claveNotificaciones(email), data)
+	pipe.LTrim(ctx, claveNotificaciones(email), 0, maxNotificaciones-1)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	if plantilla, ok := plantillasCorreo[tipo]; ok {
+		s.Correo.encolar(ctx, email, plantilla, datos)
+	}
+	return nil
+}
+
+// notificarEnSegundoPlano envía una notificación en una goroutine con un contexto propio, para que
+// no demore la petición que la originó ni se pierda si esta se cancela. Los errores se registran en
+// el log.
+func (s *NotificacionService) notificarEnSegundoPlano(email, tipo, mensaje string, datos map[string]string) {
+	if s == nil {
+		return
+	}
+	go s.notificarRegistrandoError(context.Background(), email, tipo, mensaje, datos)
+}
+
+// notificarRegistrandoError envía una notificación registrando el error en el log en lugar de
+// devolverlo, para que un fallo al notificar no haga fallar la operación que la originó.
+func (s *NotificacionService) notificarRegistrandoError(ctx context.Context, email, tipo, mensaje string, datos map[string]string) {
+	if s == nil {
+		return
+	}
+	if err := s.Notificar(ctx, email, tipo, mensaje, datos); err != nil {
+		log.Printf("Error al notificar a %s (%s): %v", email, tipo, err)
+	}
+}
+
+// NotificarMenciones notifica a los usuarios mencionados en los textos, excepto al autor. Solo se
+// notifica a los emails que corresponden a un usuario existente.
+func (s *NotificacionService) NotificarMenciones(ctx context.Context, autor, mensaje string, datos map[string]string, textos ...string) {
+	if s == nil {
+		return
+	}
+
+	mencionados := extraerMenciones(textos...)
+	delete(mencionados, strings.ToLower(autor))
+	if len(mencionados) == 0 {
+		return
+	}
+
+	emails := make([]string, 0, len(mencionados))
+	for email := range mencionados {
+		emails = append(emails, email)
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		res, err := tx.Run(ctx, `
+            MATCH (u:Usuario)
+            WHERE toLower(u.email) IN $emails
+            RETURN u.email
+        `, map[string]interface{}{"emails": emails})
+		if err != nil {
+			return nil, err
+		}
+		var existentes []string
+		for res.Next(ctx) {
+			if email, ok := res.Record().Values[0].(string); ok {
+				existentes = append(existentes, email)
+			}
+		}
+		return existentes, res.Err()
+	})
+	if err != nil {
+		log.Printf("Error al obtener los usuarios mencionados: %v", err)
+		return
+	}
+
+	for _, email := range result.([]string) {
+		s.notificarEnSegundoPlano(email, NotificacionMencion, mensaje, datos)
+	}
+}
+
+// extraerMenciones devuelve los emails mencionados en los textos, en minúsculas y sin repetir.
+func extraerMenciones(textos ...string) map[string]bool {
+	mencionados := map[string]bool{}
+	for _, texto := range textos {
+		for _, mencion := range expresionMencion.FindAllStringSubmatch(texto, -1) {
+			mencionados[strings.ToLower(mencion[1])] = true
+		}
+	}
+	return mencionados
+}
+
+// ObtenerNotificaciones obtiene las notificaciones de un usuario, de la más reciente a la más antigua.
+// Si soloNoLeidas es verdadero se omiten las ya leídas.
+func (s *NotificacionService) ObtenerNotificaciones(ctx context.Context, email, password string, soloNoLeidas bool, limite int) ([]models.Notificacion, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	todas, err := s.leerNotificaciones(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	notificaciones := []models.Notificacion{}
+	for _, notificacion := range todas {
+		if soloNoLeidas && notificacion.Leida {
+			continue
+		}
+		notificaciones = append(notificaciones, notificacion)
+		if len(notificaciones) == limite {
+			break
+		}
+	}
+	return notificaciones, nil
+}
+
+// ContarNoLeidas obtiene la cantidad de notificaciones sin leer de un usuario.
+func (s *NotificacionService) ContarNoLeidas(ctx context.Context, email, password string) (int, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return 0, err
+	}
+
+	notificaciones, err := s.leerNotificaciones(ctx, email)
+	if err != nil {
+		return 0, err
+	}
+
+	noLeidas := 0
+	for _, notificacion := range notificaciones {
+		if !notificacion.Leida {
+			noLeidas++
+		}
+	}
+	return noLeidas, nil
+}
+
+// MarcarLeidas marca como leídas las notificaciones indicadas, o todas si no se indica ninguna.
+// Los IDs que ya no están en la lista del usuario se ignoran.
+func (s *NotificacionService) MarcarLeidas(ctx context.Context, email, password string, ids []string) error {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return err
+	}
+
+	notificaciones, err := s.leerNotificaciones(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	marcar := map[string]bool{}
+	for _, id := range ids {
+		marcar[id] = true
+	}
+
+	// El conjunto se reconstruye con los IDs vigentes para descartar los de notificaciones ya eliminadas
+	leidas := []interface{}{}
+	for _, notificacion := range notificaciones {
+		if notificacion.Leida || len(ids) == 0 || marcar[notificacion.ID] {
+			leidas = append(leidas, notificacion.ID)
+		}
+	}
+
+	pipe := s.RedisClient.TxPipeline()
+	pipe.Del(ctx, claveNotificacionesLeidas(email))
+	if len(leidas) > 0 {
+		pipe.SAdd(ctx, claveNotificacionesLeidas(email), leidas...)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ObtenerPreferencias obtiene qué tipos de notificación tiene activados un usuario.
+func (s *NotificacionService) ObtenerPreferencias(ctx context.Context, email, password string) (map[string]bool, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	guardadas, err := s.RedisClient.HGetAll(ctx, clavePreferenciasNotificacion(email)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	preferencias := map[string]bool{}
+	for _, tipo := range TiposNotificacion {
+		preferencias[tipo] = guardadas[tipo] != "0"
+	}
+	return preferencias, nil
+}
+
+// ActualizarPreferencias activa o desactiva tipos de notificación para un usuario. Los tipos no
+// incluidos conservan su preferencia actual.
+func (s *NotificacionService) ActualizarPreferencias(ctx context.Context, email, password string, cambios map[string]bool) (map[string]bool, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	valores := map[string]interface{}{}
+	for tipo, activa := range cambios {
+		if !esTipoNotificacion(tipo) {
+			return nil, errors.New("tipo de notificación inválido: " + tipo)
+		}
+		valores[tipo] = "0"
+		if activa {
+			valores[tipo] = "1"
+		}
+	}
+
+	if len(valores) > 0 {
+		if err := s.RedisClient.HSet(ctx, clavePreferenciasNotificacion(email), valores).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.ObtenerPreferencias(ctx, email, password)
+}
+
+// leerNotificaciones obtiene todas las notificaciones guardadas de un usuario con su estado de lectura.
+func (s *NotificacionService) leerNotificaciones(ctx context.Context, email string) ([]models.Notificacion, error) {
+	pipe := s.RedisClient.Pipeline()
+	lista := pipe.LRange(ctx, claveNotificaciones(email), 0, -1)
+	leidas := pipe.SMembers(ctx, claveNotificacionesLeidas(email))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	idsLeidos := map[string]bool{}
+	for _, id := range leidas.Val() {
+		idsLeidos[id] = true
+	}
+
+	notificaciones := []models.Notificacion{}
+	for _, data := range lista.Val() {
+		var notificacion models.Notificacion
+		if err := json.Unmarshal([]byte(data), &notificacion); err != nil {
+			log.Printf("Notificación mal formada para %s: %v", email, err)
+			continue
+		}
+		notificacion.Leida = idsLeidos[notificacion.ID]
+		notificaciones = append(notificaciones, notificacion)
+	}
+	return notificaciones, nil
+}
+
+func esTipoNotificacion(tipo string) bool {
+	for _, t := range TiposNotificacion {
+		if t == tipo {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-API/almacenamiento"
+	"go-API/models"
+	"image"
+	"image/draw"
+	_ "image/gif" // Registrar el formato GIF para image.Decode
+	"image/jpeg"
+	_ "image/png" // Registrar el formato PNG para image.Decode
+	"io"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	TamanoPortadaOriginal = "original"
+	TamanoPortadaPequena  = "pequena"
+	TamanoPortadaMediana  = "mediana"
+
+	// MaxTamanoPortada es el tamaño máximo en bytes de la imagen de portada.
+	MaxTamanoPortada = 10 << 20
+
+	minAnchoPortada = 320
+	minAltoPortada  = 180
+	maxLadoPortada  = 4096
+)
+
+// anchosMiniatura son los anchos en píxeles de las miniaturas que se generan de cada portada.
+var anchosMiniatura = map[string]int{
+	TamanoPortadaPequena: 240,
+	TamanoPortadaMediana: 640,
+}
+
+// tiposPortada son los formatos de imagen aceptados, según el nombre que les da image.DecodeConfig.
+var tiposPortada = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+// PortadaService gestiona las imágenes de portada de los cursos. La imagen original y sus miniaturas
+// en JPEG se guardan en el almacenamiento bajo portadas/<curso>/<versión>/<tamaño>.
+type PortadaService struct {
+	CursoCollection *mongo.Collection
+	RedisClient     *redis.Client
+	Almacenamiento  almacenamiento.Almacenamiento
+}
+
+func NewPortadaService(db *mongo.Database, redisClient *redis.Client, almacen almacenamiento.Almacenamiento) *PortadaService {
+	return &PortadaService{
+		CursoCollection: db.Collection("cursos"),
+		RedisClient:     redisClient,
+		Almacenamiento:  almacen,
+	}
+}
+
+func clavePortada(cursoID primitive.ObjectID, version, tamano string) string {
+	return "portadas/" + cursoID.Hex() + "/" + version + "/" + tamano
+}
+
+func urlPortada(cursoID primitive.ObjectID, version, tamano string) string {
+	return "/api/cursos/" + cursoID.Hex() + "/portada/" + tamano + "?v=" + version
+}
+
+// SubirPortada valida la imagen de portada de un curso, genera sus miniaturas y la reemplaza como
+// imagen del curso. Solo el instructor del curso o un administrador puede hacerlo.
+func (s *PortadaService) SubirPortada(ctx context.Context, cursoID, email, password string, contenido io.Reader) (*models.PortadaCurso, error) {
+	curso, err := s.obtenerCurso(ctx, cursoID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+	if curso.Instructor != email && !esAdmin(email) {
+		return nil, errors.New("solo el instructor del curso o un administrador puede cambiar la portada")
+	}
+
+	datos, err := io.ReadAll(io.LimitReader(contenido, MaxTamanoPortada+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(datos) > MaxTamanoPortada {
+		return nil, fmt.Errorf("la imagen supera el tamaño máximo de %d MB", MaxTamanoPortada>>20)
+	}
+
+	// Se validan formato y dimensiones antes de decodificar la imagen completa
+	config, formato, err := image.DecodeConfig(bytes.NewReader(datos))
+	tipoContenido, ok := tiposPortada[formato]
+	if err != nil || !ok {
+		return nil, errors.New("formato de imagen no permitido")
+	}
+	if config.Width < minAnchoPortada || config.Height < minAltoPortada || config.Width > maxLadoPortada || config.Height > maxLadoPortada {
+		return nil, fmt.Errorf("la imagen debe medir entre %dx%d y %dx%d píxeles", minAnchoPortada, minAltoPortada, maxLadoPortada, maxLadoPortada)
+	}
+
+	imagen, _, err := image.Decode(bytes.NewReader(datos))
+	if err != nil {
+		return nil, errors.New("formato de imagen no permitido")
+	}
+
+	portada := &models.PortadaCurso{
+		Version:       primitive.NewObjectID().Hex(),
+		TipoContenido: tipoContenido,
+		Ancho:         config.Width,
+		Alto:          config.Height,
+		Fecha:         time.Now(),
+	}
+	portada.URL = urlPortada(curso.ID, portada.Version, TamanoPortadaOriginal)
+	portada.URLPequena = urlPortada(curso.ID, portada.Version, TamanoPortadaPequena)
+	portada.URLMediana = urlPortada(curso.ID, portada.Version, TamanoPortadaMediana)
+
+	archivos := map[string][]byte{TamanoPortadaOriginal: datos}
+	opaca := sobreFondoBlanco(imagen)
+	for tamano, ancho := range anchosMiniatura {
+		var miniatura bytes.Buffer
+		if err := jpeg.Encode(&miniatura, redimensionar(opaca, ancho), &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		archivos[tamano] = miniatura.Bytes()
+	}
+
+	guardados := []string{}
+	for tamano, archivo := range archivos {
+		clave := clavePortada(curso.ID, portada.Version, tamano)
+		if _, err := s.Almacenamiento.Guardar(ctx, clave, bytes.NewReader(archivo)); err != nil {
+			s.eliminarArchivos(ctx, guardados)
+			return nil, err
+		}
+		guardados = append(guardados, clave)
+	}
+
+	_, err = s.CursoCollection.UpdateOne(ctx, bson.M{"_id": curso.ID}, bson.M{"$set": bson.M{
+		"portada":    portada,
+		"imagen_url": portada.URL,
+	}})
+	if err != nil {
+		s.eliminarArchivos(ctx, guardados)
+		return nil, err
+	}
+
+	// Las URLs anteriores dejan de funcionar; los clientes obtienen las nuevas del curso
+	if curso.Portada != nil {
+		s.eliminarArchivos(ctx, s.clavesPortada(curso.ID, curso.Portada.Version))
+	}
+	return portada, nil
+}
+
+// AbrirPortada devuelve la imagen de portada de un curso en el tamaño indicado, con su tipo de
+// contenido y la versión vigente. El usuario debe poder ver el curso.
+func (s *PortadaService) AbrirPortada(ctx context.Context, cursoID, tamano, email, password string) (io.ReadCloser, string, string, error) {
+	if tamano != TamanoPortadaOriginal && anchosMiniatura[tamano] == 0 {
+		return nil, "", "", errors.New("tamaño de portada inválido")
+	}
+
+	curso, err := s.obtenerCurso(ctx, cursoID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	usuario, err := usuarioOpcional(ctx, s.RedisClient, email, password)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if !cursoVisible(*curso, usuario) {
+		return nil, "", "", errors.New("curso no encontrado")
+	}
+	if curso.Portada == nil {
+		return nil, "", "", errors.New("el curso no tiene portada")
+	}
+
+	archivo, err := s.Almacenamiento.Abrir(ctx, clavePortada(curso.ID, curso.Portada.Version, tamano))
+	if err == almacenamiento.ErrNoEncontrado {
+		return nil, "", "", errors.New("el curso no tiene portada")
+	} else if err != nil {
+		return nil, "", "", err
+	}
+
+	tipoContenido := "image/jpeg"
+	if tamano == TamanoPortadaOriginal {
+		tipoContenido = curso.Portada.TipoContenido
+	}
+	return archivo, tipoContenido, curso.Portada.Version, nil
+}
+
+func (s *PortadaService) obtenerCurso(ctx context.Context, id string) (*models.Curso, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("ID de curso inválido")
+	}
+
+	var curso models.Curso
+	if err := s.CursoCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+	return &curso, nil
+}
+
+func (s *PortadaService) clavesPortada(cursoID primitive.ObjectID, version string) []string {
+	claves := []string{clavePortada(cursoID, version, TamanoPortadaOriginal)}
+	for tamano := range anchosMiniatura {
+		claves = append(claves, clavePortada(cursoID, version, tamano))
+	}
+	return claves
+}
+
+func (s *PortadaService) eliminarArchivos(ctx context.Context, claves []string) {
+	for _, clave := range claves {
+		eliminarArchivo(ctx, s.Almacenamiento, clave)
+	}
+}
+
+// sobreFondoBlanco dibuja la imagen sobre un fondo blanco, ya que las miniaturas se guardan en JPEG,
+// que no admite transparencia.
+func sobreFondoBlanco(imagen image.Image) *image.RGBA {
+	limites := imagen.Bounds()
+	opaca := image.NewRGBA(image.Rect(0, 0, limites.Dx(), limites.Dy()))
+	draw.Draw(opaca, opaca.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(opaca, opaca.Bounds(), imagen, limites.Min, draw.Over)
+	return opaca
+}
+
+// redimensionar reduce la imagen al ancho indicado conservando la proporción. Cada píxel de destino
+// es el promedio del área que cubre en la imagen original. Las imágenes más angostas se devuelven
+// sin cambios.
+func redimensionar(imagen *image.RGBA, ancho int) *image.RGBA {
+	anchoOriginal, altoOriginal := imagen.Bounds().Dx(), imagen.Bounds().Dy()
+	if ancho >= anchoOriginal {
+		return imagen
+	}
+	alto := max(1, altoOriginal*ancho/anchoOriginal)
+
+	reducida := image.NewRGBA(image.Rect(0, 0, ancho, alto))
+	for y := 0; y < alto; y++ {
+		y0, y1 := y*altoOriginal/alto, (y+1)*altoOriginal/alto
+		for x := 0; x < ancho; x++ {
+			x0, x1 := x*anchoOriginal/ancho, (x+1)*anchoOriginal/ancho
+
+			var suma [4]int
+			for oy := y0; oy < y1; oy++ {
+				i := imagen.PixOffset(x0, oy)
+				for ox := x0; ox < x1; ox++ {
+					for c := 0; c < 4; c++ {
+						suma[c] += int(imagen.Pix[i+c])
+					}
+					i += 4
+				}
+			}
+
+			n := (x1 - x0) * (y1 - y0)
+			j := reducida.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				reducida.Pix[j+c] = uint8(suma[c] / n)
+			}
+		}
+	}
+	return reducida
+}
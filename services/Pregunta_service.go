@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-API/models"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	EstadoPreguntaSinResponder = "sin_responder"
+	EstadoPreguntaRespondida   = "respondidas"
+	EstadoPreguntaTodas        = "todas"
+)
+
+// PreguntaService gestiona el modo de preguntas y respuestas de los comentarios de clase: la
+// respuesta aceptada de cada pregunta y las listas de preguntas pendientes de los instructores.
+// Una pregunta se considera respondida si tiene una respuesta aceptada o si el instructor del
+// curso la respondió.
+type PreguntaService struct {
+	Driver          neo4j.DriverWithContext
+	CursoCollection *mongo.Collection
+	RedisClient     *redis.Client
+}
+
+func NewPreguntaService(driver neo4j.DriverWithContext, cursoCollection *mongo.Collection, redisClient *redis.Client) *PreguntaService {
+	return &PreguntaService{
+		Driver:          driver,
+		CursoCollection: cursoCollection,
+		RedisClient:     redisClient,
+	}
+}
+
+// consultaPreguntas devuelve la consulta de las preguntas visibles de los cursos que cumplen el
+// filtro indicado (sobre el nodo "curso"), filtradas por $estado y de la más antigua a la más reciente.
+func consultaPreguntas(filtroCurso string) string {
+	return `
+        MATCH (curso:Curso)-[:CONTENEDOR_DE]->(:Unidad)-[:CONTENEDOR_DE]->(clase:Clase)<-[:PERTENECE_A]-(p:Comentario {esPregunta: true})
+        WHERE ` + filtroCurso + `
+          AND coalesce(p.eliminado, false) = false AND coalesce(p.moderacion, $aprobado) = $aprobado
+        OPTIONAL MATCH (r:Comentario)-[:RESPONDE_A]->(p)
+        WHERE coalesce(r.eliminado, false) = false AND coalesce(r.moderacion, $aprobado) = $aprobado
+        WITH curso, clase, p, COUNT(r) AS respuestas, collect(r.autor) AS autores
+        WITH curso, clase, p, respuestas,
+             p.respuestaAceptadaId IS NOT NULL OR coalesce(curso.instructor IN autores, false) AS respondida
+        WHERE $estado = 'todas' OR respondida = ($estado = 'respondidas')
+        RETURN p.id, curso.id, curso.nombre, clase.id, clase.nombre, p.autor, p.titulo, p.detalle, p.fecha,
+               respuestas, respondida, p.respuestaAceptadaId
+        ORDER BY p.fecha ASC
+    `
+}
+
+// ObtenerPreguntasPorCurso obtiene las preguntas de un curso según su estado: "sin_responder",
+// "respondidas" o "todas". El usuario debe poder ver el curso.
+func (s *PreguntaService) ObtenerPreguntasPorCurso(ctx context.Context, cursoID, email, password, estado string) ([]models.Pregunta, error) {
+	if err := verificarCursoVisible(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password); err != nil {
+		return nil, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		checkResult, err := tx.Run(ctx, `MATCH (c:Curso {id: $cursoID}) RETURN c.id`, map[string]interface{}{"cursoID": cursoID})
+		if err != nil {
+			return nil, err
+		}
+		if !checkResult.Next(ctx) {
+			return nil, errors.New("curso no encontrado")
+		}
+
+		return leerPreguntas(ctx, tx, consultaPreguntas("curso.id = $cursoID"), map[string]interface{}{
+			"cursoID": cursoID,
+			"estado":  estado,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]models.Pregunta), nil
+}
+
+// ObtenerPanelInstructor obtiene las preguntas sin responder de todos los cursos del instructor,
+// agrupadas por curso. Los cursos sin preguntas pendientes se incluyen con una lista vacía.
+func (s *PreguntaService) ObtenerPanelInstructor(ctx context.Context, email, password string) ([]models.PreguntasCurso, int, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, 0, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		cursosResult, err := tx.Run(ctx, `
+            MATCH (curso:Curso {instructor: $email})
+            RETURN curso.id, curso.nombre
+            ORDER BY curso.nombre
+        `, map[string]interface{}{"email": email})
+		if err != nil {
+			return nil, err
+		}
+
+		cursos := []models.PreguntasCurso{}
+		indices := map[string]int{}
+		for cursosResult.Next(ctx) {
+			record := cursosResult.Record()
+			curso := models.PreguntasCurso{Preguntas: []models.Pregunta{}}
+			curso.CursoID, _ = record.Values[0].(string)
+			curso.Curso, _ = record.Values[1].(string)
+			indices[curso.CursoID] = len(cursos)
+			cursos = append(cursos, curso)
+		}
+		if err = cursosResult.Err(); err != nil {
+			return nil, err
+		}
+
+		preguntas, err := leerPreguntas(ctx, tx, consultaPreguntas("curso.instructor = $email"), map[string]interface{}{
+			"email":  email,
+			"estado": EstadoPreguntaSinResponder,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pregunta := range preguntas {
+			i := indices[pregunta.CursoID]
+			cursos[i].Preguntas = append(cursos[i].Preguntas, pregunta)
+			cursos[i].SinResponder++
+		}
+		return cursos, nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursos := result.([]models.PreguntasCurso)
+	total := 0
+	for _, curso := range cursos {
+		total += curso.SinResponder
+	}
+	return cursos, total, nil
+}
+
+// AceptarRespuesta marca una respuesta directa de una pregunta como su respuesta aceptada. Solo el
+// instructor del curso o un administrador puede hacerlo; aceptar otra respuesta reemplaza la anterior.
+func (s *PreguntaService) AceptarRespuesta(ctx context.Context, preguntaID, respuestaID, email, password string) error {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		checkQuery := `
+            MATCH (p:Comentario {id: $preguntaID})-[:PERTENECE_A]->(:Clase)<-[:CONTENEDOR_DE]-(:Unidad)<-[:CONTENEDOR_DE]-(curso:Curso)
+            WHERE coalesce(p.eliminado, false) = false
+            OPTIONAL MATCH (r:Comentario {id: $respuestaID})-[:RESPONDE_A]->(p)
+            WHERE coalesce(r.eliminado, false) = false AND coalesce(r.moderacion, $aprobado) = $aprobado
+            RETURN coalesce(p.esPregunta, false), coalesce(curso.instructor = $email, false) OR $admin, r IS NOT NULL
+        `
+		checkResult, err := tx.Run(ctx, checkQuery, map[string]interface{}{
+			"preguntaID":  preguntaID,
+			"respuestaID": respuestaID,
+			"email":       email,
+			"admin":       esAdmin(email),
+			"aprobado":    EstadoModeracionAprobado,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !checkResult.Next(ctx) {
+			return nil, errors.New("comentario no encontrado")
+		}
+		record := checkResult.Record()
+		if !record.Values[0].(bool) {
+			return nil, errors.New("el comentario no es una pregunta")
+		}
+		if !record.Values[1].(bool) {
+			return nil, errors.New("solo el instructor del curso o un administrador puede aceptar respuestas")
+		}
+		if !record.Values[2].(bool) {
+			return nil, errors.New("la respuesta no pertenece a la pregunta")
+		}
+
+		updateQuery := `
+            MATCH (p:Comentario {id: $preguntaID})
+            SET p.respuestaAceptadaId = $respuestaID,
+                p.aceptadaPor = $email,
+                p.aceptadaEn = datetime()
+        `
+		_, err = tx.Run(ctx, updateQuery, map[string]interface{}{
+			"preguntaID":  preguntaID,
+			"respuestaID": respuestaID,
+			"email":       email,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// leerPreguntas ejecuta una consulta generada por consultaPreguntas y construye las preguntas.
+func leerPreguntas(ctx context.Context, tx neo4j.ManagedTransaction, query string, params map[string]interface{}) ([]models.Pregunta, error) {
+	params["aprobado"] = EstadoModeracionAprobado
+	res, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	preguntas := []models.Pregunta{}
+	for res.Next(ctx) {
+		record := res.Record()
+		pregunta := models.Pregunta{}
+		pregunta.ID, _ = record.Values[0].(string)
+		pregunta.CursoID, _ = record.Values[1].(string)
+		pregunta.Curso, _ = record.Values[2].(string)
+		pregunta.ClaseID, _ = record.Values[3].(string)
+		pregunta.Clase, _ = record.Values[4].(string)
+		pregunta.Autor, _ = record.Values[5].(string)
+		pregunta.Titulo, _ = record.Values[6].(string)
+		pregunta.Detalle, _ = record.Values[7].(string)
+		pregunta.Fecha, _ = record.Values[8].(time.Time)
+		pregunta.CantRespuestas = int(record.Values[9].(int64))
+		pregunta.Respondida, _ = record.Values[10].(bool)
+		pregunta.RespuestaAceptadaID, _ = record.Values[11].(string)
+		preguntas = append(preguntas, pregunta)
+	}
+
+	return preguntas, res.Err()
+}
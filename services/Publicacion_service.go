@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-API/models"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const errorInstructorEstadoCurso = "solo el instructor del curso o un administrador puede cambiar el estado del curso"
+
+// filtroCursosPublicados selecciona los cursos publicados, incluidos los que no tienen estado guardado.
+var filtroCursosPublicados = bson.M{"estado": bson.M{"$in": bson.A{models.EstadoCursoPublicado, nil}}}
+
+// cursoVisible indica si un usuario, o un usuario anónimo si es nil, puede ver un curso. Los cursos
+// publicados son visibles para todos y los archivados también para sus inscritos. El instructor del
+// curso y los administradores pueden ver el curso en cualquier estado.
+func cursoVisible(curso models.Curso, usuario *models.Usuario) bool {
+	switch {
+	case curso.EstadoActual() == models.EstadoCursoPublicado:
+		return true
+	case usuario == nil:
+		return false
+	case curso.Instructor == usuario.Email || esAdmin(usuario.Email):
+		return true
+	case curso.EstadoActual() == models.EstadoCursoArchivado:
+		return contains(usuario.Inscritos, curso.ID)
+	}
+	return false
+}
+
+// obtenerCursoVisible obtiene un curso si el usuario puede verlo. Los cursos que no puede ver se
+// tratan como inexistentes.
+func obtenerCursoVisible(ctx context.Context, cursos *mongo.Collection, cursoID primitive.ObjectID, usuario *models.Usuario) (*models.Curso, error) {
+	var curso models.Curso
+	if err := cursos.FindOne(ctx, bson.M{"_id": cursoID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !cursoVisible(curso, usuario) {
+		return nil, errors.New("curso no encontrado")
+	}
+	return &curso, nil
+}
+
+// verificarCursoVisible verifica que el usuario de las credenciales, o un usuario anónimo si no se
+// indicaron, pueda ver el curso. Se usa en las lecturas públicas del contenido de un curso, sus
+// unidades y sus clases.
+func verificarCursoVisible(ctx context.Context, redisClient *redis.Client, cursos *mongo.Collection, cursoID, email, password string) error {
+	objectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return errors.New("curso no encontrado")
+	}
+	usuario, err := usuarioOpcional(ctx, redisClient, email, password)
+	if err != nil {
+		return err
+	}
+	_, err = obtenerCursoVisible(ctx, cursos, objectID, usuario)
+	return err
+}
+
+// PublicarCurso publica un curso en borrador o archivado. Si se indica una fecha futura, en cambio,
+// programa la publicación de un borrador para esa fecha. El curso debe tener al menos una unidad con
+// una clase. Solo el instructor del curso o un administrador puede hacerlo.
+func (s *CursoService) PublicarCurso(ctx context.Context, id, email, password string, fecha *time.Time) (*models.Curso, error) {
+	curso, err := s.obtenerCursoEditable(ctx, id, email, password)
+	if err != nil {
+		return nil, err
+	}
+	if curso.EstadoActual() == models.EstadoCursoPublicado {
+		return nil, errors.New("el curso ya está publicado")
+	}
+	if err := s.validarContenidoPublicable(ctx, curso); err != nil {
+		return nil, err
+	}
+
+	ahora := time.Now()
+	cambios := bson.M{
+		"$set":   bson.M{"estado": models.EstadoCursoPublicado, "publicado_en": ahora},
+		"$unset": bson.M{"publicacion_programada": ""},
+	}
+	if fecha != nil && fecha.After(ahora) {
+		if curso.EstadoActual() != models.EstadoCursoBorrador {
+			return nil, errors.New("solo se puede programar la publicación de un curso en borrador")
+		}
+		cambios = bson.M{"$set": bson.M{"publicacion_programada": fecha}}
+	}
+
+	return s.actualizarEstado(ctx, curso.ID, cambios)
+}
+
+// DespublicarCurso vuelve un curso publicado a borrador, o cancela la publicación programada de un
+// borrador. Solo el instructor del curso o un administrador puede hacerlo.
+func (s *CursoService) DespublicarCurso(ctx context.Context, id, email, password string) (*models.Curso, error) {
+	curso, err := s.obtenerCursoEditable(ctx, id, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	estado := curso.EstadoActual()
+	if estado != models.EstadoCursoPublicado && !(estado == models.EstadoCursoBorrador && curso.PublicacionProgramada != nil) {
+		return nil, errors.New("el curso no está publicado")
+	}
+
+	return s.actualizarEstado(ctx, curso.ID, bson.M{
+		"$set":   bson.M{"estado": models.EstadoCursoBorrador},
+		"$unset": bson.M{"publicacion_programada": ""},
+	})
+}
+
+// ArchivarCurso archiva un curso en borrador o publicado. Un curso archivado deja de aparecer en el
+// catálogo y no admite inscripciones, pero sus inscritos conservan el acceso. Solo el instructor del
+// curso o un administrador puede hacerlo.
+func (s *CursoService) ArchivarCurso(ctx context.Context, id, email, password string) (*models.Curso, error) {
+	curso, err := s.obtenerCursoEditable(ctx, id, email, password)
+	if err != nil {
+		return nil, err
+	}
+	if curso.EstadoActual() == models.EstadoCursoArchivado {
+		return nil, errors.New("el curso ya está archivado")
+	}
+
+	return s.actualizarEstado(ctx, curso.ID, bson.M{
+		"$set":   bson.M{"estado": models.EstadoCursoArchivado},
+		"$unset": bson.M{"publicacion_programada": ""},
+	})
+}
+
+// PublicarProgramados publica los borradores cuya publicación programada ya llegó y devuelve cuántos
+// publicó. Si un curso ya no tiene contenido para publicarse se cancela su publicación programada.
+func (s *CursoService) PublicarProgramados(ctx context.Context, ahora time.Time) (int, error) {
+	cursor, err := s.CursoCollection.Find(ctx, bson.M{
+		"estado":                 models.EstadoCursoBorrador,
+		"publicacion_programada": bson.M{"$lte": ahora},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var cursos []models.Curso
+	if err := cursor.All(ctx, &cursos); err != nil {
+		return 0, err
+	}
+
+	publicados := 0
+	for i := range cursos {
+		curso := &cursos[i]
+		// El filtro incluye la fecha para no pisar un cambio hecho mientras tanto por el instructor
+		filtro := bson.M{"_id": curso.ID, "estado": models.EstadoCursoBorrador, "publicacion_programada": curso.PublicacionProgramada}
+
+		if err := s.validarContenidoPublicable(ctx, curso); err != nil {
+			log.Printf("Se canceló la publicación programada del curso %s: %v", curso.ID.Hex(), err)
+			if _, err := s.CursoCollection.UpdateOne(ctx, filtro, bson.M{"$unset": bson.M{"publicacion_programada": ""}}); err != nil {
+				return publicados, err
+			}
+			continue
+		}
+
+		result, err := s.CursoCollection.UpdateOne(ctx, filtro, bson.M{
+			"$set":   bson.M{"estado": models.EstadoCursoPublicado, "publicado_en": ahora},
+			"$unset": bson.M{"publicacion_programada": ""},
+		})
+		if err != nil {
+			return publicados, err
+		}
+		publicados += int(result.ModifiedCount)
+	}
+
+	return publicados, nil
+}
+
+// obtenerCursoEditable verifica las credenciales y obtiene un curso del que el usuario es instructor,
+// o cualquier curso si es administrador.
+func (s *CursoService) obtenerCursoEditable(ctx context.Context, id, email, password string) (*models.Curso, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, objectID, email, password, errorInstructorEstadoCurso); err != nil {
+		return nil, err
+	}
+
+	var curso models.Curso
+	if err := s.CursoCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+	return &curso, nil
+}
+
+// validarContenidoPublicable verifica que el curso tenga al menos una unidad con una clase.
+func (s *CursoService) validarContenidoPublicable(ctx context.Context, curso *models.Curso) error {
+	cantidad, err := s.UnidadCollection.CountDocuments(ctx, bson.M{
+		"_id":      bson.M{"$in": curso.Unidades},
+		"clases.0": bson.M{"$exists": true},
+	})
+	if err != nil {
+		return err
+	}
+	if cantidad == 0 {
+		return errors.New("el curso debe tener al menos una unidad con una clase para publicarse")
+	}
+	return nil
+}
+
+func (s *CursoService) actualizarEstado(ctx context.Context, id primitive.ObjectID, cambios bson.M) (*models.Curso, error) {
+	var curso models.Curso
+	err := s.CursoCollection.FindOneAndUpdate(ctx, bson.M{"_id": id}, cambios,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&curso)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+	return &curso, nil
+}
+
+// ProgramadorPublicaciones publica en segundo plano los cursos con publicación programada,
+// revisándolos cada cierto intervalo.
+type ProgramadorPublicaciones struct {
+	cursos    *CursoService
+	intervalo time.Duration
+
+	ctx      context.Context
+	cancelar context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewProgramadorPublicaciones crea un programador que revisa las publicaciones pendientes con el
+// intervalo indicado.
+func NewProgramadorPublicaciones(cursos *CursoService, intervalo time.Duration) *ProgramadorPublicaciones {
+	ctx, cancelar := context.WithCancel(context.Background())
+	return &ProgramadorPublicaciones{
+		cursos:    cursos,
+		intervalo: intervalo,
+		ctx:       ctx,
+		cancelar:  cancelar,
+	}
+}
+
+// Iniciar comienza a revisar las publicaciones pendientes, la primera vez de inmediato.
+func (p *ProgramadorPublicaciones) Iniciar() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.intervalo)
+		defer ticker.Stop()
+		for {
+			p.publicarPendientes()
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Detener deja de revisar las publicaciones pendientes y espera a que termine la revisión en curso.
+func (p *ProgramadorPublicaciones) Detener() {
+	p.cancelar()
+	p.wg.Wait()
+}
+
+func (p *ProgramadorPublicaciones) publicarPendientes() {
+	publicados, err := p.cursos.PublicarProgramados(p.ctx, time.Now())
+	if err != nil && p.ctx.Err() == nil {
+		log.Printf("Error al publicar los cursos programados: %v", err)
+	}
+	if publicados > 0 {
+		log.Printf("Se publicaron %d cursos programados", publicados)
+	}
+}
@@ -2,9 +2,9 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"go-API/models"
+	"math"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -35,36 +35,10 @@ func (s *PuntuacionService) CrearPuntuacionParaCurso(email, password, cursoID st
 	}
 
 	// Verificar si el usuario está inscrito en el curso
-	key := "usuario:" + email + ":" + password
-	val, err := s.RedisClient.Get(context.TODO(), key).Result()
-	if err == redis.Nil {
-		return errors.New("usuario no encontrado")
-	} else if err != nil {
+	if _, err := obtenerUsuarioInscrito(context.TODO(), s.RedisClient, email, password, cursoID); err != nil {
 		return err
 	}
 
-	var usuario models.Usuario
-	if err := json.Unmarshal([]byte(val), &usuario); err != nil {
-		return err
-	}
-
-	cursoObjectID, err := primitive.ObjectIDFromHex(cursoID)
-	if err != nil {
-		return errors.New("ID de curso inválido")
-	}
-
-	inscrito := false
-	for _, inscritoID := range usuario.Inscritos {
-		if inscritoID == cursoObjectID {
-			inscrito = true
-			break
-		}
-	}
-
-	if !inscrito {
-		return errors.New("el usuario no está inscrito en este curso")
-	}
-
 	// Verificar si el usuario ya ha puntuado el curso
 	session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(context.TODO())
@@ -123,15 +97,109 @@ func (s *PuntuacionService) CrearPuntuacionParaCurso(email, password, cursoID st
 	return s.ActualizarValoracionCurso(cursoID)
 }
 
-// ActualizarValoracionCurso actualiza la valoración promedio de un curso en MongoDB y Neo4j.
+// ActualizarValoracionCurso actualiza la valoración promedio y la valoración ponderada de un curso en
+// MongoDB. La valoración ponderada de los demás cursos también depende del promedio global de las
+// puntuaciones, pero se corrige en ReconciliarValoraciones para no escribir todos los cursos en cada
+// puntuación; la variación del promedio global por una puntuación es mínima.
 func (s *PuntuacionService) ActualizarValoracionCurso(cursoID string) error {
+	estadisticas, err := s.estadisticasPuntuacion(cursoID)
+	if err != nil {
+		return err
+	}
+
+	if estadisticas.Total == 0 {
+		return errors.New("error al calcular el promedio")
+	}
+
+	// Actualizar la valoración del curso en MongoDB
+	objectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return errors.New("ID de curso inválido")
+	}
+
+	_, err = s.CursoCollection.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{
+			"valoracion":           float32(estadisticas.Promedio),
+			"valoracion_ponderada": float32(estadisticas.ValoracionPonderada),
+		}},
+	)
+	return err
+}
+
+// puntuacionesCurso es el promedio y la cantidad de puntuaciones de un curso.
+type puntuacionesCurso struct {
+	promedio float64
+	total    int
+}
+
+// puntuacionesPorCurso obtiene de Neo4j las puntuaciones de cada curso, por ID, y el promedio global
+// de todas las puntuaciones.
+func (s *PuntuacionService) puntuacionesPorCurso(ctx context.Context) (map[string]puntuacionesCurso, float64, error) {
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	var promedioGlobal float64
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+            OPTIONAL MATCH (:Usuario)-[g:PUNTUO]->(:Curso)
+            WITH AVG(g.valor) AS promedioGlobal
+            MATCH (c:Curso)
+            OPTIONAL MATCH (c)<-[r:PUNTUO]-()
+            RETURN c.id AS id, AVG(r.valor) AS promedio, COUNT(r) AS total, promedioGlobal
+        `
+		res, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		puntuaciones := map[string]puntuacionesCurso{}
+		for res.Next(ctx) {
+			record := res.Record()
+			id, _ := record.Values[0].(string)
+			promedio, _ := record.Values[1].(float64)
+			total, _ := record.Values[2].(int64)
+			promedioGlobal, _ = record.Values[3].(float64)
+			puntuaciones[id] = puntuacionesCurso{promedio: promedio, total: int(total)}
+		}
+		return puntuaciones, res.Err()
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.(map[string]puntuacionesCurso), promedioGlobal, nil
+}
+
+// ObtenerEstadisticasPuntuacion obtiene la distribución de las puntuaciones de un curso:
+// histograma, cantidad, mediana, desviación estándar y promedio bayesiano. El usuario debe poder ver
+// el curso.
+func (s *PuntuacionService) ObtenerEstadisticasPuntuacion(cursoID, email, password string) (*models.EstadisticasPuntuacion, error) {
+	if err := verificarCursoVisible(context.TODO(), s.RedisClient, s.CursoCollection, cursoID, email, password); err != nil {
+		return nil, err
+	}
+	return s.estadisticasPuntuacion(cursoID)
+}
+
+// estadisticasPuntuacion calcula las estadísticas de puntuación de un curso sin verificar su visibilidad.
+func (s *PuntuacionService) estadisticasPuntuacion(cursoID string) (*models.EstadisticasPuntuacion, error) {
 	session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(context.TODO())
 
 	result, err := session.ExecuteRead(context.TODO(), func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		// El promedio global de todas las puntuaciones se usa como valor previo del promedio bayesiano
 		query := `
-            MATCH (c:Curso {id: $cursoID})<-[r:PUNTUO]-()
-            RETURN AVG(r.valor) AS promedio
+            OPTIONAL MATCH (:Usuario)-[g:PUNTUO]->(:Curso)
+            WITH AVG(g.valor) AS promedioGlobal
+            OPTIONAL MATCH (c:Curso {id: $cursoID})<-[r:PUNTUO]-()
+            WITH promedioGlobal,
+                 COLLECT(r.valor) AS valores,
+                 COUNT(r) AS total,
+                 AVG(r.valor) AS promedio,
+                 percentileCont(r.valor, 0.5) AS mediana,
+                 stDevP(r.valor) AS desviacion
+            RETURN total, promedio, mediana, desviacion, promedioGlobal,
+                   [estrellas IN range(0, 5) | size([v IN valores WHERE toInteger(round(v)) = estrellas])] AS histograma
         `
 		params := map[string]interface{}{
 			"cursoID": cursoID,
@@ -140,42 +208,130 @@ func (s *PuntuacionService) ActualizarValoracionCurso(cursoID string) error {
 		if err != nil {
 			return nil, err
 		}
-		if res.Next(context.TODO()) {
-			promedio, _ := res.Record().Get("promedio")
-			return promedio, nil
+		if !res.Next(context.TODO()) {
+			return nil, errors.New("no se encontraron puntuaciones")
 		}
-		return nil, errors.New("no se encontraron puntuaciones")
+		record := res.Record()
+
+		estadisticas := &models.EstadisticasPuntuacion{
+			CursoID:    cursoID,
+			Histograma: make([]int, 6),
+		}
+		if total, ok := record.Values[0].(int64); ok {
+			estadisticas.Total = int(total)
+		}
+		estadisticas.Promedio, _ = record.Values[1].(float64)
+		estadisticas.Mediana, _ = record.Values[2].(float64)
+		estadisticas.DesviacionEstandar, _ = record.Values[3].(float64)
+		promedioGlobal, _ := record.Values[4].(float64)
+		if histograma, ok := record.Values[5].([]interface{}); ok {
+			for i, cantidad := range histograma {
+				if i < len(estadisticas.Histograma) {
+					estadisticas.Histograma[i] = int(cantidad.(int64))
+				}
+			}
+		}
+		estadisticas.ValoracionPonderada = valoracionBayesiana(estadisticas.Promedio, estadisticas.Total, promedioGlobal)
+
+		return estadisticas, res.Err()
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	promedio, ok := result.(float64)
-	if !ok {
-		return errors.New("error al calcular el promedio")
+	return result.(*models.EstadisticasPuntuacion), nil
+}
+
+// pesoValoracionBayesiana es la cantidad de puntuaciones "virtuales" con el promedio global que
+// se suman a cada curso, de modo que un curso con pocas puntuaciones no supere a uno bien evaluado.
+const pesoValoracionBayesiana = 5
+
+// valoracionBayesiana calcula el promedio ponderado (bayesiano) de un curso.
+func valoracionBayesiana(promedio float64, total int, promedioGlobal float64) float64 {
+	if total == 0 {
+		return 0
 	}
+	return (pesoValoracionBayesiana*promedioGlobal + float64(total)*promedio) / float64(pesoValoracionBayesiana+total)
+}
 
-	// Actualizar la valoración del curso en MongoDB
-	objectID, err := primitive.ObjectIDFromHex(cursoID)
+// ReconciliarValoraciones recalcula desde Neo4j la valoración y la valoración ponderada de todos
+// los cursos con puntuaciones. Devuelve los cursos cuya valoración almacenada en MongoDB no coincidía con el
+// promedio de sus puntuaciones; cada corrección queda registrada en la auditoría.
+func (s *PuntuacionService) ReconciliarValoraciones(email, password string) ([]models.DiferenciaValoracion, error) {
+	if _, err := obtenerAdmin(context.TODO(), s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	puntuaciones, promedioGlobal, err := s.puntuacionesPorCurso(context.TODO())
 	if err != nil {
-		return errors.New("ID de curso inválido")
+		return nil, err
 	}
 
-	_, err = s.CursoCollection.UpdateOne(
-		context.TODO(),
-		bson.M{"_id": objectID},
-		bson.M{"$set": bson.M{"valoracion": promedio}},
-	)
+	cursor, err := s.CursoCollection.Find(context.TODO(), bson.M{})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var cursos []models.Curso
+	if err = cursor.All(context.TODO(), &cursos); err != nil {
+		return nil, err
 	}
 
-	return nil
+	auditoria := s.CursoCollection.Database().Collection("auditoria_valoraciones")
+	diferencias := []models.DiferenciaValoracion{}
+	for _, curso := range cursos {
+		// Los cursos sin puntuaciones conservan su valoración, que pudo fijar un administrador
+		p, ok := puntuaciones[curso.ID.Hex()]
+		if !ok || p.total == 0 {
+			continue
+		}
+		valoracion := float32(p.promedio)
+		ponderada := float32(valoracionBayesiana(p.promedio, p.total, promedioGlobal))
+
+		if math.Abs(float64(curso.Valoracion-valoracion)) > toleranciaValoracion {
+			diferencias = append(diferencias, models.DiferenciaValoracion{
+				CursoID:              curso.ID.Hex(),
+				Nombre:               curso.Nombre,
+				ValoracionAlmacenada: curso.Valoracion,
+				ValoracionCalculada:  valoracion,
+				Puntuaciones:         p.total,
+			})
+			if err := registrarAuditoriaValoracion(auditoria, models.AuditoriaValoracion{
+				CursoID:            curso.ID,
+				Admin:              email,
+				ValoracionAnterior: curso.Valoracion,
+				ValoracionNueva:    valoracion,
+				Motivo:             "reconciliación con las puntuaciones de Neo4j",
+			}); err != nil {
+				return nil, err
+			}
+		} else if math.Abs(float64(curso.ValoracionPonderada-ponderada)) <= toleranciaValoracion {
+			continue
+		}
+
+		_, err := s.CursoCollection.UpdateOne(
+			context.TODO(),
+			bson.M{"_id": curso.ID},
+			bson.M{"$set": bson.M{"valoracion": valoracion, "valoracion_ponderada": ponderada}},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diferencias, nil
 }
 
-// ObtenerPromedioPuntuacion obtiene el promedio de puntuaciones de un curso.
-func (s *PuntuacionService) ObtenerPromedioPuntuacion(cursoID string) (float64, error) {
+// toleranciaValoracion es la diferencia máxima entre dos valoraciones para considerarlas iguales.
+const toleranciaValoracion = 0.001
+
+// ObtenerPromedioPuntuacion obtiene el promedio de puntuaciones de un curso, si el usuario puede
+// verlo.
+func (s *PuntuacionService) ObtenerPromedioPuntuacion(cursoID, email, password string) (float64, error) {
+	if err := verificarCursoVisible(context.TODO(), s.RedisClient, s.CursoCollection, cursoID, email, password); err != nil {
+		return 0, err
+	}
+
 	session := s.Driver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(context.TODO())
 
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-API/models"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ReaccionMeGusta   = "me_gusta"
+	ReaccionNoMeGusta = "no_me_gusta"
+)
+
+// ReaccionService gestiona las reacciones ("me gusta" / "no me gusta") de los usuarios a clases y
+// comentarios de clase. Cada usuario tiene como máximo una reacción por elemento, guardada como
+// relación REACCIONO en Neo4j; los contadores se mantienen desnormalizados en el elemento.
+type ReaccionService struct {
+	Driver          neo4j.DriverWithContext
+	RedisClient     *redis.Client
+	ClaseCollection *mongo.Collection
+}
+
+func NewReaccionService(driver neo4j.DriverWithContext, redisClient *redis.Client, claseCollection *mongo.Collection) *ReaccionService {
+	return &ReaccionService{
+		Driver:          driver,
+		RedisClient:     redisClient,
+		ClaseCollection: claseCollection,
+	}
+}
+
+// ReaccionarComentario registra la reacción de un usuario a un comentario de clase. Repetir la misma
+// reacción la quita y elegir la opuesta la reemplaza. Devuelve la reacción vigente y los contadores.
+func (s *ReaccionService) ReaccionarComentario(ctx context.Context, email, password, comentarioID, tipo string) (string, int, int, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return "", 0, 0, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	type contadores struct {
+		actual             string
+		meGusta, noMeGusta int
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		anterior, actual, err := alternarReaccion(ctx, tx, "Comentario", email, comentarioID, tipo)
+		if err != nil {
+			return nil, err
+		}
+
+		// Los contadores se ajustan en la misma transacción que la relación
+		dMeGusta, dNoMeGusta := deltasReaccion(anterior, actual)
+		query := `
+            MATCH (c:Comentario {id: $id})
+            SET c.meGusta = coalesce(c.meGusta, 0) + $dMeGusta,
+                c.noMeGusta = coalesce(c.noMeGusta, 0) + $dNoMeGusta
+            RETURN c.meGusta, c.noMeGusta
+        `
+		res, err := tx.Run(ctx, query, map[string]interface{}{
+			"id":         comentarioID,
+			"dMeGusta":   dMeGusta,
+			"dNoMeGusta": dNoMeGusta,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return nil, errors.New("comentario no encontrado")
+		}
+		record := res.Record()
+		return contadores{
+			actual:    actual,
+			meGusta:   int(record.Values[0].(int64)),
+			noMeGusta: int(record.Values[1].(int64)),
+		}, res.Err()
+	})
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	c := result.(contadores)
+	return c.actual, c.meGusta, c.noMeGusta, nil
+}
+
+// ReaccionarClase registra la reacción de un usuario a una clase. Repetir la misma reacción la quita
+// y elegir la opuesta la reemplaza. Los contadores MeGusta y NoMeGusta de la clase en MongoDB se
+// recalculan desde las reacciones de Neo4j. Devuelve la reacción vigente y los contadores.
+func (s *ReaccionService) ReaccionarClase(ctx context.Context, email, password, claseID, tipo string) (string, int, int, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return "", 0, 0, err
+	}
+
+	claseObjectID, err := primitive.ObjectIDFromHex(claseID)
+	if err != nil {
+		return "", 0, 0, errors.New("ID de clase inválido")
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	type contadores struct {
+		actual             string
+		meGusta, noMeGusta int
+	}
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, actual, err := alternarReaccion(ctx, tx, "Clase", email, claseID, tipo)
+		if err != nil {
+			return nil, err
+		}
+
+		// Los contadores se cuentan desde las relaciones en lugar de incrementarse, así un fallo al
+		// guardarlos en MongoDB se corrige con la siguiente reacción a la clase
+		res, err := tx.Run(ctx, `
+            MATCH (n:Clase {id: $id})
+            OPTIONAL MATCH (:Usuario)-[r:REACCIONO]->(n)
+            RETURN COUNT(CASE WHEN r.tipo = $meGusta THEN 1 END), COUNT(CASE WHEN r.tipo = $noMeGusta THEN 1 END)
+        `, map[string]interface{}{
+			"id":        claseID,
+			"meGusta":   ReaccionMeGusta,
+			"noMeGusta": ReaccionNoMeGusta,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return nil, errors.New("clase no encontrada")
+		}
+		record := res.Record()
+		return contadores{
+			actual:    actual,
+			meGusta:   int(record.Values[0].(int64)),
+			noMeGusta: int(record.Values[1].(int64)),
+		}, res.Err()
+	})
+	if err != nil {
+		return "", 0, 0, err
+	}
+	c := result.(contadores)
+
+	var clase models.Clase
+	err = s.ClaseCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": claseObjectID},
+		bson.M{"$set": bson.M{"me_gusta": c.meGusta, "no_me_gusta": c.noMeGusta}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&clase)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", 0, 0, errors.New("clase no encontrada")
+		}
+		return "", 0, 0, err
+	}
+
+	return c.actual, clase.MeGusta, clase.NoMeGusta, nil
+}
+
+// alternarReaccion crea, reemplaza o quita la relación REACCIONO entre un usuario y un nodo de la
+// etiqueta indicada. Devuelve la reacción anterior y la vigente ("" si no hay).
+func alternarReaccion(ctx context.Context, tx neo4j.ManagedTransaction, etiqueta, email, id, tipo string) (string, string, error) {
+	// La etiqueta proviene siempre de una constante del servicio, nunca del cliente
+	query := `
+        MATCH (u:Usuario {email: $email}), (n:` + etiqueta + ` {id: $id})
+        WHERE coalesce(n.eliminado, false) = false
+        OPTIONAL MATCH (u)-[r:REACCIONO]->(n)
+        WITH u, n, r, r.tipo AS anterior
+        FOREACH (_ IN CASE WHEN r IS NOT NULL AND anterior = $tipo THEN [1] ELSE [] END | DELETE r)
+        FOREACH (_ IN CASE WHEN r IS NOT NULL AND anterior <> $tipo THEN [1] ELSE [] END |
+            SET r.tipo = $tipo, r.fecha = datetime()
+        )
+        FOREACH (_ IN CASE WHEN r IS NULL THEN [1] ELSE [] END |
+            MERGE (u)-[nueva:REACCIONO]->(n)
+            SET nueva.tipo = $tipo, nueva.fecha = datetime()
+        )
+        RETURN anterior
+    `
+	res, err := tx.Run(ctx, query, map[string]interface{}{
+		"email": email,
+		"id":    id,
+		"tipo":  tipo,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if !res.Next(ctx) {
+		if etiqueta == "Clase" {
+			return "", "", errors.New("clase no encontrada")
+		}
+		return "", "", errors.New("comentario no encontrado")
+	}
+
+	anterior, _ := res.Record().Values[0].(string)
+	actual := tipo
+	if anterior == tipo {
+		actual = ""
+	}
+	return anterior, actual, res.Err()
+}
+
+// deltasReaccion calcula cuánto cambian los contadores de "me gusta" y "no me gusta" al pasar
+// de la reacción anterior a la actual.
+func deltasReaccion(anterior, actual string) (int, int) {
+	dMeGusta, dNoMeGusta := 0, 0
+	switch anterior {
+	case ReaccionMeGusta:
+		dMeGusta--
+	case ReaccionNoMeGusta:
+		dNoMeGusta--
+	}
+	switch actual {
+	case ReaccionMeGusta:
+		dMeGusta++
+	case ReaccionNoMeGusta:
+		dNoMeGusta++
+	}
+	return dMeGusta, dNoMeGusta
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-API/models"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ResenaService gestiona las reseñas de cursos (puntuación + texto) y sus votos de utilidad.
+type ResenaService struct {
+	Driver            neo4j.DriverWithContext
+	CursoCollection   *mongo.Collection
+	RedisClient       *redis.Client
+	PuntuacionService *PuntuacionService
+}
+
+func NewResenaService(driver neo4j.DriverWithContext, cursoCollection *mongo.Collection, redisClient *redis.Client, puntuacionService *PuntuacionService) *ResenaService {
+	return &ResenaService{
+		Driver:            driver,
+		CursoCollection:   cursoCollection,
+		RedisClient:       redisClient,
+		PuntuacionService: puntuacionService,
+	}
+}
+
+// CrearResena crea una reseña para un curso. La puntuación de la reseña se guarda también como
+// relación PUNTUO, de modo que el promedio y las estadísticas del curso la incluyan.
+func (s *ResenaService) CrearResena(ctx context.Context, email, password, cursoID string, valor float32, texto string) (*models.Resena, error) {
+	if valor < 0 || valor > 5 {
+		return nil, errors.New("la puntuación debe estar entre 0 y 5")
+	}
+	if utf8.RuneCountInString(texto) < 15 {
+		return nil, errors.New("la reseña debe tener al menos 15 caracteres")
+	}
+
+	if _, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID); err != nil {
+		return nil, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		checkQuery := `
+            MATCH (u:Usuario {email: $email}), (c:Curso {id: $cursoID})
+            RETURN size([(u)-[:ESCRIBIO]->(r:Resena)-[:RESENA_DE]->(c) | r]) > 0 AS existe
+        `
+		// Sin agregaciones la consulta no devuelve filas si falta el usuario o el curso
+		checkResult, err := tx.Run(ctx, checkQuery, map[string]interface{}{
+			"email":   email,
+			"cursoID": cursoID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !checkResult.Next(ctx) {
+			return nil, errors.New("usuario o curso no encontrado")
+		}
+		if checkResult.Record().Values[0].(bool) {
+			return nil, errors.New("el usuario ya escribió una reseña para este curso")
+		}
+
+		// Si el usuario ya había puntuado el curso, la reseña reemplaza esa puntuación
+		createQuery := `
+            MATCH (u:Usuario {email: $email}), (c:Curso {id: $cursoID})
+            MERGE (u)-[p:PUNTUO]->(c)
+            SET p.valor = $valor
+            CREATE (u)-[:ESCRIBIO]->(r:Resena {
+                id: $id,
+                valor: $valor,
+                texto: $texto,
+                fecha: datetime(),
+                util: 0,
+                no_util: 0
+            })-[:RESENA_DE]->(c)
+            RETURN r.id, u.email, u.nombre, r.valor, r.texto, r.fecha, r.util, r.no_util
+        `
+		res, err := tx.Run(ctx, createQuery, map[string]interface{}{
+			"email":   email,
+			"cursoID": cursoID,
+			"id":      uuid.New().String(),
+			"valor":   valor,
+			"texto":   texto,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return nil, errors.New("no se pudo crear la reseña")
+		}
+
+		resena := resenaDesdeRecord(res.Record())
+		resena.CursoID = cursoID
+		return resena, res.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.PuntuacionService.ActualizarValoracionCurso(cursoID); err != nil {
+		return nil, err
+	}
+
+	return result.(*models.Resena), nil
+}
+
+// ObtenerResenasPorCurso obtiene una página de reseñas de un curso junto con el total de reseñas.
+// El orden puede ser "utilidad" (más votadas como útiles primero) o "recientes" (por defecto). El
+// usuario debe poder ver el curso.
+func (s *ResenaService) ObtenerResenasPorCurso(ctx context.Context, cursoID, email, password, orden string, pagina, limite int) ([]models.Resena, int, error) {
+	if err := verificarCursoVisible(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password); err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := "r.fecha DESC"
+	if orden == "utilidad" {
+		orderBy = "(r.util - r.no_util) DESC, r.util DESC, r.fecha DESC"
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	type paginaResenas struct {
+		resenas []models.Resena
+		total   int
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		countQuery := `
+            MATCH (:Usuario)-[:ESCRIBIO]->(r:Resena)-[:RESENA_DE]->(:Curso {id: $cursoID})
+            RETURN COUNT(r) AS total
+        `
+		countResult, err := tx.Run(ctx, countQuery, map[string]interface{}{"cursoID": cursoID})
+		if err != nil {
+			return nil, err
+		}
+		total := 0
+		if countResult.Next(ctx) {
+			total = int(countResult.Record().Values[0].(int64))
+		}
+
+		query := `
+            MATCH (u:Usuario)-[:ESCRIBIO]->(r:Resena)-[:RESENA_DE]->(:Curso {id: $cursoID})
+            RETURN r.id, u.email, u.nombre, r.valor, r.texto, r.fecha, r.util, r.no_util
+            ORDER BY ` + orderBy + `
+            SKIP $skip LIMIT $limite
+        `
+		res, err := tx.Run(ctx, query, map[string]interface{}{
+			"cursoID": cursoID,
+			"skip":    (pagina - 1) * limite,
+			"limite":  limite,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		resenas := []models.Resena{}
+		for res.Next(ctx) {
+			resena := resenaDesdeRecord(res.Record())
+			resena.CursoID = cursoID
+			resenas = append(resenas, *resena)
+		}
+		if err = res.Err(); err != nil {
+			return nil, err
+		}
+
+		return paginaResenas{resenas: resenas, total: total}, nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p := result.(paginaResenas)
+	return p.resenas, p.total, nil
+}
+
+// VotarResena registra si una reseña le resultó útil a un usuario. Cada usuario tiene un único
+// voto por reseña; votar de nuevo reemplaza el voto anterior.
+func (s *ResenaService) VotarResena(ctx context.Context, email, password, resenaID string, util bool) (*models.Resena, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		checkQuery := `
+            MATCH (autor:Usuario)-[:ESCRIBIO]->(r:Resena {id: $resenaID})
+            OPTIONAL MATCH (u:Usuario {email: $email})
+            RETURN u IS NOT NULL AS existeUsuario, autor.email = $email AS propia
+        `
+		// Sin agregaciones la consulta no devuelve filas si falta la reseña
+		checkResult, err := tx.Run(ctx, checkQuery, map[string]interface{}{
+			"email":    email,
+			"resenaID": resenaID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !checkResult.Next(ctx) {
+			return nil, errors.New("reseña no encontrada")
+		}
+		if !checkResult.Record().Values[0].(bool) {
+			return nil, errors.New("usuario no encontrado")
+		}
+		if checkResult.Record().Values[1].(bool) {
+			return nil, errors.New("no puede votar su propia reseña")
+		}
+
+		// Los contadores se ajustan descontando el voto anterior del usuario, si existía
+		voteQuery := `
+            MATCH (u:Usuario {email: $email}), (autor:Usuario)-[:ESCRIBIO]->(r:Resena {id: $resenaID})-[:RESENA_DE]->(c:Curso)
+            MERGE (u)-[v:VOTO_RESENA]->(r)
+            WITH autor, r, c, v, v.util AS anterior
+            SET v.util = $util,
+                v.fecha = datetime(),
+                r.util = coalesce(r.util, 0)
+                    + CASE WHEN $util THEN 1 ELSE 0 END
+                    - CASE WHEN anterior = true THEN 1 ELSE 0 END,
+                r.no_util = coalesce(r.no_util, 0)
+                    + CASE WHEN $util THEN 0 ELSE 1 END
+                    - CASE WHEN anterior = false THEN 1 ELSE 0 END
+            RETURN r.id, autor.email, autor.nombre, r.valor, r.texto, r.fecha, r.util, r.no_util, c.id
+        `
+		res, err := tx.Run(ctx, voteQuery, map[string]interface{}{
+			"email":    email,
+			"resenaID": resenaID,
+			"util":     util,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return nil, errors.New("reseña no encontrada")
+		}
+
+		resena := resenaDesdeRecord(res.Record())
+		resena.CursoID, _ = res.Record().Values[8].(string)
+		return resena, res.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*models.Resena), nil
+}
+
+// resenaDesdeRecord construye una reseña a partir de un registro con las columnas
+// id, email, nombre, valor, texto, fecha, util y no_util (en ese orden).
+func resenaDesdeRecord(record *neo4j.Record) *models.Resena {
+	resena := &models.Resena{}
+	resena.ID, _ = record.Values[0].(string)
+	resena.Autor, _ = record.Values[1].(string)
+	resena.Nombre, _ = record.Values[2].(string)
+	resena.Valor, _ = record.Values[3].(float64)
+	resena.Texto, _ = record.Values[4].(string)
+	resena.Fecha, _ = record.Values[5].(time.Time)
+	if util, ok := record.Values[6].(int64); ok {
+		resena.Util = int(util)
+	}
+	if noUtil, ok := record.Values[7].(int64); ok {
+		resena.NoUtil = int(noUtil)
+	}
+	return resena
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-API/almacenamiento"
+	"go-API/models"
+	"go-API/subtitulos"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// MaxTamanoSubtitulos es el tamaño máximo en bytes de un archivo de subtítulos.
+	MaxTamanoSubtitulos = 2 << 20
+
+	errorInstructorSubtitulos = "solo el instructor del curso o un administrador puede gestionar los subtítulos"
+)
+
+// expresionIdioma reconoce los códigos de idioma como "es", "en-US" o "pt-BR".
+var expresionIdioma = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// SubtituloService gestiona los subtítulos de las clases y la búsqueda en sus transcripciones. Cada
+// clase tiene como máximo una pista por idioma, guardada en WebVTT en el almacenamiento; sus
+// segmentos se indexan en la colección "transcripciones" con las palabras normalizadas del texto.
+type SubtituloService struct {
+	ClaseCollection         *mongo.Collection
+	UnidadCollection        *mongo.Collection
+	CursoCollection         *mongo.Collection
+	TranscripcionCollection *mongo.Collection
+	RedisClient             *redis.Client
+	Almacenamiento          almacenamiento.Almacenamiento
+}
+
+func NewSubtituloService(db *mongo.Database, redisClient *redis.Client, almacen almacenamiento.Almacenamiento) *SubtituloService {
+	return &SubtituloService{
+		ClaseCollection:         db.Collection("clases"),
+		UnidadCollection:        db.Collection("unidades"),
+		CursoCollection:         db.Collection("cursos"),
+		TranscripcionCollection: db.Collection("transcripciones"),
+		RedisClient:             redisClient,
+		Almacenamiento:          almacen,
+	}
+}
+
+// SubirSubtitulos valida un archivo WebVTT o SRT y lo guarda como la pista de subtítulos de la clase
+// en el idioma indicado, reemplazando la anterior. Solo el instructor del curso o un administrador
+// puede hacerlo.
+func (s *SubtituloService) SubirSubtitulos(ctx context.Context, claseID, email, password, idioma, nombreArchivo string, contenido io.Reader) (*models.PistaSubtitulos, error) {
+	if !expresionIdioma.MatchString(idioma) {
+		return nil, errors.New("código de idioma inválido")
+	}
+	formato := strings.TrimPrefix(strings.ToLower(filepath.Ext(nombreArchivo)), ".")
+	if formato != subtitulos.FormatoVTT && formato != subtitulos.FormatoSRT {
+		return nil, errors.New("formato de subtítulos no permitido")
+	}
+
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorSubtitulos); err != nil {
+		return nil, err
+	}
+
+	datos, err := io.ReadAll(io.LimitReader(contenido, MaxTamanoSubtitulos+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(datos) > MaxTamanoSubtitulos {
+		return nil, fmt.Errorf("el archivo supera el tamaño máximo de %d MB", MaxTamanoSubtitulos>>20)
+	}
+	segmentos, err := subtitulos.Parsear(datos, formato)
+	if err != nil {
+		return nil, fmt.Errorf("subtítulos inválidos: %v", err)
+	}
+
+	pista := &models.PistaSubtitulos{
+		Idioma:          idioma,
+		FormatoOriginal: formato,
+		Segmentos:       len(segmentos),
+		Version:         primitive.NewObjectID().Hex(),
+		SubidoPor:       email,
+		Fecha:           time.Now(),
+	}
+	pista.Clave = "subtitulos/" + clase.ID.Hex() + "/" + idioma + "-" + pista.Version
+	pista.URL = "/api/clases/" + clase.ID.Hex() + "/subtitulos/" + idioma + "?v=" + pista.Version
+
+	if _, err := s.Almacenamiento.Guardar(ctx, pista.Clave, bytes.NewReader(subtitulos.EscribirVTT(segmentos))); err != nil {
+		return nil, err
+	}
+
+	if err := s.indexarTranscripcion(ctx, clase.ID, cursoID, idioma, segmentos); err != nil {
+		eliminarArchivo(ctx, s.Almacenamiento, pista.Clave)
+		return nil, err
+	}
+
+	pistas := []models.PistaSubtitulos{}
+	var anterior *models.PistaSubtitulos
+	for i, existente := range clase.Subtitulos {
+		if existente.Idioma == idioma {
+			anterior = &clase.Subtitulos[i]
+			continue
+		}
+		pistas = append(pistas, existente)
+	}
+	pistas = append(pistas, *pista)
+
+	if _, err := s.ClaseCollection.UpdateOne(ctx, bson.M{"_id": clase.ID}, bson.M{"$set": bson.M{"subtitulos": pistas}}); err != nil {
+		eliminarArchivo(ctx, s.Almacenamiento, pista.Clave)
+		return nil, err
+	}
+	if anterior != nil {
+		eliminarArchivo(ctx, s.Almacenamiento, anterior.Clave)
+	}
+
+	return pista, nil
+}
+
+// indexarTranscripcion reemplaza los segmentos indexados de la clase en el idioma indicado.
+func (s *SubtituloService) indexarTranscripcion(ctx context.Context, claseID, cursoID primitive.ObjectID, idioma string, segmentos []subtitulos.Segmento) error {
+	if _, err := s.TranscripcionCollection.DeleteMany(ctx, bson.M{"clase_id": claseID, "idioma": idioma}); err != nil {
+		return err
+	}
+
+	documentos := []interface{}{}
+	for _, segmento := range segmentos {
+		texto := segmento.TextoPlano()
+		palabras := strings.Fields(normalizarTexto(texto))
+		if len(palabras) == 0 {
+			continue
+		}
+		documentos = append(documentos, models.SegmentoTranscripcion{
+			ClaseID:  claseID,
+			CursoID:  cursoID,
+			Idioma:   idioma,
+			Inicio:   segmento.Inicio.Seconds(),
+			Fin:      segmento.Fin.Seconds(),
+			Texto:    texto,
+			Palabras: palabras,
+		})
+	}
+	if len(documentos) == 0 {
+		return nil
+	}
+
+	_, err := s.TranscripcionCollection.InsertMany(ctx, documentos)
+	return err
+}
+
+// ObtenerSubtitulos obtiene las pistas de subtítulos de una clase. Solo pueden verlas los inscritos
+// en el curso, su instructor y los administradores; los inscritos no pueden ver las de una unidad
+// bloqueada.
+func (s *SubtituloService) ObtenerSubtitulos(ctx context.Context, claseID, email, password string) ([]models.PistaSubtitulos, error) {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verificarAccesoClase(ctx, clase, cursoID, email, password); err != nil {
+		return nil, err
+	}
+	if clase.Subtitulos == nil {
+		return []models.PistaSubtitulos{}, nil
+	}
+	return clase.Subtitulos, nil
+}
+
+// AbrirSubtitulos devuelve el archivo WebVTT de los subtítulos de una clase en un idioma, con los
+// mismos permisos que ObtenerSubtitulos.
+func (s *SubtituloService) AbrirSubtitulos(ctx context.Context, claseID, idioma, email, password string) (*models.PistaSubtitulos, io.ReadCloser, error) {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pista := buscarPista(clase, idioma)
+	if pista == nil {
+		return nil, nil, errors.New("subtítulos no encontrados")
+	}
+	if err := s.verificarAccesoClase(ctx, clase, cursoID, email, password); err != nil {
+		return nil, nil, err
+	}
+
+	archivo, err := s.Almacenamiento.Abrir(ctx, pista.Clave)
+	if err == almacenamiento.ErrNoEncontrado {
+		return nil, nil, errors.New("subtítulos no encontrados")
+	} else if err != nil {
+		return nil, nil, err
+	}
+	return pista, archivo, nil
+}
+
+// verificarAccesoClase verifica que el usuario pueda ver los subtítulos de la clase: el curso debe ser
+// visible para él y debe estar inscrito con la unidad de la clase desbloqueada, o ser el instructor
+// del curso o un administrador.
+func (s *SubtituloService) verificarAccesoClase(ctx context.Context, clase *models.Clase, cursoID primitive.ObjectID, email, password string) error {
+	if err := verificarCursoVisible(ctx, s.RedisClient, s.CursoCollection, cursoID.Hex(), email, password); err != nil {
+		return err
+	}
+	usuario, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID.Hex())
+	if err != nil {
+		if err.Error() != "el usuario no está inscrito en este curso" {
+			return err
+		}
+		if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorSubtitulos); err != nil {
+			return errors.New("el usuario no está inscrito en este curso")
+		}
+		return nil
+	}
+
+	estado, err := bloqueoUnidad(ctx, s.CursoCollection, s.UnidadCollection, cursoID, clase.UnidadID, usuario)
+	if err != nil {
+		return err
+	}
+	if estado.bloqueada {
+		return errorClaseBloqueada(estado)
+	}
+	return nil
+}
+
+// EliminarSubtitulos elimina la pista de subtítulos de una clase en un idioma y su transcripción.
+// Solo el instructor del curso o un administrador puede hacerlo.
+func (s *SubtituloService) EliminarSubtitulos(ctx context.Context, claseID, idioma, email, password string) error {
+	clase, cursoID, err := obtenerClaseYCurso(ctx, s.ClaseCollection, s.UnidadCollection, claseID)
+	if err != nil {
+		return err
+	}
+	pista := buscarPista(clase, idioma)
+	if pista == nil {
+		return errors.New("subtítulos no encontrados")
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, cursoID, email, password, errorInstructorSubtitulos); err != nil {
+		return err
+	}
+
+	_, err = s.ClaseCollection.UpdateOne(ctx, bson.M{"_id": clase.ID}, bson.M{"$pull": bson.M{"subtitulos": bson.M{"idioma": idioma}}})
+	if err != nil {
+		return err
+	}
+	if _, err := s.TranscripcionCollection.DeleteMany(ctx, bson.M{"clase_id": clase.ID, "idioma": idioma}); err != nil {
+		return err
+	}
+
+	eliminarArchivo(ctx, s.Almacenamiento, pista.Clave)
+	return nil
+}
+
+// BuscarEnTranscripciones busca una frase en los subtítulos de las clases de un curso y devuelve los
+// segmentos en los que aparece, por clase y en orden de tiempo. La comparación ignora mayúsculas,
+// tildes y puntuación; la frase debe aparecer completa dentro de un mismo segmento. Si se indica un
+// idioma solo se busca en los subtítulos de ese idioma. Solo pueden buscar los inscritos en el curso,
+// su instructor y los administradores; a los inscritos no se les devuelven los segmentos de las clases
+// de unidades bloqueadas.
+func (s *SubtituloService) BuscarEnTranscripciones(ctx context.Context, cursoID, frase, idioma, email, password string, limite int) ([]models.ResultadoTranscripcion, error) {
+	objectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return nil, errors.New("ID de curso inválido")
+	}
+	usuario, err := usuarioOpcional(ctx, s.RedisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+	curso, err := obtenerCursoVisible(ctx, s.CursoCollection, objectID, usuario)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, cursoID); err != nil {
+		if err.Error() != "el usuario no está inscrito en este curso" {
+			return nil, err
+		}
+		if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, objectID, email, password, errorInstructorSubtitulos); err != nil {
+			return nil, errors.New("el usuario no está inscrito en este curso")
+		}
+	}
+	buscables, err := s.clasesBuscables(ctx, *curso, usuario)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizada := normalizarTexto(frase)
+	if normalizada == "" {
+		return nil, errors.New("la búsqueda no puede estar vacía")
+	}
+
+	// Las palabras filtran los candidatos en la base; el orden de la frase se verifica después
+	filtro := bson.M{
+		"curso_id": objectID,
+		"clase_id": bson.M{"$in": buscables},
+		"palabras": bson.M{"$all": strings.Fields(normalizada)},
+	}
+	if idioma != "" {
+		filtro["idioma"] = idioma
+	}
+	cursor, err := s.TranscripcionCollection.Find(ctx, filtro, options.Find().SetSort(bson.D{{Key: "clase_id", Value: 1}, {Key: "inicio", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	segmentos := []models.SegmentoTranscripcion{}
+	for len(segmentos) < limite && cursor.Next(ctx) {
+		var segmento models.SegmentoTranscripcion
+		if err := cursor.Decode(&segmento); err != nil {
+			return nil, err
+		}
+		if strings.Contains(" "+strings.Join(segmento.Palabras, " ")+" ", " "+normalizada+" ") {
+			segmentos = append(segmentos, segmento)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	resultados := []models.ResultadoTranscripcion{}
+	if len(segmentos) == 0 {
+		return resultados, nil
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, segmento := range segmentos {
+		ids = append(ids, segmento.ClaseID)
+	}
+	cursorClases, err := s.ClaseCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find().SetProjection(bson.M{"nombre": 1, "unidad_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var clases []models.Clase
+	if err := cursorClases.All(ctx, &clases); err != nil {
+		return nil, err
+	}
+	clasesPorID := map[primitive.ObjectID]models.Clase{}
+	for _, clase := range clases {
+		clasesPorID[clase.ID] = clase
+	}
+
+	for _, segmento := range segmentos {
+		clase := clasesPorID[segmento.ClaseID]
+		resultados = append(resultados, models.ResultadoTranscripcion{
+			ClaseID:  segmento.ClaseID.Hex(),
+			Clase:    clase.Nombre,
+			UnidadID: clase.UnidadID.Hex(),
+			Idioma:   segmento.Idioma,
+			Inicio:   segmento.Inicio,
+			Fin:      segmento.Fin,
+			Texto:    segmento.Texto,
+		})
+	}
+	return resultados, nil
+}
+
+func buscarPista(clase *models.Clase, idioma string) *models.PistaSubtitulos {
+	for i := range clase.Subtitulos {
+		if clase.Subtitulos[i].Idioma == idioma {
+			return &clase.Subtitulos[i]
+		}
+	}
+	return nil
+}
+
+// CrearIndices crea el índice de los segmentos de transcripción por curso y palabra que usan las
+// búsquedas.
+func (s *SubtituloService) CrearIndices(ctx context.Context) error {
+	_, err := s.TranscripcionCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "curso_id", Value: 1}, {Key: "palabras", Value: 1}},
+	})
+	return err
+}
+
+// clasesBuscables devuelve los IDs de las clases que forman parte del curso y no están en unidades
+// bloqueadas para el usuario. Las clases que una restauración de versión dejó fuera del curso
+// conservan sus segmentos, pero no aparecen en las búsquedas.
+func (s *SubtituloService) clasesBuscables(ctx context.Context, curso models.Curso, usuario *models.Usuario) ([]primitive.ObjectID, error) {
+	cursor, err := s.UnidadCollection.Find(ctx, bson.M{"_id": bson.M{"$in": curso.Unidades}})
+	if err != nil {
+		return nil, err
+	}
+	var unidades []models.Unidad
+	if err := cursor.All(ctx, &unidades); err != nil {
+		return nil, err
+	}
+
+	bloqueos := calcularBloqueos(curso, unidades, usuario, time.Now())
+	buscables := []primitive.ObjectID{}
+	for _, unidad := range unidades {
+		if !bloqueos[unidad.ID].bloqueada {
+			buscables = append(buscables, unidad.Clases...)
+		}
+	}
+	return buscables, nil
+}
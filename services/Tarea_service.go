@@ -0,0 +1,386 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-API/almacenamiento"
+	"go-API/models"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// MaxTamanoEntrega es el tamaño máximo en bytes del archivo de una entrega.
+	MaxTamanoEntrega = 25 << 20
+
+	errorInstructorTareas = "solo el instructor del curso o un administrador puede gestionar las tareas"
+)
+
+// TareaService gestiona las tareas de las unidades y las entregas de los usuarios. Los archivos de
+// las entregas se guardan en el almacenamiento bajo la clave entregas/<tarea>/<ID único del archivo>.
+type TareaService struct {
+	TareaCollection   *mongo.Collection
+	EntregaCollection *mongo.Collection
+	CursoCollection   *mongo.Collection
+	UnidadCollection  *mongo.Collection
+	RedisClient       *redis.Client
+	Almacenamiento    almacenamiento.Almacenamiento
+}
+
+func NewTareaService(db *mongo.Database, redisClient *redis.Client, almacen almacenamiento.Almacenamiento) *TareaService {
+	return &TareaService{
+		TareaCollection:   db.Collection("tareas"),
+		EntregaCollection: db.Collection("entregas"),
+		CursoCollection:   db.Collection("cursos"),
+		UnidadCollection:  db.Collection("unidades"),
+		RedisClient:       redisClient,
+		Almacenamiento:    almacen,
+	}
+}
+
+// CrearTarea crea una tarea en una unidad. Solo el instructor del curso o un administrador puede hacerlo.
+func (s *TareaService) CrearTarea(ctx context.Context, unidadID, email, password string, tarea *models.Tarea) (*models.Tarea, error) {
+	unidadObjectID, err := primitive.ObjectIDFromHex(unidadID)
+	if err != nil {
+		return nil, errors.New("ID de unidad inválido")
+	}
+
+	var unidad models.Unidad
+	if err := s.UnidadCollection.FindOne(ctx, bson.M{"_id": unidadObjectID}).Decode(&unidad); err == mongo.ErrNoDocuments {
+		return nil, errors.New("unidad no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, unidad.IDcurso, email, password, errorInstructorTareas); err != nil {
+		return nil, err
+	}
+	if tarea.PuntajeMaximo <= 0 {
+		return nil, errors.New("el puntaje máximo debe ser mayor que 0")
+	}
+
+	tarea.ID = primitive.NewObjectID()
+	tarea.UnidadID = unidad.ID
+	tarea.CursoID = unidad.IDcurso
+	tarea.Fecha = time.Now()
+	if _, err := s.TareaCollection.InsertOne(ctx, tarea); err != nil {
+		return nil, err
+	}
+
+	return tarea, nil
+}
+
+// ObtenerTareasPorUnidad obtiene las tareas de una unidad ordenadas por fecha de entrega, si el
+// usuario puede ver el curso de la unidad y la unidad no está bloqueada para él.
+func (s *TareaService) ObtenerTareasPorUnidad(ctx context.Context, unidadID, email, password string) ([]models.Tarea, error) {
+	objectID, err := primitive.ObjectIDFromHex(unidadID)
+	if err != nil {
+		return nil, errors.New("ID de unidad inválido")
+	}
+
+	var unidad models.Unidad
+	if err := s.UnidadCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&unidad); err == mongo.ErrNoDocuments {
+		return nil, errors.New("unidad no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+	if err := verificarUnidadDisponible(ctx, s.RedisClient, s.CursoCollection, s.UnidadCollection, unidad.IDcurso, unidad.ID, email, password); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.TareaCollection.Find(ctx, bson.M{"unidad_id": objectID}, options.Find().SetSort(bson.D{{Key: "fecha_entrega", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	tareas := []models.Tarea{}
+	if err := cursor.All(ctx, &tareas); err != nil {
+		return nil, err
+	}
+	return tareas, nil
+}
+
+// EntregarTarea guarda el archivo entregado por un usuario inscrito, si la unidad de la tarea no está
+// bloqueada para él. Si ya había entregado la tarea y todavía no fue calificada, la nueva entrega
+// reemplaza a la anterior. Las entregas posteriores a la fecha límite se marcan como atrasadas. Se
+// aceptan las mismas extensiones que en los adjuntos y el tipo de contenido se deduce de la extensión.
+func (s *TareaService) EntregarTarea(ctx context.Context, tareaID, email, password, nombreArchivo string, tamano int64, contenido io.Reader) (*models.Entrega, error) {
+	if tamano > MaxTamanoEntrega {
+		return nil, fmt.Errorf("el archivo supera el tamaño máximo de %d MB", MaxTamanoEntrega>>20)
+	}
+	tipoContenido, ok := tiposAdjunto[strings.ToLower(filepath.Ext(nombreArchivo))]
+	if !ok {
+		return nil, errors.New("tipo de archivo no permitido")
+	}
+
+	tarea, err := s.obtenerTarea(ctx, tareaID)
+	if err != nil {
+		return nil, err
+	}
+	usuario, err := obtenerUsuarioInscrito(ctx, s.RedisClient, email, password, tarea.CursoID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarUnidadDesbloqueada(ctx, s.CursoCollection, s.UnidadCollection, tarea.CursoID, tarea.UnidadID, usuario); err != nil {
+		return nil, err
+	}
+
+	var anterior models.Entrega
+	err = s.EntregaCollection.FindOne(ctx, bson.M{"tarea_id": tarea.ID, "email": email}).Decode(&anterior)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	existe := err == nil
+	if existe && anterior.Calificacion != nil {
+		return nil, errors.New("la entrega ya fue calificada")
+	}
+
+	entrega := &models.Entrega{
+		TareaID:       tarea.ID,
+		Email:         email,
+		NombreArchivo: filepath.Base(nombreArchivo),
+		TipoContenido: tipoContenido,
+		Fecha:         time.Now(),
+	}
+	entrega.Atrasada = entrega.Fecha.After(tarea.FechaEntrega)
+	entrega.Clave = "entregas/" + tarea.ID.Hex() + "/" + primitive.NewObjectID().Hex()
+
+	// Se limita la lectura por si el tamaño declarado no coincide con el contenido
+	escritos, err := s.Almacenamiento.Guardar(ctx, entrega.Clave, io.LimitReader(contenido, MaxTamanoEntrega+1))
+	if err != nil {
+		return nil, err
+	}
+	if escritos > MaxTamanoEntrega {
+		eliminarArchivo(ctx, s.Almacenamiento, entrega.Clave)
+		return nil, fmt.Errorf("el archivo supera el tamaño máximo de %d MB", MaxTamanoEntrega>>20)
+	}
+	entrega.Tamano = escritos
+
+	guardada, err := s.guardarEntrega(ctx, entrega)
+	if err != nil {
+		eliminarArchivo(ctx, s.Almacenamiento, entrega.Clave)
+		return nil, err
+	}
+	entrega.ID = guardada.ID
+	if existe && anterior.ID == guardada.ID {
+		eliminarArchivo(ctx, s.Almacenamiento, anterior.Clave)
+	}
+
+	entrega.TituloTarea = tarea.Titulo
+	return entrega, nil
+}
+
+// guardarEntrega reemplaza la entrega sin calificar del usuario o la crea si no tiene ninguna. El
+// filtro excluye las entregas calificadas, de modo que una calificación guardada después de la
+// verificación no se pierda: en ese caso la inserción choca con el índice único de la tarea y el
+// usuario. Si el choque se debe a otra entrega simultánea del usuario se vuelve a intentar una vez.
+func (s *TareaService) guardarEntrega(ctx context.Context, entrega *models.Entrega) (*models.Entrega, error) {
+	filtro := bson.M{"tarea_id": entrega.TareaID, "email": entrega.Email, "calificacion": bson.M{"$exists": false}}
+	opciones := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+
+	var guardada models.Entrega
+	err := s.EntregaCollection.FindOneAndReplace(ctx, filtro, entrega, opciones).Decode(&guardada)
+	if mongo.IsDuplicateKeyError(err) {
+		err = s.EntregaCollection.FindOneAndReplace(ctx, filtro, entrega, opciones).Decode(&guardada)
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, errors.New("la entrega ya fue calificada")
+	} else if err != nil {
+		return nil, err
+	}
+	return &guardada, nil
+}
+
+// CrearIndices crea el índice único que limita las entregas a una por usuario y tarea.
+func (s *TareaService) CrearIndices(ctx context.Context) error {
+	_, err := s.EntregaCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "tarea_id", Value: 1}, {Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// ObtenerEntregas obtiene las entregas de una tarea. Solo el instructor del curso o un administrador
+// puede verlas.
+func (s *TareaService) ObtenerEntregas(ctx context.Context, tareaID, email, password string) ([]models.Entrega, error) {
+	tarea, err := s.obtenerTarea(ctx, tareaID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, tarea.CursoID, email, password, errorInstructorTareas); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.EntregaCollection.Find(ctx, bson.M{"tarea_id": tarea.ID}, options.Find().SetSort(bson.D{{Key: "fecha", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	entregas := []models.Entrega{}
+	if err := cursor.All(ctx, &entregas); err != nil {
+		return nil, err
+	}
+	for i := range entregas {
+		entregas[i].TituloTarea = tarea.Titulo
+	}
+	return entregas, nil
+}
+
+// ObtenerEntregasUsuario obtiene las entregas de un usuario con su calificación.
+func (s *TareaService) ObtenerEntregasUsuario(ctx context.Context, email, password string) ([]models.Entrega, error) {
+	if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.EntregaCollection.Find(ctx, bson.M{"email": email}, options.Find().SetSort(bson.D{{Key: "fecha", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	entregas := []models.Entrega{}
+	if err := cursor.All(ctx, &entregas); err != nil {
+		return nil, err
+	}
+	if len(entregas) == 0 {
+		return entregas, nil
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, entrega := range entregas {
+		ids = append(ids, entrega.TareaID)
+	}
+	cursorTareas, err := s.TareaCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find().SetProjection(bson.M{"titulo": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var tareas []models.Tarea
+	if err := cursorTareas.All(ctx, &tareas); err != nil {
+		return nil, err
+	}
+	titulos := map[primitive.ObjectID]string{}
+	for _, tarea := range tareas {
+		titulos[tarea.ID] = tarea.Titulo
+	}
+
+	for i := range entregas {
+		entregas[i].TituloTarea = titulos[entregas[i].TareaID]
+	}
+	return entregas, nil
+}
+
+// AbrirArchivoEntrega devuelve el archivo de una entrega. Pueden descargarlo su autor, el instructor
+// del curso y los administradores. El tipo de contenido se deduce otra vez de la extensión porque las
+// entregas anteriores guardaron el que declaraba el cliente.
+func (s *TareaService) AbrirArchivoEntrega(ctx context.Context, entregaID, email, password string) (*models.Entrega, io.ReadCloser, error) {
+	entrega, tarea, err := s.obtenerEntrega(ctx, entregaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if entrega.Email == email {
+		if _, err := obtenerUsuarioRedis(ctx, s.RedisClient, email, password); err != nil {
+			return nil, nil, err
+		}
+	} else if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, tarea.CursoID, email, password, errorInstructorTareas); err != nil {
+		return nil, nil, err
+	}
+
+	archivo, err := s.Almacenamiento.Abrir(ctx, entrega.Clave)
+	if err == almacenamiento.ErrNoEncontrado {
+		return nil, nil, errors.New("archivo de la entrega no encontrado")
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	tipoContenido, ok := tiposAdjunto[strings.ToLower(filepath.Ext(entrega.NombreArchivo))]
+	if !ok {
+		tipoContenido = "application/octet-stream"
+	}
+	entrega.TipoContenido = tipoContenido
+	return entrega, archivo, nil
+}
+
+// CalificarEntrega registra la calificación y el comentario del instructor sobre una entrega. Una
+// entrega calificada puede volver a calificarse, pero ya no puede reemplazarse.
+func (s *TareaService) CalificarEntrega(ctx context.Context, entregaID, email, password string, calificacion float64, comentario string) (*models.Entrega, error) {
+	entrega, tarea, err := s.obtenerEntrega(ctx, entregaID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, tarea.CursoID, email, password, errorInstructorTareas); err != nil {
+		return nil, err
+	}
+	if calificacion < 0 || calificacion > tarea.PuntajeMaximo {
+		return nil, errors.New("la calificación debe estar entre 0 y el puntaje máximo de la tarea")
+	}
+
+	ahora := time.Now()
+	_, err = s.EntregaCollection.UpdateOne(ctx, bson.M{"_id": entrega.ID}, bson.M{"$set": bson.M{
+		"calificacion":   calificacion,
+		"comentario":     comentario,
+		"calificada_por": email,
+		"calificada_en":  ahora,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	entrega.Calificacion = &calificacion
+	entrega.Comentario = comentario
+	entrega.CalificadaPor = email
+	entrega.CalificadaEn = &ahora
+	entrega.TituloTarea = tarea.Titulo
+	return entrega, nil
+}
+
+func (s *TareaService) obtenerTarea(ctx context.Context, id string) (*models.Tarea, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+
+	var tarea models.Tarea
+	if err := s.TareaCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&tarea); err == mongo.ErrNoDocuments {
+		return nil, errors.New("tarea no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+	return &tarea, nil
+}
+
+func (s *TareaService) obtenerEntrega(ctx context.Context, id string) (*models.Entrega, *models.Tarea, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil, errors.New("ID inválido")
+	}
+
+	var entrega models.Entrega
+	if err := s.EntregaCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&entrega); err == mongo.ErrNoDocuments {
+		return nil, nil, errors.New("entrega no encontrada")
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	tarea, err := s.obtenerTarea(ctx, entrega.TareaID.Hex())
+	if err != nil {
+		return nil, nil, err
+	}
+	return &entrega, tarea, nil
+}
+
+// eliminarArchivo borra un archivo que ya no se usa, registrando el error en el log.
+func eliminarArchivo(ctx context.Context, almacen almacenamiento.Almacenamiento, clave string) {
+	if err := almacen.Eliminar(ctx, clave); err != nil && err != almacenamiento.ErrNoEncontrado {
+		log.Printf("Error al eliminar el archivo %s: %v", clave, err)
+	}
+}
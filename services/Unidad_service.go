@@ -5,40 +5,61 @@ import (
 	"errors"
 
 	"go-API/models"
+	"log"
+	"time"
 
+	"github.com/go-redis/redis/v8"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+const (
+	DesbloqueoFecha           = "fecha"
+	DesbloqueoDiasInscripcion = "dias_inscripcion"
+	DesbloqueoUnidadAnterior  = "unidad_anterior"
+
+	errorInstructorUnidades = "solo el instructor del curso o un administrador puede modificar las unidades"
+)
+
 // UnidadService maneja la lógica relacionada con las unidades.
 type UnidadService struct {
 	UnidadCollection *mongo.Collection
 	CursoCollection  *mongo.Collection
+	Driver           neo4j.DriverWithContext
+	RedisClient      *redis.Client
+	historial        *historialVersiones
 }
 
 // NewUnidadService crea un nuevo servicio para las unidades.
-func NewUnidadService(db *mongo.Database) *UnidadService {
+func NewUnidadService(db *mongo.Database, driver neo4j.DriverWithContext, redisClient *redis.Client) *UnidadService {
 	return &UnidadService{
 		UnidadCollection: db.Collection("unidades"),
 		CursoCollection:  db.Collection("cursos"),
+		Driver:           driver,
+		RedisClient:      redisClient,
+		historial:        nuevoHistorialVersiones(db),
 	}
 }
 
-// ObtenerUnidadesPorCurso obtiene todas las unidades asociadas a un curso.
-func (s *UnidadService) ObtenerUnidadesPorCurso(id string) ([]models.Unidad, error) {
+// ObtenerUnidadesPorCurso obtiene todas las unidades asociadas a un curso visible para el usuario, indicando cuáles están
+// bloqueadas para el usuario de las credenciales. Sin credenciales se consideran las de un usuario
+// no inscrito.
+func (s *UnidadService) ObtenerUnidadesPorCurso(id, email, password string) ([]models.Unidad, error) {
+	usuario, err := usuarioOpcional(context.TODO(), s.RedisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+
 	objectID, err := primitive.ObjectIDFromHex(id) // Convertir a ObjectID
 	if err != nil {
 		return nil, errors.New("ID inválido")
 	}
 
-	// Obtener el curso por su ID
-	var curso models.Curso
-	err = s.CursoCollection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&curso)
+	// Obtener el curso por su ID, si el usuario puede verlo
+	curso, err := obtenerCursoVisible(context.TODO(), s.CursoCollection, objectID, usuario)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("curso no encontrado")
-		}
 		return nil, err
 	}
 
@@ -53,6 +74,13 @@ func (s *UnidadService) ObtenerUnidadesPorCurso(id string) ([]models.Unidad, err
 		return nil, err
 	}
 
+	bloqueos := calcularBloqueos(*curso, unidades, usuario, time.Now())
+	for i := range unidades {
+		estado := bloqueos[unidades[i].ID]
+		unidades[i].Bloqueada = estado.bloqueada
+		unidades[i].DesbloqueaEn = estado.desbloqueaEn
+	}
+
 	return unidades, nil
 }
 
@@ -73,6 +101,8 @@ func (s *UnidadService) CrearUnidad(id string, unidad models.Unidad) (*mongo.Ins
         return nil, err
     }
 
+    s.historial.versionInicial(context.TODO(), objectID)
+
     // Crear la nueva unidad con el ID del curso
     nuevaUnidad := models.Unidad{
         ID:      primitive.NewObjectID(),
@@ -87,6 +117,15 @@ func (s *UnidadService) CrearUnidad(id string, unidad models.Unidad) (*mongo.Ins
         return nil, err
     }
 
+    // Crear el nodo Unidad en Neo4j enlazado a su curso
+    if err := sincronizarUnidadEnNeo4j(context.TODO(), s.Driver, curso, nuevaUnidad); err != nil {
+        // Si falla crear el nodo en Neo4j, revertimos la inserción en MongoDB
+        if _, delErr := s.UnidadCollection.DeleteOne(context.TODO(), bson.M{"_id": nuevaUnidad.ID}); delErr != nil {
+            log.Printf("No se pudo eliminar la unidad de MongoDB tras fallo en Neo4j: %v", delErr)
+        }
+        return nil, err
+    }
+
     // Agregar el ID de la nueva unidad al curso
     _, err = s.CursoCollection.UpdateOne(
 		context.TODO(),
@@ -97,5 +136,242 @@ func (s *UnidadService) CrearUnidad(id string, unidad models.Unidad) (*mongo.Ins
         return nil, err
     }
 
+    s.historial.registrar(context.TODO(), objectID, "unidad creada: "+nuevaUnidad.Nombre, "")
     return result, nil
 }
+
+// sincronizarUnidadEnNeo4j crea (o actualiza) el nodo Unidad y su relación CONTENEDOR_DE con el nodo Curso.
+func sincronizarUnidadEnNeo4j(ctx context.Context, driver neo4j.DriverWithContext, curso models.Curso, unidad models.Unidad) error {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+            MERGE (c:Curso {id: $cursoID})
+            ON CREATE SET c.nombre = $cursoNombre
+            MERGE (u:Unidad {id: $id})
+            SET u.nombre = $nombre
+            MERGE (c)-[:CONTENEDOR_DE]->(u)
+        `
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"cursoID":     curso.ID.Hex(),
+			"cursoNombre": curso.Nombre,
+			"id":          unidad.ID.Hex(),
+			"nombre":      unidad.Nombre,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// ActualizarDesbloqueo reemplaza la regla de desbloqueo de una unidad, o la quita si la regla es nil.
+// Solo el instructor del curso o un administrador puede hacerlo.
+func (s *UnidadService) ActualizarDesbloqueo(ctx context.Context, id, email, password string, regla *models.ReglaDesbloqueo) (*models.Unidad, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("ID inválido")
+	}
+
+	var unidad models.Unidad
+	if err := s.UnidadCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&unidad); err == mongo.ErrNoDocuments {
+		return nil, errors.New("unidad no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, unidad.IDcurso, email, password, errorInstructorUnidades); err != nil {
+		return nil, err
+	}
+
+	cambios := bson.M{"$unset": bson.M{"desbloqueo": ""}}
+	if regla != nil {
+		if err := validarReglaDesbloqueo(regla); err != nil {
+			return nil, err
+		}
+		cambios = bson.M{"$set": bson.M{"desbloqueo": regla}}
+	}
+
+	s.historial.versionInicial(ctx, unidad.IDcurso)
+	if _, err := s.UnidadCollection.UpdateByID(ctx, objectID, cambios); err != nil {
+		return nil, err
+	}
+
+	unidad.Desbloqueo = regla
+	s.historial.registrar(ctx, unidad.IDcurso, "desbloqueo actualizado: "+unidad.Nombre, email)
+	return &unidad, nil
+}
+
+// validarReglaDesbloqueo verifica que la regla tenga los datos que requiere su tipo y descarta los demás.
+func validarReglaDesbloqueo(regla *models.ReglaDesbloqueo) error {
+	switch regla.Tipo {
+	case DesbloqueoFecha:
+		if regla.Fecha == nil {
+			return errors.New("la regla de fecha requiere la fecha de desbloqueo")
+		}
+		regla.Dias = 0
+	case DesbloqueoDiasInscripcion:
+		if regla.Dias <= 0 {
+			return errors.New("la regla de días desde la inscripción requiere una cantidad de días mayor que cero")
+		}
+		regla.Fecha = nil
+	case DesbloqueoUnidadAnterior:
+		regla.Fecha = nil
+		regla.Dias = 0
+	default:
+		return errors.New("tipo de regla de desbloqueo inválido")
+	}
+	return nil
+}
+
+// estadoBloqueo indica si una unidad está bloqueada para un usuario y, si se conoce, cuándo se
+// desbloquea. La fecha se desconoce cuando depende de completar la unidad anterior o de inscribirse.
+type estadoBloqueo struct {
+	bloqueada    bool
+	desbloqueaEn *time.Time
+}
+
+// calcularBloqueos calcula el estado de bloqueo de las unidades del curso para un usuario, o para un
+// usuario anónimo si es nil. El instructor del curso y los administradores ven todas las unidades
+// desbloqueadas. La unidad anterior es la que precede en el orden del curso y se considera completada
+// cuando está desbloqueada y el usuario vio todas sus clases.
+func calcularBloqueos(curso models.Curso, unidades []models.Unidad, usuario *models.Usuario, ahora time.Time) map[primitive.ObjectID]estadoBloqueo {
+	bloqueos := map[primitive.ObjectID]estadoBloqueo{}
+	if usuario != nil && (curso.Instructor == usuario.Email || esAdmin(usuario.Email)) {
+		return bloqueos
+	}
+
+	var inscripcion *time.Time
+	vistas := map[primitive.ObjectID]bool{}
+	if usuario != nil {
+		for i, cursoID := range usuario.Inscritos {
+			if cursoID == curso.ID && i < len(usuario.FechaInscripcion) {
+				inscripcion = &usuario.FechaInscripcion[i]
+			}
+		}
+		for _, progreso := range usuario.Progresos {
+			if progreso.CursoID == curso.ID {
+				for _, claseID := range progreso.ClasesVistas {
+					vistas[claseID] = true
+				}
+			}
+		}
+	}
+
+	porID := map[primitive.ObjectID]*models.Unidad{}
+	for i := range unidades {
+		porID[unidades[i].ID] = &unidades[i]
+	}
+
+	anteriorCompletada := true
+	for _, unidadID := range curso.Unidades {
+		unidad, ok := porID[unidadID]
+		if !ok {
+			continue
+		}
+
+		estado := estadoBloqueo{}
+		if regla := unidad.Desbloqueo; regla != nil {
+			switch regla.Tipo {
+			case DesbloqueoFecha:
+				if regla.Fecha != nil && ahora.Before(*regla.Fecha) {
+					estado = estadoBloqueo{bloqueada: true, desbloqueaEn: regla.Fecha}
+				}
+			case DesbloqueoDiasInscripcion:
+				if inscripcion == nil {
+					estado.bloqueada = true
+				} else if fecha := inscripcion.AddDate(0, 0, regla.Dias); ahora.Before(fecha) {
+					estado = estadoBloqueo{bloqueada: true, desbloqueaEn: &fecha}
+				}
+			case DesbloqueoUnidadAnterior:
+				estado.bloqueada = !anteriorCompletada
+			}
+		}
+		bloqueos[unidadID] = estado
+
+		anteriorCompletada = !estado.bloqueada
+		for _, claseID := range unidad.Clases {
+			if !vistas[claseID] {
+				anteriorCompletada = false
+				break
+			}
+		}
+	}
+
+	return bloqueos
+}
+
+// bloqueoUnidad obtiene el curso de una unidad y sus unidades y calcula el estado de bloqueo de la
+// unidad para el usuario.
+func bloqueoUnidad(ctx context.Context, cursos, unidades *mongo.Collection, cursoID, unidadID primitive.ObjectID, usuario *models.Usuario) (estadoBloqueo, error) {
+	var curso models.Curso
+	if err := cursos.FindOne(ctx, bson.M{"_id": cursoID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return estadoBloqueo{}, errors.New("curso no encontrado")
+	} else if err != nil {
+		return estadoBloqueo{}, err
+	}
+
+	cursor, err := unidades.Find(ctx, bson.M{"_id": bson.M{"$in": curso.Unidades}})
+	if err != nil {
+		return estadoBloqueo{}, err
+	}
+	var unidadesCurso []models.Unidad
+	if err := cursor.All(ctx, &unidadesCurso); err != nil {
+		return estadoBloqueo{}, err
+	}
+
+	return calcularBloqueos(curso, unidadesCurso, usuario, time.Now())[unidadID], nil
+}
+
+// verificarUnidadDisponible verifica que el usuario de las credenciales, o un usuario anónimo si no se
+// indicaron, pueda ver el curso y que la unidad no esté bloqueada para él.
+func verificarUnidadDisponible(ctx context.Context, redisClient *redis.Client, cursos, unidades *mongo.Collection, cursoID, unidadID primitive.ObjectID, email, password string) error {
+	usuario, err := usuarioOpcional(ctx, redisClient, email, password)
+	if err != nil {
+		return err
+	}
+	if _, err := obtenerCursoVisible(ctx, cursos, cursoID, usuario); err != nil {
+		return err
+	}
+	return verificarUnidadDesbloqueada(ctx, cursos, unidades, cursoID, unidadID, usuario)
+}
+
+// verificarUnidadDesbloqueada devuelve el error de clase bloqueada si la unidad está bloqueada para
+// el usuario.
+func verificarUnidadDesbloqueada(ctx context.Context, cursos, unidades *mongo.Collection, cursoID, unidadID primitive.ObjectID, usuario *models.Usuario) error {
+	estado, err := bloqueoUnidad(ctx, cursos, unidades, cursoID, unidadID, usuario)
+	if err != nil {
+		return err
+	}
+	if estado.bloqueada {
+		return errorClaseBloqueada(estado)
+	}
+	return nil
+}
+
+// ocultarClaseBloqueada marca una clase como bloqueada y quita el contenido al que no se puede
+// acceder todavía: el video, los adjuntos y los subtítulos.
+func ocultarClaseBloqueada(clase *models.Clase, estado estadoBloqueo) {
+	clase.Bloqueada = true
+	clase.DesbloqueaEn = estado.desbloqueaEn
+	clase.VideoURL = ""
+	clase.Adjuntos_url = []string{}
+	clase.Adjuntos = []models.Adjunto{}
+	clase.Subtitulos = []models.PistaSubtitulos{}
+}
+
+// errorClaseBloqueada describe hasta cuándo está bloqueada una clase.
+func errorClaseBloqueada(estado estadoBloqueo) error {
+	if estado.desbloqueaEn != nil {
+		return errors.New("la clase está bloqueada hasta el " + estado.desbloqueaEn.Format(time.RFC3339))
+	}
+	return errors.New("la clase está bloqueada hasta completar la unidad anterior")
+}
+
+// usuarioOpcional obtiene el usuario de las credenciales, o nil si no se indicaron.
+func usuarioOpcional(ctx context.Context, redisClient *redis.Client, email, password string) (*models.Usuario, error) {
+	if email == "" && password == "" {
+		return nil, nil
+	}
+	return obtenerUsuarioRedis(ctx, redisClient, email, password)
+}
@@ -3,9 +3,14 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"go-API/correo"
 	"go-API/models"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -13,23 +18,32 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UsuarioService struct {
-	RedisClient      *redis.Client
-	CursoCollection  *mongo.Collection
-	UnidadCollection *mongo.Collection
-	ClaseCollection  *mongo.Collection
-	Driver           neo4j.DriverWithContext
+	RedisClient       *redis.Client
+	CursoCollection   *mongo.Collection
+	UnidadCollection  *mongo.Collection
+	ClaseCollection   *mongo.Collection
+	AnuncioCollection *mongo.Collection
+	Driver            neo4j.DriverWithContext
+	Notificaciones    *NotificacionService
+	Correo            *CorreoService
+	Cuestionarios     *CuestionarioService
 }
 
-func NewUsuarioService(redisClient *redis.Client, cursoCollection *mongo.Collection, unidadCollection *mongo.Collection, claseCollection *mongo.Collection, driver neo4j.DriverWithContext) *UsuarioService {
+func NewUsuarioService(redisClient *redis.Client, cursoCollection *mongo.Collection, unidadCollection *mongo.Collection, claseCollection *mongo.Collection, anuncioCollection *mongo.Collection, driver neo4j.DriverWithContext, notificaciones *NotificacionService, correo *CorreoService, cuestionarios *CuestionarioService) *UsuarioService {
 	return &UsuarioService{
-		RedisClient:      redisClient,
-		CursoCollection:  cursoCollection,
-		UnidadCollection: unidadCollection,
-		ClaseCollection:  claseCollection,
-		Driver:           driver,
+		RedisClient:       redisClient,
+		CursoCollection:   cursoCollection,
+		UnidadCollection:  unidadCollection,
+		ClaseCollection:   claseCollection,
+		AnuncioCollection: anuncioCollection,
+		Driver:            driver,
+		Notificaciones:    notificaciones,
+		Correo:            correo,
+		Cuestionarios:     cuestionarios,
 	}
 }
 
@@ -129,6 +143,12 @@ func (us *UsuarioService) ObtenerUsuarioPorCorreoYContrasena(email, password str
 	return &usuario, nil
 }
 
+// claveInscritosCurso es el conjunto de Redis con los emails de los inscritos en un curso. Permite
+// obtener los inscritos sin recorrer todos los usuarios.
+func claveInscritosCurso(cursoID primitive.ObjectID) string {
+	return "curso:inscritos:" + cursoID.Hex()
+}
+
 func (us *UsuarioService) InscribirseACurso(email, password, cursoID string) error {
 	key := "usuario:" + email + ":" + password
 
@@ -149,6 +169,17 @@ func (us *UsuarioService) InscribirseACurso(email, password, cursoID string) err
 		return err
 	}
 
+	// Solo se admiten inscripciones en cursos publicados
+	var curso models.Curso
+	if err := us.CursoCollection.FindOne(context.TODO(), bson.M{"_id": cursoObjectID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return errors.New("curso no encontrado")
+	} else if err != nil {
+		return err
+	}
+	if curso.EstadoActual() != models.EstadoCursoPublicado {
+		return errors.New("el curso no está publicado")
+	}
+
 	// Verificar si el usuario ya está inscrito en el curso
 	for _, inscrito := range usuario.Inscritos {
 		if inscrito == cursoObjectID {
@@ -173,16 +204,34 @@ func (us *UsuarioService) InscribirseACurso(email, password, cursoID string) err
 		return err
 	}
 
-	// Actualizar el usuario en Redis
-	err = us.RedisClient.Set(context.TODO(), key, data, 0).Err()
-	if err != nil {
+	// Actualizar el usuario en Redis y agregarlo a los inscritos del curso
+	pipe := us.RedisClient.TxPipeline()
+	pipe.Set(context.TODO(), key, data, 0)
+	pipe.SAdd(context.TODO(), claveInscritosCurso(cursoObjectID), email)
+	if _, err := pipe.Exec(context.TODO()); err != nil {
 		return err
 	}
 
+	nombre := curso.Nombre
+	us.Notificaciones.notificarEnSegundoPlano(email, NotificacionInscripcion,
+		"Te inscribiste en el curso "+nombre, map[string]string{"curso_id": cursoID, "curso": nombre})
+
 	return nil
 }
 
-func (us *UsuarioService) ObtenerCursosInscritos(email, password string) ([]models.Curso, error) {
+// nombreCurso obtiene el nombre de un curso para los mensajes de notificación, o un texto genérico
+// si no se encuentra.
+func (us *UsuarioService) nombreCurso(cursoID primitive.ObjectID) string {
+	var curso models.Curso
+	if err := us.CursoCollection.FindOne(context.TODO(), bson.M{"_id": cursoID}).Decode(&curso); err != nil {
+		return "seleccionado"
+	}
+	return curso.Nombre
+}
+
+// ObtenerCursosInscritos obtiene los cursos en los que el usuario está inscrito, con la cantidad de
+// anuncios de cada uno que aún no leyó.
+func (us *UsuarioService) ObtenerCursosInscritos(email, password string) ([]models.CursoInscrito, error) {
 	// Construir la clave de Redis
 	key := "usuario:" + email + ":" + password
 
@@ -202,7 +251,7 @@ func (us *UsuarioService) ObtenerCursosInscritos(email, password string) ([]mode
 
 	// Verificar si el usuario tiene cursos inscritos
 	if len(usuario.Inscritos) == 0 {
-		return []models.Curso{}, nil // Retorna un slice vacío
+		return []models.CursoInscrito{}, nil // Retorna un slice vacío
 	}
 
 	// Convertir los IDs de cursos a ObjectID si es necesario
@@ -215,13 +264,18 @@ func (us *UsuarioService) ObtenerCursosInscritos(email, password string) ([]mode
 	}
 	defer cursor.Close(context.TODO())
 
-	var cursos []models.Curso
+	noLeidos, err := contarAnunciosNoLeidos(context.TODO(), us.AnuncioCollection, us.RedisClient, email, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursos []models.CursoInscrito
 	for cursor.Next(context.TODO()) {
 		var curso models.Curso
 		if err := cursor.Decode(&curso); err != nil {
 			return nil, err
 		}
-		cursos = append(cursos, curso)
+		cursos = append(cursos, models.CursoInscrito{Curso: curso, AnunciosNoLeidos: noLeidos[curso.ID]})
 	}
 
 	if err := cursor.Err(); err != nil {
@@ -248,7 +302,7 @@ func (s *UsuarioService) VerClase(email, password, claseID string) error {
 	// Obtener la Clase
 	var clase models.Clase
 	err = s.ClaseCollection.FindOne(context.TODO(), bson.M{"_id": claseObjectID}).Decode(&clase)
-	if err != nil {
+	if err != nil || clase.UnidadID.IsZero() {
 		return errors.New("clase no encontrada")
 	}
 
@@ -258,6 +312,9 @@ func (s *UsuarioService) VerClase(email, password, claseID string) error {
 	if err != nil {
 		return errors.New("unidad no encontrada")
 	}
+	if unidad.IDcurso.IsZero() {
+		return errors.New("clase no encontrada")
+	}
 
 	// Obtener el Curso de la Unidad
 	cursoID := unidad.IDcurso
@@ -275,11 +332,29 @@ func (s *UsuarioService) VerClase(email, password, claseID string) error {
 		return errors.New("el usuario no está inscrito en el curso de esta clase")
 	}
 
+	// Las clases de una unidad bloqueada no se pueden ver hasta que se desbloquee
+	estado, err := bloqueoUnidad(context.TODO(), s.CursoCollection, s.UnidadCollection, cursoID, unidad.ID, usuario)
+	if err != nil {
+		return err
+	}
+	if estado.bloqueada {
+		return errorClaseBloqueada(estado)
+	}
+
 	// Verificar si la clase ya ha sido vista
 	if contains(progreso.ClasesVistas, claseObjectID) {
 		return errors.New("clase ya vista")
 	}
 
+	// Los cuestionarios requeridos de la clase deben estar aprobados para marcarla como vista
+	pendiente, err := s.Cuestionarios.cuestionarioPendiente(context.TODO(), email, claseObjectID)
+	if err != nil {
+		return err
+	}
+	if pendiente {
+		return errors.New("debe aprobar el cuestionario de la clase para completarla")
+	}
+
 	// Agregar la clase a ClasesVistas
 	progreso.ClasesVistas = append(progreso.ClasesVistas, claseObjectID)
 
@@ -290,6 +365,7 @@ func (s *UsuarioService) VerClase(email, password, claseID string) error {
 	}
 
 	// Actualizar el estado del progreso
+	estadoAnterior := progreso.Estado
 	if len(progreso.ClasesVistas) == 0 {
 		progreso.Estado = "INICIADO"
 	} else if len(progreso.ClasesVistas) < len(totalClases) {
@@ -318,6 +394,14 @@ func (s *UsuarioService) VerClase(email, password, claseID string) error {
 		return err
 	}
 
+	// Completar el curso emite su certificado
+	if progreso.Estado == "COMPLETADO" && estadoAnterior != "COMPLETADO" {
+		nombre := s.nombreCurso(cursoID)
+		s.Notificaciones.notificarEnSegundoPlano(email, NotificacionCertificado,
+			"Completaste el curso "+nombre+" y obtuviste tu certificado",
+			map[string]string{"curso_id": cursoID.Hex(), "curso": nombre})
+	}
+
 	return nil
 }
 
@@ -352,13 +436,261 @@ func contains(slice []primitive.ObjectID, item primitive.ObjectID) bool {
 	return false
 }
 
-// ObtenerProgresoCursos obtiene el progreso de los cursos en los que un usuario está inscrito.
-func (s *UsuarioService) ObtenerProgresoCursos(email, password string) ([]models.ProgresoCurso, error) {
+// obtenerUsuarioRedis obtiene un usuario desde Redis validando su email y contraseña.
+func obtenerUsuarioRedis(ctx context.Context, redisClient *redis.Client, email, password string) (*models.Usuario, error) {
+	key := "usuario:" + email + ":" + password
+	val, err := redisClient.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, errors.New("usuario no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+
+	var usuario models.Usuario
+	if err := json.Unmarshal([]byte(val), &usuario); err != nil {
+		return nil, err
+	}
+
+	return &usuario, nil
+}
+
+// obtenerUsuarioPorEmail obtiene un usuario desde Redis solo por su email, junto con su clave.
+// Se usa cuando no se conoce la contraseña, como al enviarle un correo.
+func obtenerUsuarioPorEmail(ctx context.Context, redisClient *redis.Client, email string) (*models.Usuario, string, error) {
+	// Escapar los caracteres especiales del patrón para que el email se compare literalmente
+	patron := strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`).Replace(email)
+	keys, err := redisClient.Keys(ctx, "usuario:"+patron+":*").Result()
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, key := range keys {
+		val, err := redisClient.Get(ctx, key).Result()
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return nil, "", err
+		}
+
+		var usuario models.Usuario
+		if err := json.Unmarshal([]byte(val), &usuario); err != nil {
+			return nil, "", err
+		}
+		if usuario.Email == email {
+			return &usuario, key, nil
+		}
+	}
+
+	return nil, "", errors.New("usuario no encontrado")
+}
+
+// obtenerUsuarioInscrito obtiene un usuario desde Redis y verifica que esté inscrito en el curso.
+func obtenerUsuarioInscrito(ctx context.Context, redisClient *redis.Client, email, password, cursoID string) (*models.Usuario, error) {
+	usuario, err := obtenerUsuarioRedis(ctx, redisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	cursoObjectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return nil, errors.New("ID de curso inválido")
+	}
+
+	if !contains(usuario.Inscritos, cursoObjectID) {
+		return nil, errors.New("el usuario no está inscrito en este curso")
+	}
+
+	return usuario, nil
+}
+
+// esAdmin indica si un email pertenece a un administrador. Los administradores se configuran
+// en la variable de entorno ADMIN_EMAILS como una lista separada por comas.
+func esAdmin(email string) bool {
+	for _, admin := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if admin = strings.TrimSpace(admin); admin != "" && strings.EqualFold(admin, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// obtenerAdmin obtiene un usuario desde Redis y verifica que sea administrador.
+func obtenerAdmin(ctx context.Context, redisClient *redis.Client, email, password string) (*models.Usuario, error) {
+	usuario, err := obtenerUsuarioRedis(ctx, redisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if !esAdmin(usuario.Email) {
+		return nil, errors.New("acceso restringido a administradores")
+	}
+
+	return usuario, nil
+}
+
+// esModerador indica si un email pertenece a un moderador de comentarios. Son moderadores los
+// administradores y los emails de la variable de entorno MODERADOR_EMAILS (separados por comas).
+func esModerador(email string) bool {
+	if esAdmin(email) {
+		return true
+	}
+	for _, moderador := range strings.Split(os.Getenv("MODERADOR_EMAILS"), ",") {
+		if moderador = strings.TrimSpace(moderador); moderador != "" && strings.EqualFold(moderador, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// obtenerModerador obtiene un usuario desde Redis y verifica que sea moderador.
+func obtenerModerador(ctx context.Context, redisClient *redis.Client, email, password string) (*models.Usuario, error) {
+	usuario, err := obtenerUsuarioRedis(ctx, redisClient, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if !esModerador(usuario.Email) {
+		return nil, errors.New("acceso restringido a moderadores")
+	}
+
+	return usuario, nil
+}
+
+// ObtenerProgresoCursos obtiene el progreso de los cursos en los que un usuario está inscrito, con
+// la duración total de cada curso y el tiempo que le queda según las clases que aún no vio.
+func (s *UsuarioService) ObtenerProgresoCursos(email, password string) ([]models.ProgresoCursoDetallado, error) {
 	// Obtener el usuario
 	usuario, err := s.ObtenerUsuarioPorCorreoYContrasena(email, password)
 	if err != nil {
 		return nil, err
 	}
 
-	return usuario.Progresos, nil
+	progresos := []models.ProgresoCursoDetallado{}
+	if len(usuario.Progresos) == 0 {
+		return progresos, nil
+	}
+
+	cursoIDs := []primitive.ObjectID{}
+	for _, progreso := range usuario.Progresos {
+		cursoIDs = append(cursoIDs, progreso.CursoID)
+	}
+
+	// Se suman las clases existentes, para que las ya eliminadas no cuenten como vistas ni pendientes
+	cursor, err := s.UnidadCollection.Find(context.TODO(), bson.M{"idcurso": bson.M{"$in": cursoIDs}}, options.Find().SetProjection(bson.M{"idcurso": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var unidades []models.Unidad
+	if err := cursor.All(context.TODO(), &unidades); err != nil {
+		return nil, err
+	}
+	cursoDeUnidad := map[primitive.ObjectID]primitive.ObjectID{}
+	unidadIDs := []primitive.ObjectID{}
+	for _, unidad := range unidades {
+		cursoDeUnidad[unidad.ID] = unidad.IDcurso
+		unidadIDs = append(unidadIDs, unidad.ID)
+	}
+
+	cursor, err = s.ClaseCollection.Find(context.TODO(), bson.M{"unidad_id": bson.M{"$in": unidadIDs}}, options.Find().SetProjection(bson.M{"unidad_id": 1, "duracion": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var clases []models.Clase
+	if err := cursor.All(context.TODO(), &clases); err != nil {
+		return nil, err
+	}
+	clasesPorCurso := map[primitive.ObjectID][]models.Clase{}
+	for _, clase := range clases {
+		cursoID := cursoDeUnidad[clase.UnidadID]
+		clasesPorCurso[cursoID] = append(clasesPorCurso[cursoID], clase)
+	}
+
+	for _, progreso := range usuario.Progresos {
+		detallado := models.ProgresoCursoDetallado{ProgresoCurso: progreso}
+		for _, clase := range clasesPorCurso[progreso.CursoID] {
+			detallado.DuracionTotal += clase.Duracion
+			if !contains(progreso.ClasesVistas, clase.ID) {
+				detallado.TiempoRestante += clase.Duracion
+			}
+		}
+		progresos = append(progresos, detallado)
+	}
+
+	return progresos, nil
+}
+
+// vigenciaRestablecimiento es el tiempo durante el que se puede usar un código de restablecimiento.
+const vigenciaRestablecimiento = time.Hour
+
+// SolicitarRestablecimientoPassword genera un código de un solo uso para restablecer la contraseña y
+// lo envía al correo del usuario. Si el email no está registrado no hace nada, para no revelar qué
+// emails tienen cuenta.
+func (s *UsuarioService) SolicitarRestablecimientoPassword(ctx context.Context, email string) error {
+	if _, _, err := obtenerUsuarioPorEmail(ctx, s.RedisClient, email); err != nil {
+		if err.Error() == "usuario no encontrado" {
+			return nil
+		}
+		return err
+	}
+
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return err
+	}
+	token := hex.EncodeToString(bytes)
+
+	if err := s.RedisClient.Set(ctx, "restablecimiento:"+token, email, vigenciaRestablecimiento).Err(); err != nil {
+		return err
+	}
+
+	s.Correo.encolar(ctx, email, correo.PlantillaRestablecerPassword, map[string]string{"token": token})
+	return nil
+}
+
+// RestablecerPassword cambia la contraseña del usuario dueño del código. Como la contraseña forma
+// parte de la clave del usuario en Redis, el usuario se guarda bajo su nueva clave.
+func (s *UsuarioService) RestablecerPassword(ctx context.Context, token, password string) error {
+	// El código se consume al leerlo para que no pueda usarse dos veces
+	email, err := s.RedisClient.GetDel(ctx, "restablecimiento:"+token).Result()
+	if err == redis.Nil {
+		return errors.New("código inválido o vencido")
+	} else if err != nil {
+		return err
+	}
+
+	usuario, keyAnterior, err := obtenerUsuarioPorEmail(ctx, s.RedisClient, email)
+	if err != nil {
+		if err.Error() == "usuario no encontrado" {
+			return errors.New("código inválido o vencido")
+		}
+		return err
+	}
+
+	usuario.Password = password
+	data, err := json.Marshal(usuario)
+	if err != nil {
+		return err
+	}
+
+	keyNueva := "usuario:" + email + ":" + password
+	pipe := s.RedisClient.TxPipeline()
+	pipe.Set(ctx, keyNueva, data, 0)
+	if keyNueva != keyAnterior {
+		pipe.Del(ctx, keyAnterior)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, `MATCH (u:Usuario {email: $email}) SET u.password = $password`, map[string]interface{}{
+			"email":    email,
+			"password": password,
+		})
+		return nil, err
+	})
+	return err
 }
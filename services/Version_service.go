@@ -0,0 +1,645 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-API/models"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	CambioAgregado   = "agregado"
+	CambioEliminado  = "eliminado"
+	CambioModificado = "modificado"
+
+	errorInstructorVersiones = "solo el instructor del curso o un administrador puede gestionar las versiones del curso"
+
+	// maxReintentosVersion es la cantidad de veces que se toma un número nuevo si otro cambio
+	// simultáneo guardó antes una versión con el mismo número.
+	maxReintentosVersion = 3
+)
+
+// historialVersiones guarda una instantánea de la estructura de un curso cada vez que cambia. Cada
+// curso lleva en "version" el número de su última versión.
+type historialVersiones struct {
+	versiones *mongo.Collection
+	cursos    *mongo.Collection
+	unidades  *mongo.Collection
+	clases    *mongo.Collection
+}
+
+func nuevoHistorialVersiones(db *mongo.Database) *historialVersiones {
+	return &historialVersiones{
+		versiones: db.Collection("versiones_curso"),
+		cursos:    db.Collection("cursos"),
+		unidades:  db.Collection("unidades"),
+		clases:    db.Collection("clases"),
+	}
+}
+
+// registrar guarda una versión nueva del curso registrando el error en el log en lugar de devolverlo,
+// para que un fallo del historial no haga fallar el cambio que lo originó.
+func (h *historialVersiones) registrar(ctx context.Context, cursoID primitive.ObjectID, motivo, autor string) {
+	if _, err := h.guardar(ctx, cursoID, motivo, autor); err != nil {
+		log.Printf("Error al guardar la versión del curso %s (%s): %v", cursoID.Hex(), motivo, err)
+	}
+}
+
+// versionInicial guarda la estructura actual de un curso que todavía no tiene versiones como su
+// versión 1. Se llama antes de cada cambio para que el estado previo al primer cambio de los cursos
+// creados antes del historial también pueda restaurarse. Los errores se registran en el log.
+func (h *historialVersiones) versionInicial(ctx context.Context, cursoID primitive.ObjectID) {
+	if err := h.guardarVersionInicial(ctx, cursoID); err != nil {
+		log.Printf("Error al guardar la versión inicial del curso %s: %v", cursoID.Hex(), err)
+	}
+}
+
+func (h *historialVersiones) guardarVersionInicial(ctx context.Context, cursoID primitive.ObjectID) error {
+	var curso models.Curso
+	err := h.cursos.FindOne(ctx, bson.M{"_id": cursoID}, options.FindOne().SetProjection(bson.M{"version": 1})).Decode(&curso)
+	if err != nil || curso.Version > 0 {
+		return err
+	}
+
+	estructura, err := h.instantanea(ctx, cursoID)
+	if err != nil {
+		return err
+	}
+
+	// Solo el primero de dos cambios simultáneos toma el número 1
+	resultado, err := h.cursos.UpdateOne(ctx, bson.M{"_id": cursoID, "version": bson.M{"$in": bson.A{0, nil}}}, bson.M{"$set": bson.M{"version": 1}})
+	if err != nil || resultado.ModifiedCount == 0 {
+		return err
+	}
+
+	_, err = h.versiones.InsertOne(ctx, &models.VersionCurso{
+		ID:         primitive.NewObjectID(),
+		CursoID:    cursoID,
+		Numero:     1,
+		Motivo:     "versión inicial",
+		Fecha:      time.Now(),
+		Estructura: estructura,
+	})
+	return err
+}
+
+// guardar toma una instantánea de la estructura actual del curso y la guarda con el número siguiente.
+// El índice único de curso y número impide que dos versiones compartan número; si otro cambio
+// simultáneo ocupó el número, se toma el siguiente.
+func (h *historialVersiones) guardar(ctx context.Context, cursoID primitive.ObjectID, motivo, autor string) (*models.VersionCurso, error) {
+	estructura, err := h.instantanea(ctx, cursoID)
+	if err != nil {
+		return nil, err
+	}
+
+	for intento := 1; ; intento++ {
+		var curso models.Curso
+		err = h.cursos.FindOneAndUpdate(ctx, bson.M{"_id": cursoID}, bson.M{"$inc": bson.M{"version": 1}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&curso)
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("curso no encontrado")
+		} else if err != nil {
+			return nil, err
+		}
+
+		version := &models.VersionCurso{
+			ID:         primitive.NewObjectID(),
+			CursoID:    cursoID,
+			Numero:     curso.Version,
+			Motivo:     motivo,
+			Autor:      autor,
+			Fecha:      time.Now(),
+			Estructura: estructura,
+		}
+		_, err = h.versiones.InsertOne(ctx, version)
+		if err == nil {
+			return version, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) || intento == maxReintentosVersion {
+			return nil, err
+		}
+	}
+}
+
+// instantanea obtiene la estructura actual de un curso, con sus unidades y clases en el orden del curso.
+func (h *historialVersiones) instantanea(ctx context.Context, cursoID primitive.ObjectID) (*models.EstructuraCurso, error) {
+	var curso models.Curso
+	if err := h.cursos.FindOne(ctx, bson.M{"_id": cursoID}).Decode(&curso); err == mongo.ErrNoDocuments {
+		return nil, errors.New("curso no encontrado")
+	} else if err != nil {
+		return nil, err
+	}
+
+	cursor, err := h.unidades.Find(ctx, bson.M{"_id": bson.M{"$in": curso.Unidades}})
+	if err != nil {
+		return nil, err
+	}
+	var unidades []models.Unidad
+	if err := cursor.All(ctx, &unidades); err != nil {
+		return nil, err
+	}
+
+	idsClases := []primitive.ObjectID{}
+	unidadesPorID := map[primitive.ObjectID]models.Unidad{}
+	for _, unidad := range unidades {
+		unidadesPorID[unidad.ID] = unidad
+		idsClases = append(idsClases, unidad.Clases...)
+	}
+
+	cursor, err = h.clases.Find(ctx, bson.M{"_id": bson.M{"$in": idsClases}})
+	if err != nil {
+		return nil, err
+	}
+	var clases []models.Clase
+	if err := cursor.All(ctx, &clases); err != nil {
+		return nil, err
+	}
+	clasesPorID := map[primitive.ObjectID]models.Clase{}
+	for _, clase := range clases {
+		clasesPorID[clase.ID] = clase
+	}
+
+	estructura := &models.EstructuraCurso{
+		Nombre:      curso.Nombre,
+		Descripcion: curso.Descripcion,
+		Unidades:    []models.EstructuraUnidad{},
+	}
+	for _, unidadID := range curso.Unidades {
+		unidad, ok := unidadesPorID[unidadID]
+		if !ok {
+			continue
+		}
+		estructuraUnidad := models.EstructuraUnidad{
+			ID:         unidad.ID,
+			Nombre:     unidad.Nombre,
+			Desbloqueo: unidad.Desbloqueo,
+			Clases:     []models.EstructuraClase{},
+		}
+		for _, claseID := range unidad.Clases {
+			clase, ok := clasesPorID[claseID]
+			if !ok {
+				continue
+			}
+			estructuraUnidad.Clases = append(estructuraUnidad.Clases, models.EstructuraClase{
+				ID:          clase.ID,
+				Nombre:      clase.Nombre,
+				Descripcion: clase.Descripcion,
+				VideoURL:    clase.VideoURL,
+				Duracion:    clase.Duracion,
+			})
+		}
+		estructura.Unidades = append(estructura.Unidades, estructuraUnidad)
+	}
+	return estructura, nil
+}
+
+// VersionService gestiona el historial de versiones de la estructura de los cursos: permite
+// consultarlas, compararlas y restaurar una versión anterior. Solo el instructor del curso o un
+// administrador puede hacerlo.
+type VersionService struct {
+	VersionCollection *mongo.Collection
+	CursoCollection   *mongo.Collection
+	UnidadCollection  *mongo.Collection
+	ClaseCollection   *mongo.Collection
+	Driver            neo4j.DriverWithContext
+	RedisClient       *redis.Client
+	historial         *historialVersiones
+}
+
+func NewVersionService(db *mongo.Database, driver neo4j.DriverWithContext, redisClient *redis.Client) *VersionService {
+	return &VersionService{
+		VersionCollection: db.Collection("versiones_curso"),
+		CursoCollection:   db.Collection("cursos"),
+		UnidadCollection:  db.Collection("unidades"),
+		ClaseCollection:   db.Collection("clases"),
+		Driver:            driver,
+		RedisClient:       redisClient,
+		historial:         nuevoHistorialVersiones(db),
+	}
+}
+
+// CrearIndices crea el índice único que impide que dos versiones de un curso tengan el mismo número.
+func (s *VersionService) CrearIndices(ctx context.Context) error {
+	_, err := s.VersionCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "curso_id", Value: 1}, {Key: "numero", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// ObtenerVersiones obtiene las versiones de un curso, de la más reciente a la más antigua, sin su
+// estructura.
+func (s *VersionService) ObtenerVersiones(ctx context.Context, cursoID, email, password string) ([]models.VersionCurso, error) {
+	objectID, err := s.verificarInstructor(ctx, cursoID, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	opciones := options.Find().
+		SetSort(bson.D{{Key: "numero", Value: -1}}).
+		SetProjection(bson.M{"estructura": 0})
+	cursor, err := s.VersionCollection.Find(ctx, bson.M{"curso_id": objectID}, opciones)
+	if err != nil {
+		return nil, err
+	}
+
+	versiones := []models.VersionCurso{}
+	if err := cursor.All(ctx, &versiones); err != nil {
+		return nil, err
+	}
+	return versiones, nil
+}
+
+// ObtenerVersion obtiene una versión de un curso con su estructura.
+func (s *VersionService) ObtenerVersion(ctx context.Context, cursoID string, numero int, email, password string) (*models.VersionCurso, error) {
+	objectID, err := s.verificarInstructor(ctx, cursoID, email, password)
+	if err != nil {
+		return nil, err
+	}
+	return s.buscarVersion(ctx, objectID, numero)
+}
+
+// CompararVersiones obtiene los cambios en la estructura de un curso entre dos versiones. Si hasta es
+// 0 se compara con la versión más reciente.
+func (s *VersionService) CompararVersiones(ctx context.Context, cursoID string, desde, hasta int, email, password string) (*models.DiferenciaVersiones, error) {
+	objectID, err := s.verificarInstructor(ctx, cursoID, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasta == 0 {
+		var curso models.Curso
+		err := s.CursoCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&curso)
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("curso no encontrado")
+		} else if err != nil {
+			return nil, err
+		}
+		hasta = curso.Version
+	}
+
+	anterior, err := s.buscarVersion(ctx, objectID, desde)
+	if err != nil {
+		return nil, err
+	}
+	posterior, err := s.buscarVersion(ctx, objectID, hasta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DiferenciaVersiones{
+		Desde:   desde,
+		Hasta:   hasta,
+		Cambios: compararEstructuras(anterior.Estructura, posterior.Estructura),
+	}, nil
+}
+
+// RestaurarVersion devuelve la estructura de un curso a la de una versión anterior y guarda el
+// resultado como una versión nueva. Las unidades y clases que siguen existiendo conservan su ID, por
+// lo que el progreso de los inscritos, los comentarios y los adjuntos de esas clases no cambian. Las
+// unidades y clases que no están en la versión se quitan del curso sin eliminarlas, y vuelven con todos
+// sus datos si se restaura una versión que las incluye; las que se habían eliminado se vuelven a crear
+// sin comentarios ni adjuntos. Primero se sincroniza Neo4j, que puede repetirse sin efectos, y luego
+// se cambia MongoDB en una transacción; así una restauración fallida no deja el curso a medias y se
+// puede volver a intentar.
+func (s *VersionService) RestaurarVersion(ctx context.Context, cursoID string, numero int, email, password string) (*models.VersionCurso, error) {
+	objectID, err := s.verificarInstructor(ctx, cursoID, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.buscarVersion(ctx, objectID, numero)
+	if err != nil {
+		return nil, err
+	}
+	destino := version.Estructura
+
+	actual, err := s.historial.instantanea(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(compararEstructuras(actual, destino)) == 0 {
+		return nil, errors.New("el curso ya tiene la estructura de esa versión")
+	}
+
+	if err := s.sincronizarEstructuraEnNeo4j(ctx, objectID, destino); err != nil {
+		return nil, err
+	}
+
+	sesion, err := s.CursoCollection.Database().Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sesion.EndSession(ctx)
+	_, err = sesion.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, s.escribirEstructura(sc, objectID, actual, destino)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.historial.guardar(ctx, objectID, fmt.Sprintf("restauración de la versión %d", numero), email)
+}
+
+// escribirEstructura deja en MongoDB el curso, sus unidades y sus clases como en la estructura. Las
+// unidades y clases actuales que no están en ella se desenlazan: dejan de figurar en las listas del
+// curso y de sus unidades y pierden su curso o su unidad, de modo que ninguna consulta las alcanza,
+// pero sus documentos se conservan para volver a enlazarlos si se restaura una versión que las incluye.
+func (s *VersionService) escribirEstructura(ctx context.Context, cursoID primitive.ObjectID, actual, estructura *models.EstructuraCurso) error {
+	if err := s.desenlazarSobrantes(ctx, actual, estructura); err != nil {
+		return err
+	}
+
+	idsUnidades := []primitive.ObjectID{}
+	duracionCurso, cantClases := 0, 0
+	for _, unidad := range estructura.Unidades {
+		idsClases := []primitive.ObjectID{}
+		duracionUnidad := 0
+		for _, clase := range unidad.Clases {
+			if err := s.restaurarClase(ctx, unidad.ID, clase); err != nil {
+				return err
+			}
+			idsClases = append(idsClases, clase.ID)
+			duracionUnidad += clase.Duracion
+		}
+
+		cambios := bson.M{"$set": bson.M{
+			"idcurso":  cursoID,
+			"nombre":   unidad.Nombre,
+			"clases":   idsClases,
+			"duracion": duracionUnidad,
+		}}
+		if unidad.Desbloqueo != nil {
+			cambios["$set"].(bson.M)["desbloqueo"] = unidad.Desbloqueo
+		} else {
+			cambios["$unset"] = bson.M{"desbloqueo": ""}
+		}
+		if _, err := s.UnidadCollection.UpdateByID(ctx, unidad.ID, cambios, options.Update().SetUpsert(true)); err != nil {
+			return err
+		}
+
+		idsUnidades = append(idsUnidades, unidad.ID)
+		duracionCurso += duracionUnidad
+		cantClases += len(unidad.Clases)
+	}
+
+	_, err := s.CursoCollection.UpdateByID(ctx, cursoID, bson.M{"$set": bson.M{
+		"nombre":      estructura.Nombre,
+		"descripcion": estructura.Descripcion,
+		"unidades":    idsUnidades,
+		"duracion":    duracionCurso,
+		"cant_clases": cantClases,
+	}})
+	return err
+}
+
+// desenlazarSobrantes quita la unidad de las clases y el curso de las unidades que están en la
+// estructura actual pero no en la que se restaura.
+func (s *VersionService) desenlazarSobrantes(ctx context.Context, actual, estructura *models.EstructuraCurso) error {
+	unidadesDestino := map[primitive.ObjectID]bool{}
+	clasesDestino := map[primitive.ObjectID]bool{}
+	for _, unidad := range estructura.Unidades {
+		unidadesDestino[unidad.ID] = true
+		for _, clase := range unidad.Clases {
+			clasesDestino[clase.ID] = true
+		}
+	}
+
+	unidadesSobrantes := []primitive.ObjectID{}
+	clasesSobrantes := []primitive.ObjectID{}
+	for _, unidad := range actual.Unidades {
+		if !unidadesDestino[unidad.ID] {
+			unidadesSobrantes = append(unidadesSobrantes, unidad.ID)
+		}
+		for _, clase := range unidad.Clases {
+			if !clasesDestino[clase.ID] {
+				clasesSobrantes = append(clasesSobrantes, clase.ID)
+			}
+		}
+	}
+
+	if len(clasesSobrantes) > 0 {
+		_, err := s.ClaseCollection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": clasesSobrantes}}, bson.M{"$unset": bson.M{"unidad_id": ""}})
+		if err != nil {
+			return err
+		}
+	}
+	if len(unidadesSobrantes) > 0 {
+		_, err := s.UnidadCollection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": unidadesSobrantes}}, bson.M{"$unset": bson.M{"idcurso": ""}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restaurarClase devuelve una clase a los datos de la versión, o la vuelve a crear con listas vacías
+// si se había eliminado.
+func (s *VersionService) restaurarClase(ctx context.Context, unidadID primitive.ObjectID, clase models.EstructuraClase) error {
+	_, err := s.ClaseCollection.UpdateByID(ctx, clase.ID, bson.M{
+		"$set": bson.M{
+			"unidad_id":   unidadID,
+			"nombre":      clase.Nombre,
+			"descripcion": clase.Descripcion,
+			"video_url":   clase.VideoURL,
+			"duracion":    clase.Duracion,
+		},
+		"$setOnInsert": bson.M{
+			"adjuntos_url": []string{},
+			"adjuntos":     []models.Adjunto{},
+			"subtitulos":   []models.PistaSubtitulos{},
+			"comentarios":  []primitive.ObjectID{},
+			"me_gusta":     0,
+			"no_me_gusta":  0,
+		},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+// sincronizarEstructuraEnNeo4j deja en Neo4j las unidades y clases de la estructura enlazadas con su
+// curso. Las relaciones de las clases con otras unidades se reemplazan, y las unidades y clases que no
+// están en la estructura se desenlazan del curso conservando sus nodos y sus comentarios.
+func (s *VersionService) sincronizarEstructuraEnNeo4j(ctx context.Context, cursoID primitive.ObjectID, estructura *models.EstructuraCurso) error {
+	unidades := []map[string]interface{}{}
+	idsUnidades := []string{}
+	idsClases := []string{}
+	for _, unidad := range estructura.Unidades {
+		idsUnidades = append(idsUnidades, unidad.ID.Hex())
+		clases := []map[string]interface{}{}
+		for _, clase := range unidad.Clases {
+			clases = append(clases, map[string]interface{}{"id": clase.ID.Hex(), "nombre": clase.Nombre})
+			idsClases = append(idsClases, clase.ID.Hex())
+		}
+		unidades = append(unidades, map[string]interface{}{
+			"id":     unidad.ID.Hex(),
+			"nombre": unidad.Nombre,
+			"clases": clases,
+		})
+	}
+
+	session := s.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, `
+            MATCH (:Curso {id: $cursoID})-[r:CONTENEDOR_DE]->(u:Unidad)
+            WHERE NOT u.id IN $unidades
+            DELETE r
+        `, map[string]interface{}{"cursoID": cursoID.Hex(), "unidades": idsUnidades})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Run(ctx, `
+            MATCH (u:Unidad)-[r:CONTENEDOR_DE]->(cl:Clase)
+            WHERE cl.id IN $clases OR (u.id IN $unidades AND NOT cl.id IN $clases)
+            DELETE r
+        `, map[string]interface{}{"clases": idsClases, "unidades": idsUnidades})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Run(ctx, `
+            MERGE (c:Curso {id: $cursoID})
+            SET c.nombre = $nombre
+            WITH c
+            UNWIND $unidades AS unidad
+            MERGE (u:Unidad {id: unidad.id})
+            SET u.nombre = unidad.nombre
+            MERGE (c)-[:CONTENEDOR_DE]->(u)
+            WITH u, unidad
+            UNWIND unidad.clases AS clase
+            MERGE (cl:Clase {id: clase.id})
+            SET cl.nombre = clase.nombre
+            MERGE (u)-[:CONTENEDOR_DE]->(cl)
+        `, map[string]interface{}{
+			"cursoID":  cursoID.Hex(),
+			"nombre":   estructura.Nombre,
+			"unidades": unidades,
+		})
+		return nil, err
+	})
+	return err
+}
+
+func (s *VersionService) verificarInstructor(ctx context.Context, cursoID, email, password string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(cursoID)
+	if err != nil {
+		return primitive.NilObjectID, errors.New("ID inválido")
+	}
+	if err := verificarInstructorCurso(ctx, s.RedisClient, s.CursoCollection, objectID, email, password, errorInstructorVersiones); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return objectID, nil
+}
+
+func (s *VersionService) buscarVersion(ctx context.Context, cursoID primitive.ObjectID, numero int) (*models.VersionCurso, error) {
+	var version models.VersionCurso
+	err := s.VersionCollection.FindOne(ctx, bson.M{"curso_id": cursoID, "numero": numero}).Decode(&version)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.New("versión no encontrada")
+	} else if err != nil {
+		return nil, err
+	}
+	if version.Estructura == nil {
+		version.Estructura = &models.EstructuraCurso{Unidades: []models.EstructuraUnidad{}}
+	}
+	return &version, nil
+}
+
+// compararEstructuras devuelve los cambios para pasar de la estructura anterior a la posterior: primero
+// los datos del curso, luego las unidades y por último las clases, cada una en el orden del curso.
+func compararEstructuras(anterior, posterior *models.EstructuraCurso) []models.CambioVersion {
+	cambios := []models.CambioVersion{}
+
+	campos := []models.CampoModificado{}
+	campos = agregarCampo(campos, "nombre", anterior.Nombre, posterior.Nombre)
+	campos = agregarCampo(campos, "descripcion", anterior.Descripcion, posterior.Descripcion)
+	if len(campos) > 0 {
+		cambios = append(cambios, models.CambioVersion{Elemento: "curso", Tipo: CambioModificado, Nombre: posterior.Nombre, Campos: campos})
+	}
+
+	unidadesAnteriores := map[primitive.ObjectID]models.EstructuraUnidad{}
+	clasesAnteriores := map[primitive.ObjectID]models.EstructuraClase{}
+	unidadDeClase := map[primitive.ObjectID]models.EstructuraUnidad{}
+	for _, unidad := range anterior.Unidades {
+		unidadesAnteriores[unidad.ID] = unidad
+		for _, clase := range unidad.Clases {
+			clasesAnteriores[clase.ID] = clase
+			unidadDeClase[clase.ID] = unidad
+		}
+	}
+	unidadesPosteriores := map[primitive.ObjectID]bool{}
+	clasesPosteriores := map[primitive.ObjectID]bool{}
+
+	cambiosClases := []models.CambioVersion{}
+	for _, unidad := range posterior.Unidades {
+		unidadesPosteriores[unidad.ID] = true
+		if previa, ok := unidadesAnteriores[unidad.ID]; !ok {
+			cambios = append(cambios, models.CambioVersion{Elemento: "unidad", Tipo: CambioAgregado, ID: unidad.ID.Hex(), Nombre: unidad.Nombre})
+		} else {
+			campos := agregarCampo(nil, "nombre", previa.Nombre, unidad.Nombre)
+			if !reflect.DeepEqual(previa.Desbloqueo, unidad.Desbloqueo) {
+				campos = append(campos, models.CampoModificado{Campo: "desbloqueo", Antes: previa.Desbloqueo, Despues: unidad.Desbloqueo})
+			}
+			if len(campos) > 0 {
+				cambios = append(cambios, models.CambioVersion{Elemento: "unidad", Tipo: CambioModificado, ID: unidad.ID.Hex(), Nombre: unidad.Nombre, Campos: campos})
+			}
+		}
+
+		for _, clase := range unidad.Clases {
+			clasesPosteriores[clase.ID] = true
+			cambio := models.CambioVersion{Elemento: "clase", ID: clase.ID.Hex(), Nombre: clase.Nombre, Unidad: unidad.Nombre}
+			previa, ok := clasesAnteriores[clase.ID]
+			if !ok {
+				cambio.Tipo = CambioAgregado
+				cambiosClases = append(cambiosClases, cambio)
+				continue
+			}
+			campos := agregarCampo(nil, "nombre", previa.Nombre, clase.Nombre)
+			campos = agregarCampo(campos, "descripcion", previa.Descripcion, clase.Descripcion)
+			campos = agregarCampo(campos, "video_url", previa.VideoURL, clase.VideoURL)
+			if previa.Duracion != clase.Duracion {
+				campos = append(campos, models.CampoModificado{Campo: "duracion", Antes: previa.Duracion, Despues: clase.Duracion})
+			}
+			if unidadPrevia := unidadDeClase[clase.ID]; unidadPrevia.ID != unidad.ID {
+				campos = append(campos, models.CampoModificado{Campo: "unidad", Antes: unidadPrevia.Nombre, Despues: unidad.Nombre})
+			}
+			if len(campos) > 0 {
+				cambio.Tipo = CambioModificado
+				cambio.Campos = campos
+				cambiosClases = append(cambiosClases, cambio)
+			}
+		}
+	}
+
+	for _, unidad := range anterior.Unidades {
+		if !unidadesPosteriores[unidad.ID] {
+			cambios = append(cambios, models.CambioVersion{Elemento: "unidad", Tipo: CambioEliminado, ID: unidad.ID.Hex(), Nombre: unidad.Nombre})
+		}
+		for _, clase := range unidad.Clases {
+			if !clasesPosteriores[clase.ID] {
+				cambiosClases = append(cambiosClases, models.CambioVersion{Elemento: "clase", Tipo: CambioEliminado, ID: clase.ID.Hex(), Nombre: clase.Nombre, Unidad: unidad.Nombre})
+			}
+		}
+	}
+
+	return append(cambios, cambiosClases...)
+}
+
+func agregarCampo(campos []models.CampoModificado, campo, antes, despues string) []models.CampoModificado {
+	if antes == despues {
+		return campos
+	}
+	return append(campos, models.CampoModificado{Campo: campo, Antes: antes, Despues: despues})
+}
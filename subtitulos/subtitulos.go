@@ -0,0 +1,224 @@
+// Package subtitulos lee subtítulos en formato WebVTT y SRT y los escribe en WebVTT, el formato que
+// entienden los reproductores web.
+package subtitulos
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatoVTT = "vtt"
+	FormatoSRT = "srt"
+)
+
+// Segmento es un fragmento de texto que se muestra entre dos instantes del video.
+type Segmento struct {
+	Inicio time.Duration
+	Fin    time.Duration
+	Texto  string
+}
+
+// posicionSRT reconoce las etiquetas de posición de SRT ({\an8}), que WebVTT no admite.
+var posicionSRT = regexp.MustCompile(`\{\\[^}]*\}`)
+
+// etiquetas reconoce las etiquetas de formato de WebVTT (<i>, <v Orador>, <00:01.000>...) y las
+// de posición de SRT ({\an8}).
+var etiquetas = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+
+// TextoPlano devuelve el texto del segmento sin etiquetas de formato y en una sola línea.
+func (s Segmento) TextoPlano() string {
+	return strings.Join(strings.Fields(etiquetas.ReplaceAllString(s.Texto, " ")), " ")
+}
+
+// Parsear lee los segmentos de un archivo de subtítulos en el formato indicado. Devuelve un error
+// con el número de línea si el archivo no es válido o no tiene ningún segmento.
+func Parsear(contenido []byte, formato string) ([]Segmento, error) {
+	texto := strings.TrimPrefix(string(contenido), "\ufeff")
+	texto = strings.ReplaceAll(texto, "\r\n", "\n")
+	texto = strings.ReplaceAll(texto, "\r", "\n")
+	lineas := strings.Split(texto, "\n")
+
+	var segmentos []Segmento
+	var err error
+	switch formato {
+	case FormatoVTT:
+		segmentos, err = parsearVTT(lineas)
+	case FormatoSRT:
+		segmentos, err = parsearSRT(lineas)
+	default:
+		return nil, fmt.Errorf("formato de subtítulos desconocido: %s", formato)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(segmentos) == 0 {
+		return nil, fmt.Errorf("el archivo no tiene segmentos")
+	}
+	return segmentos, nil
+}
+
+func parsearVTT(lineas []string) ([]Segmento, error) {
+	if encabezado := lineas[0]; encabezado != "WEBVTT" && !strings.HasPrefix(encabezado, "WEBVTT ") && !strings.HasPrefix(encabezado, "WEBVTT\t") {
+		return nil, fmt.Errorf("línea 1: falta el encabezado WEBVTT")
+	}
+
+	segmentos := []Segmento{}
+	for _, bloque := range bloques(lineas[1:], 2) {
+		// Los bloques de encabezado, comentarios, estilos y regiones no son segmentos
+		primera := bloque.lineas[0]
+		if !strings.Contains(primera, "-->") && (len(bloque.lineas) == 1 || !strings.Contains(bloque.lineas[1], "-->")) {
+			if esBloqueVTT(primera) || len(segmentos) == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("línea %d: falta la línea de tiempos del segmento", bloque.numero)
+		}
+
+		segmento, err := parsearBloque(bloque, ".")
+		if err != nil {
+			return nil, err
+		}
+		segmentos = append(segmentos, segmento)
+	}
+	return segmentos, nil
+}
+
+func esBloqueVTT(linea string) bool {
+	for _, tipo := range []string{"NOTE", "STYLE", "REGION"} {
+		if linea == tipo || strings.HasPrefix(linea, tipo+" ") || strings.HasPrefix(linea, tipo+"\t") {
+			return true
+		}
+	}
+	return false
+}
+
+func parsearSRT(lineas []string) ([]Segmento, error) {
+	segmentos := []Segmento{}
+	for _, bloque := range bloques(lineas, 1) {
+		segmento, err := parsearBloque(bloque, ",")
+		if err != nil {
+			return nil, err
+		}
+		segmento.Texto = strings.TrimSpace(posicionSRT.ReplaceAllString(segmento.Texto, ""))
+		segmentos = append(segmentos, segmento)
+	}
+	return segmentos, nil
+}
+
+// bloque es un grupo de líneas no vacías consecutivas, con el número de su primera línea.
+type bloque struct {
+	numero int
+	lineas []string
+}
+
+// bloques separa las líneas en bloques delimitados por líneas vacías. primeraLinea es el número de
+// la primera línea recibida en el archivo.
+func bloques(lineas []string, primeraLinea int) []bloque {
+	resultado := []bloque{}
+	var actual *bloque
+	for i, linea := range lineas {
+		if strings.TrimSpace(linea) == "" {
+			actual = nil
+			continue
+		}
+		if actual == nil {
+			resultado = append(resultado, bloque{numero: primeraLinea + i})
+			actual = &resultado[len(resultado)-1]
+		}
+		actual.lineas = append(actual.lineas, linea)
+	}
+	return resultado
+}
+
+// parsearBloque lee un segmento formado por un identificador opcional, la línea de tiempos y el
+// texto. separador es el que precede a los milisegundos: "." en WebVTT y "," en SRT.
+func parsearBloque(b bloque, separador string) (Segmento, error) {
+	lineas, numero := b.lineas, b.numero
+	if !strings.Contains(lineas[0], "-->") {
+		lineas, numero = lineas[1:], numero+1
+	}
+	if len(lineas) == 0 || !strings.Contains(lineas[0], "-->") {
+		return Segmento{}, fmt.Errorf("línea %d: falta la línea de tiempos del segmento", numero)
+	}
+
+	tiempos := strings.SplitN(lineas[0], "-->", 2)
+	inicio, err := parsearTiempo(strings.TrimSpace(tiempos[0]), separador)
+	if err != nil {
+		return Segmento{}, fmt.Errorf("línea %d: %v", numero, err)
+	}
+	// Después del tiempo de fin WebVTT admite parámetros de posición, que se descartan
+	campos := strings.Fields(tiempos[1])
+	if len(campos) == 0 {
+		return Segmento{}, fmt.Errorf("línea %d: falta el tiempo de fin", numero)
+	}
+	fin, err := parsearTiempo(campos[0], separador)
+	if err != nil {
+		return Segmento{}, fmt.Errorf("línea %d: %v", numero, err)
+	}
+	if fin <= inicio {
+		return Segmento{}, fmt.Errorf("línea %d: el tiempo de fin debe ser posterior al de inicio", numero)
+	}
+
+	return Segmento{
+		Inicio: inicio,
+		Fin:    fin,
+		Texto:  strings.Join(lineas[1:], "\n"),
+	}, nil
+}
+
+// parsearTiempo lee un tiempo con el formato [hh:]mm:ss<separador>mmm.
+func parsearTiempo(valor, separador string) (time.Duration, error) {
+	segundos, milisegundos, ok := strings.Cut(valor, separador)
+	if !ok || len(milisegundos) != 3 {
+		return 0, fmt.Errorf("tiempo inválido: %q", valor)
+	}
+
+	partes := strings.Split(segundos, ":")
+	if len(partes) == 2 {
+		partes = append([]string{"0"}, partes...)
+	}
+	if len(partes) != 3 {
+		return 0, fmt.Errorf("tiempo inválido: %q", valor)
+	}
+
+	var numeros [4]int
+	for i, parte := range append(partes, milisegundos) {
+		n, err := strconv.Atoi(parte)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("tiempo inválido: %q", valor)
+		}
+		numeros[i] = n
+	}
+	if numeros[1] > 59 || numeros[2] > 59 {
+		return 0, fmt.Errorf("tiempo inválido: %q", valor)
+	}
+
+	return time.Duration(numeros[0])*time.Hour +
+		time.Duration(numeros[1])*time.Minute +
+		time.Duration(numeros[2])*time.Second +
+		time.Duration(numeros[3])*time.Millisecond, nil
+}
+
+// EscribirVTT escribe los segmentos en formato WebVTT.
+func EscribirVTT(segmentos []Segmento) []byte {
+	var b bytes.Buffer
+	b.WriteString("WEBVTT\n")
+	for _, segmento := range segmentos {
+		fmt.Fprintf(&b, "\n%s --> %s\n", formatearTiempo(segmento.Inicio), formatearTiempo(segmento.Fin))
+		if segmento.Texto != "" {
+			b.WriteString(segmento.Texto)
+			b.WriteString("\n")
+		}
+	}
+	return b.Bytes()
+}
+
+func formatearTiempo(d time.Duration) string {
+	milisegundos := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d",
+		milisegundos/3600000, milisegundos/60000%60, milisegundos/1000%60, milisegundos%1000)
+}
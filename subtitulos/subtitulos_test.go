@@ -0,0 +1,187 @@
+package subtitulos
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsear(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		formato   string
+		contenido string
+		segmentos []Segmento
+		error     string
+	}{
+		{
+			nombre:  "vtt con encabezado y parámetros de posición",
+			formato: FormatoVTT,
+			contenido: "WEBVTT - Clase 1\n\n" +
+				"1\n00:00:01.000 --> 00:00:02.500 align:start\nHola\n\n" +
+				"00:03.000 --> 00:04.000\n<v Ana>Primera línea\nsegunda línea\n",
+			segmentos: []Segmento{
+				{Inicio: time.Second, Fin: 2500 * time.Millisecond, Texto: "Hola"},
+				{Inicio: 3 * time.Second, Fin: 4 * time.Second, Texto: "<v Ana>Primera línea\nsegunda línea"},
+			},
+		},
+		{
+			nombre:  "vtt con BOM y saltos de línea de Windows",
+			formato: FormatoVTT,
+			contenido: "\ufeffWEBVTT\r\n\r\n" +
+				"00:00:01.000 --> 00:00:02.000\r\nHola\r\n",
+			segmentos: []Segmento{{Inicio: time.Second, Fin: 2 * time.Second, Texto: "Hola"}},
+		},
+		{
+			nombre:  "vtt con bloques NOTE, STYLE y REGION",
+			formato: FormatoVTT,
+			contenido: "WEBVTT\n\n" +
+				"NOTE comentario\nen dos líneas\n\n" +
+				"STYLE\n::cue { color: white }\n\n" +
+				"REGION\nid:abajo\n\n" +
+				"00:00:01.000 --> 00:00:02.000\nHola\n\n" +
+				"NOTE otro comentario\n\n" +
+				"00:00:03.000 --> 00:00:04.000\nChau\n",
+			segmentos: []Segmento{
+				{Inicio: time.Second, Fin: 2 * time.Second, Texto: "Hola"},
+				{Inicio: 3 * time.Second, Fin: 4 * time.Second, Texto: "Chau"},
+			},
+		},
+		{
+			nombre:    "vtt sin encabezado",
+			formato:   FormatoVTT,
+			contenido: "00:00:01.000 --> 00:00:02.000\nHola\n",
+			error:     "línea 1: falta el encabezado WEBVTT",
+		},
+		{
+			nombre:    "vtt con encabezado pegado a otro texto",
+			formato:   FormatoVTT,
+			contenido: "WEBVTTX\n\n00:00:01.000 --> 00:00:02.000\nHola\n",
+			error:     "línea 1: falta el encabezado WEBVTT",
+		},
+		{
+			nombre:    "vtt con separador de milisegundos de SRT",
+			formato:   FormatoVTT,
+			contenido: "WEBVTT\n\n00:00:01,000 --> 00:00:02,000\nHola\n",
+			error:     `línea 3: tiempo inválido: "00:00:01,000"`,
+		},
+		{
+			nombre:    "vtt con un bloque sin tiempos después de un segmento",
+			formato:   FormatoVTT,
+			contenido: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHola\n\ntexto suelto\n",
+			error:     "línea 6: falta la línea de tiempos del segmento",
+		},
+		{
+			nombre:    "vtt sin segmentos",
+			formato:   FormatoVTT,
+			contenido: "WEBVTT\n\nNOTE solo un comentario\n",
+			error:     "el archivo no tiene segmentos",
+		},
+		{
+			nombre:  "srt con etiquetas de posición",
+			formato: FormatoSRT,
+			contenido: "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}Hola\n\n" +
+				"2\n01:02:03,004 --> 01:02:04,000\n<i>Chau</i>\n",
+			segmentos: []Segmento{
+				{Inicio: time.Second, Fin: 2 * time.Second, Texto: "Hola"},
+				{Inicio: time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond, Fin: time.Hour + 2*time.Minute + 4*time.Second, Texto: "<i>Chau</i>"},
+			},
+		},
+		{
+			nombre:    "srt con separador de milisegundos de WebVTT",
+			formato:   FormatoSRT,
+			contenido: "1\n00:00:01.000 --> 00:00:02.000\nHola\n",
+			error:     `línea 2: tiempo inválido: "00:00:01.000"`,
+		},
+		{
+			nombre:    "srt con minutos fuera de rango",
+			formato:   FormatoSRT,
+			contenido: "1\n00:60:00,000 --> 01:00:01,000\nHola\n",
+			error:     `línea 2: tiempo inválido: "00:60:00,000"`,
+		},
+		{
+			nombre:    "srt con milisegundos incompletos",
+			formato:   FormatoSRT,
+			contenido: "1\n00:00:01,00 --> 00:00:02,000\nHola\n",
+			error:     `línea 2: tiempo inválido: "00:00:01,00"`,
+		},
+		{
+			nombre:    "srt sin tiempo de fin",
+			formato:   FormatoSRT,
+			contenido: "1\n00:00:01,000 -->\nHola\n",
+			error:     "línea 2: falta el tiempo de fin",
+		},
+		{
+			nombre:    "srt sin línea de tiempos",
+			formato:   FormatoSRT,
+			contenido: "1\nHola\n",
+			error:     "línea 2: falta la línea de tiempos del segmento",
+		},
+		{
+			nombre:    "srt con fin igual al inicio",
+			formato:   FormatoSRT,
+			contenido: "1\n00:00:02,000 --> 00:00:02,000\nHola\n",
+			error:     "línea 2: el tiempo de fin debe ser posterior al de inicio",
+		},
+		{
+			nombre:    "vtt con fin anterior al inicio",
+			formato:   FormatoVTT,
+			contenido: "WEBVTT\n\n00:00:03.000 --> 00:00:02.000\nHola\n",
+			error:     "línea 3: el tiempo de fin debe ser posterior al de inicio",
+		},
+		{
+			nombre:    "formato desconocido",
+			formato:   "ass",
+			contenido: "[Script Info]\n",
+			error:     "formato de subtítulos desconocido: ass",
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			segmentos, err := Parsear([]byte(caso.contenido), caso.formato)
+			if caso.error != "" {
+				if err == nil || err.Error() != caso.error {
+					t.Fatalf("error = %v, se esperaba %q", err, caso.error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if !reflect.DeepEqual(segmentos, caso.segmentos) {
+				t.Errorf("segmentos = %+v, se esperaba %+v", segmentos, caso.segmentos)
+			}
+		})
+	}
+}
+
+func TestTextoPlano(t *testing.T) {
+	segmento := Segmento{Texto: "<v Ana><i>Hola</i>\n{\\an8}a  todos<00:00:01.500>"}
+	if texto := segmento.TextoPlano(); texto != "Hola a todos" {
+		t.Errorf("TextoPlano() = %q, se esperaba %q", texto, "Hola a todos")
+	}
+}
+
+func TestEscribirVTT(t *testing.T) {
+	segmentos := []Segmento{
+		{Inicio: 1500 * time.Millisecond, Fin: time.Hour + 2*time.Second, Texto: "Hola"},
+		{Inicio: 2 * time.Hour, Fin: 2*time.Hour + time.Second},
+	}
+
+	escrito := string(EscribirVTT(segmentos))
+	esperado := "WEBVTT\n\n00:00:01.500 --> 01:00:02.000\nHola\n\n02:00:00.000 --> 02:00:01.000\n"
+	if escrito != esperado {
+		t.Fatalf("EscribirVTT() = %q, se esperaba %q", escrito, esperado)
+	}
+
+	// Lo escrito debe poder leerse de nuevo sin cambios
+	leidos, err := Parsear([]byte(escrito), FormatoVTT)
+	if err != nil {
+		t.Fatalf("error al leer lo escrito: %v", err)
+	}
+	if !reflect.DeepEqual(leidos, segmentos) {
+		t.Errorf("segmentos leídos = %+v, se esperaba %+v", leidos, segmentos)
+	}
+
+}